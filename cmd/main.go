@@ -6,17 +6,37 @@ import (
 	"flove/job/internal/api/http"
 	"flove/job/internal/auth"
 	"flove/job/internal/base/database"
+	"flove/job/internal/collection"
+	"flove/job/internal/comment"
+	"flove/job/internal/deadletter"
+	"flove/job/internal/ingredient"
+	"flove/job/internal/mealplan"
+	"flove/job/internal/media"
+	"flove/job/internal/moderation"
+	"flove/job/internal/pantry"
 	"flove/job/internal/recipe"
 	"flove/job/internal/recommendation"
+	"flove/job/internal/shopping"
+	"flove/job/internal/substitution"
 	"flove/job/internal/user"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
-	"strings"
 
 	authImpl "flove/job/internal/auth/impl"
+	collectionImpl "flove/job/internal/collection/impl"
+	commentImpl "flove/job/internal/comment/impl"
+	deadLetterImpl "flove/job/internal/deadletter/impl"
+	ingredientImpl "flove/job/internal/ingredient/impl"
+	mealPlanImpl "flove/job/internal/mealplan/impl"
+	mediaImpl "flove/job/internal/media/impl"
+	moderationImpl "flove/job/internal/moderation/impl"
+	pantryImpl "flove/job/internal/pantry/impl"
 	recipeImpl "flove/job/internal/recipe/impl"
 	recommendationImpl "flove/job/internal/recommendation/impl"
+	shoppingImpl "flove/job/internal/shopping/impl"
+	substitutionImpl "flove/job/internal/substitution/impl"
 	userImpl "flove/job/internal/user/impl"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
@@ -35,99 +55,126 @@ import (
 // @in cookie
 // @name Authorization
 
-func subscribeToRecipes(eventBus *database.EventBus, neo4jDriver neo4j.DriverWithContext) {
-	eventBus.Subscribe("recipe:created", func(message string) {
-		input := strings.Split(message, ":")
-		recipeID := input[0]
-		name := input[1]
-		category := input[2]
-		tags := strings.Split(input[3], ",")
+// list keeps empty event fields as empty lists on the graph nodes.
+func list(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
 
-		session := neo4jDriver.NewSession(context.TODO(), neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
-		_, err := session.ExecuteWrite(context.TODO(),
-			func(tx neo4j.ManagedTransaction) (any, error) {
-				query := `CREATE (r:Recipe {recipeID: $id, name: $name, category: $category, tags: $tags})`
-				params := map[string]any{
-					"id":       recipeID,
-					"name":     name,
-					"category": category,
-					"tags":     tags,
-				}
+	return values
+}
 
-				_, err := tx.Run(context.TODO(), query, params)
-				return nil, err
-			})
+// syncRecipe writes a created or updated recipe to the graph. Only published recipes are
+// recommended, so the node of a recipe in any other status is removed.
+func syncRecipe(neo4jDriver neo4j.DriverWithContext, snapshot recipe.RecipeSnapshot) error {
+	session := neo4jDriver.NewSession(context.TODO(), neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	_, err := session.ExecuteWrite(context.TODO(),
+		func(tx neo4j.ManagedTransaction) (any, error) {
+			query := `MATCH (r:Recipe {recipeID: $id}) DETACH DELETE r`
+			if snapshot.Status == recipe.StatusPublished {
+				query = `MERGE (r:Recipe {recipeID: $id}) SET r.name = $name, r.category = $category, r.tags = $tags, r.allergens = $allergens, r.diet_labels = $diets, r.ingredient_ids = $ingredients, r.calories_per_serving = $calories`
+			}
+
+			params := map[string]any{
+				"id":          snapshot.ID,
+				"name":        snapshot.Name,
+				"category":    snapshot.Category,
+				"tags":        list(snapshot.Tags),
+				"allergens":   list(snapshot.Allergens),
+				"diets":       list(snapshot.DietLabels),
+				"ingredients": list(snapshot.IngredientIDs),
+				"calories":    snapshot.CaloriesPerServing,
+			}
+
+			_, err := tx.Run(context.TODO(), query, params)
+			return nil, err
+		})
+
+	session.Close(context.TODO())
 
-		if err != nil {
-			log.Printf("Error creating recipe node in Neo4j: %v", err)
-		} else {
-			log.Printf("Successfully created recipe node in Neo4j")
-		}
+	if err != nil {
+		return fmt.Errorf("syncing recipe node in Neo4j: %w", err)
+	}
 
-		session.Close(context.TODO())
+	log.Printf("Successfully synced recipe node in Neo4j")
+	return nil
+}
+
+func subscribeToRecipes(eventBus *database.EventBus, neo4jDriver neo4j.DriverWithContext) {
+	database.Subscribe(eventBus, "graph", func(_ context.Context, event recipe.RecipeCreated) error {
+		return syncRecipe(neo4jDriver, event.RecipeSnapshot)
+	})
+
+	database.Subscribe(eventBus, "graph", func(_ context.Context, event recipe.RecipeUpdated) error {
+		return syncRecipe(neo4jDriver, event.RecipeSnapshot)
 	})
 
-	eventBus.Subscribe("recipe:deleted", func(message string) {
+	database.Subscribe(eventBus, "graph", func(_ context.Context, event recipe.RecipeDeleted) error {
 		session := neo4jDriver.NewSession(context.TODO(), neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
 		_, err := session.ExecuteWrite(context.TODO(),
 			func(tx neo4j.ManagedTransaction) (any, error) {
-				query := `MATCH (r:Recipe {id: $id}) DELETE r`
-				params := map[string]any{"id": message}
+				query := `MATCH (r:Recipe {recipeID: $id}) DETACH DELETE r`
+				params := map[string]any{"id": event.ID}
 
 				_, err := tx.Run(context.TODO(), query, params)
 				return nil, err
 			})
 
+		session.Close(context.TODO())
+
 		if err != nil {
-			log.Printf("Error deleting recipe node in Neo4j: %v", err)
-		} else {
-			log.Printf("Successfully deleted recipe node in Neo4j")
+			return fmt.Errorf("deleting recipe node in Neo4j: %w", err)
 		}
+
+		log.Printf("Successfully deleted recipe node in Neo4j")
+		return nil
 	})
 }
 
 func subscribeToUsers(eventBus *database.EventBus, neo4jDriver neo4j.DriverWithContext) {
-	eventBus.Subscribe("user:created", func(message string) {
-		input := strings.Split(message, ":")
-		userID := input[0]
-
+	database.Subscribe(eventBus, "graph", func(_ context.Context, event user.UserCreated) error {
 		session := neo4jDriver.NewSession(context.TODO(), neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
 		_, err := session.ExecuteWrite(context.TODO(),
 			func(tx neo4j.ManagedTransaction) (any, error) {
-				query := `CREATE (u:User {userID: $id})`
+				// redelivered events find the node already there
+				query := `MERGE (u:User {userID: $id})`
 				params := map[string]any{
-					"id": userID,
+					"id": event.ID,
 				}
 
 				_, err := tx.Run(context.TODO(), query, params)
 				return nil, err
 			})
 
+		session.Close(context.TODO())
+
 		if err != nil {
-			log.Printf("Error creating user node in Neo4j: %v", err)
-		} else {
-			log.Printf("Successfully created user node in Neo4j")
+			return fmt.Errorf("creating user node in Neo4j: %w", err)
 		}
 
-		session.Close(context.TODO())
+		log.Printf("Successfully created user node in Neo4j")
+		return nil
 	})
 
-	eventBus.Subscribe("user:deleted", func(message string) {
+	database.Subscribe(eventBus, "graph", func(_ context.Context, event user.UserDeleted) error {
 		session := neo4jDriver.NewSession(context.TODO(), neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
 		_, err := session.ExecuteWrite(context.TODO(),
 			func(tx neo4j.ManagedTransaction) (any, error) {
 				query := `MATCH (r:User {userID: $id}) DETACH DELETE r`
-				params := map[string]any{"id": message}
+				params := map[string]any{"id": event.ID}
 
 				_, err := tx.Run(context.TODO(), query, params)
 				return nil, err
 			})
 
+		session.Close(context.TODO())
+
 		if err != nil {
-			log.Printf("Error deleting user node in Neo4j: %v", err)
-		} else {
-			log.Printf("Successfully deleted user node in Neo4j")
+			return fmt.Errorf("deleting user node in Neo4j: %w", err)
 		}
+
+		log.Printf("Successfully deleted user node in Neo4j")
+		return nil
 	})
 }
 
@@ -159,7 +206,23 @@ func main() {
 
 	defer neo4jDriver.Close(context.Background())
 
-	eventBus := database.NewRedisEventBus(redisClient)
+	var eventBus *database.EventBus
+	if cfg.Events.Backend == database.EventBackendPubSub {
+		eventBus = database.NewRedisEventBus(redisClient, cfg.Events.Producer)
+	} else {
+		// local groups are named after the consumer and stay on the streams, a name that
+		// changed with every restart would leave a new group behind each time
+		if cfg.Events.Consumer == "" {
+			panic("EVENTS_CONSUMER must name this instance when the events backend is streams")
+		}
+
+		eventBus = database.NewRedisStreamsEventBus(redisClient, cfg.Events.Producer, database.StreamsOptions{
+			Consumer:    cfg.Events.Consumer,
+			MaxAttempts: cfg.Events.MaxAttempts,
+			Backoff:     cfg.Events.RetryBackoff,
+			MaxLen:      cfg.Events.StreamLength,
+		})
+	}
 	subscribeToRecipes(eventBus, neo4jDriver)
 	subscribeToUsers(eventBus, neo4jDriver)
 
@@ -172,19 +235,106 @@ func main() {
 	tokenUC := authImpl.NewTokenUC(cfg, accessTokenRepo, refreshTokenRepo, userRepo)
 	authHandler := auth.NewTokenHandler(tokenUC, userUC)
 
-	recipeRepo := recipeImpl.NewRecipeRepository(cfg, mongoDB)
-	recipeUC := recipeImpl.NewRecipeUC(cfg, eventBus, recipeRepo)
-	recipeHandler := recipe.NewRecipeHandler(cfg, recipeUC)
+	ingredientRepo, err := ingredientImpl.NewIngredientRepository(cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	ingredientUC := ingredientImpl.NewIngredientUC(cfg, ingredientRepo)
+	ingredientHandler := ingredient.NewIngredientHandler(cfg, ingredientUC)
+
+	searchIndex := recipeImpl.NewSearchIndex(cfg, mongoDB)
+	recipeRepo := recipeImpl.NewRecipeRepository(cfg, mongoDB, searchIndex)
+	if err := recipeImpl.SyncSearchIndex(context.Background(), eventBus, recipeRepo, searchIndex); err != nil {
+		panic(err)
+	}
+
+	revisionRepo := recipeImpl.NewRevisionRepository(cfg, mongoDB)
+	ratingRepo := recipeImpl.NewRatingRepository(cfg, mongoDB)
+	suggestIndex, err := recipeImpl.NewSuggestIndex(context.Background(), eventBus, recipeRepo)
+	if err != nil {
+		panic(err)
+	}
+
+	mediaStorage, err := mediaImpl.NewLocalStorage(cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	imageUC := mediaImpl.NewImageUC(cfg, mediaStorage)
+	mediaHandler := media.NewMediaHandler(imageUC)
+
+	recipeUC := recipeImpl.NewRecipeUC(cfg, eventBus, database.NewTransactor(mongoClient), recipeRepo, revisionRepo, ratingRepo, ingredientRepo, suggestIndex, imageUC)
+	recipeHandler := recipe.NewRecipeHandler(cfg, recipeUC, userUC)
+
+	commentRepo := commentImpl.NewCommentRepository(cfg, mongoDB)
+	commentUC := commentImpl.NewCommentUC(cfg, eventBus, commentRepo, recipeUC)
+	commentHandler := comment.NewCommentHandler(commentUC)
+
+	collectionRepo := collectionImpl.NewCollectionRepository(cfg, mongoDB)
+	collectionUC := collectionImpl.NewCollectionUC(cfg, eventBus, collectionRepo, recipeUC)
+	collectionImpl.WatchRecipes(eventBus, collectionRepo)
+	collectionHandler := collection.NewCollectionHandler(collectionUC)
+
+	mealPlanRepo := mealPlanImpl.NewMealPlanRepository(cfg, mongoDB)
+	mealPlanUC := mealPlanImpl.NewMealPlanUC(cfg, eventBus, mealPlanRepo, recipeUC, userUC)
+	mealPlanImpl.WatchRecipes(eventBus, mealPlanRepo)
+	mealPlanHandler := mealplan.NewMealPlanHandler(mealPlanUC)
+
+	shoppingListRepo := shoppingImpl.NewShoppingListRepository(cfg, mongoDB)
+	shoppingListUC := shoppingImpl.NewShoppingListUC(cfg, shoppingListRepo, recipeUC, mealPlanUC, ingredientUC, userUC)
+	shoppingListHandler := shopping.NewShoppingListHandler(shoppingListUC)
+
+	pantryRepo := pantryImpl.NewPantryRepository(cfg, mongoDB)
+	pantryUC := pantryImpl.NewPantryUC(cfg, pantryRepo, recipeUC, ingredientUC, userUC)
+	pantryHandler := pantry.NewPantryHandler(pantryUC)
+
+	substitutionRepo, err := substitutionImpl.NewRuleRepository(cfg, ingredientRepo)
+	if err != nil {
+		panic(err)
+	}
+
+	substitutionUC := substitutionImpl.NewSubstitutionUC(cfg, substitutionRepo, recipeUC, ingredientUC, userUC)
+	substitutionHandler := substitution.NewSubstitutionHandler(substitutionUC, userUC)
+
+	moderationRepo := moderationImpl.NewModerationRepository(cfg, mongoDB)
+	moderationRules := moderation.NewRuleEngine(
+		moderation.NewBannedWordsRule(cfg.Moderation.BannedWords),
+		moderation.NewLinkSpamRule(cfg.Moderation.MaxLinks),
+		moderation.NewDuplicateRule(moderationRepo),
+	)
+	moderationUC := moderationImpl.NewModerationUC(cfg, eventBus, moderationRepo, moderationRules, map[moderation.Kind]moderation.Target{
+		moderation.KindRecipe:  moderationImpl.NewRecipeTarget(recipeUC),
+		moderation.KindComment: moderationImpl.NewCommentTarget(commentUC),
+	})
+	moderationImpl.WatchRecipes(eventBus, moderationUC)
+	moderationImpl.WatchComments(eventBus, moderationUC)
+	moderationHandler := moderation.NewModerationHandler(moderationUC)
 
 	recommendationRepo := recommendationImpl.NewRecommendationRepository(cfg, neo4jDriver)
 	recommendationUC := recommendationImpl.NewRecommendationUC(cfg, eventBus, recommendationRepo)
-	recommendationHandler := recommendation.NewRecommendationHandler(cfg, recommendationUC)
+	recommendationImpl.WatchRatings(eventBus, recommendationUC)
+	recommendationImpl.WatchCollections(eventBus, recommendationUC)
+	recommendationHandler := recommendation.NewRecommendationHandler(cfg, recommendationUC, userUC)
+
+	deadLetterUC := deadLetterImpl.NewDeadLetterUC(cfg, eventBus)
+	deadLetterHandler := deadletter.NewDeadLetterHandler(deadLetterUC)
 
 	server := http.NewServer(cfg, http.Handlers{
 		UserHandler:           userHandler,
 		TokenHandler:          authHandler,
 		RecipeHandler:         recipeHandler,
 		RecommendationHandler: recommendationHandler,
+		IngredientHandler:     ingredientHandler,
+		ModerationHandler:     moderationHandler,
+		CommentHandler:        commentHandler,
+		MediaHandler:          mediaHandler,
+		CollectionHandler:     collectionHandler,
+		MealPlanHandler:       mealPlanHandler,
+		ShoppingListHandler:   shoppingListHandler,
+		PantryHandler:         pantryHandler,
+		SubstitutionHandler:   substitutionHandler,
+		DeadLetterHandler:     deadLetterHandler,
 	})
 	server.Start()
 	log.Println("server started")
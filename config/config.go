@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
@@ -8,9 +10,13 @@ type Config struct {
 	HttpHost string `env:"HTTP_HOST" env-default:"localhost"`
 	HttpPort int    `env:"HTTP_PORT" env-default:"8080"`
 
-	Mongo DBConfig
-	Redis RedisConfig
-	Neo4j Neo4jConfig
+	Mongo      DBConfig
+	Redis      RedisConfig
+	Neo4j      Neo4jConfig
+	Search     SearchConfig
+	Moderation ModerationConfig
+	Media      MediaConfig
+	Events     EventsConfig
 }
 
 type DBConfig struct {
@@ -26,6 +32,43 @@ type Neo4jConfig struct {
 	URL string `env:"NEO4J_URI" env-required:"true"`
 }
 
+// SearchConfig selects the full-text search backend, embedded or mongo. Path is the
+// directory the embedded engine keeps its files in.
+type SearchConfig struct {
+	Backend string `env:"SEARCH_BACKEND" env-default:"embedded"`
+	Path    string `env:"SEARCH_INDEX_PATH" env-default:"data/search"`
+}
+
+// ModerationConfig tunes the rules that flag content for review. BannedWords is a comma
+// separated list of words and phrases.
+type ModerationConfig struct {
+	BannedWords []string `env:"MODERATION_BANNED_WORDS" env-separator:","`
+	MaxLinks    int      `env:"MODERATION_MAX_LINKS" env-default:"2"`
+}
+
+// MediaConfig sets where uploaded files are kept and the URL they are served from.
+// MaxUploadSize is in bytes.
+type MediaConfig struct {
+	Path          string `env:"MEDIA_PATH" env-default:"data/media"`
+	BaseURL       string `env:"MEDIA_BASE_URL" env-default:"/media"`
+	MaxUploadSize int64  `env:"MEDIA_MAX_UPLOAD_SIZE" env-default:"10485760"`
+}
+
+// EventsConfig describes the event bus. Producer names this service in the envelope of
+// the events it publishes. Backend is streams, the default, or pubsub, which loses the
+// events published while a subscriber is down. Consumer is the stable name of this
+// instance in the consumer groups, required on streams. A handler failing MaxAttempts
+// times sends the event to the dead letters, the wait between attempts starts at
+// RetryBackoff and doubles.
+type EventsConfig struct {
+	Producer     string        `env:"EVENTS_PRODUCER" env-default:"recipe-api"`
+	Backend      string        `env:"EVENTS_BACKEND" env-default:"streams"`
+	Consumer     string        `env:"EVENTS_CONSUMER"`
+	MaxAttempts  int           `env:"EVENTS_MAX_ATTEMPTS" env-default:"5"`
+	RetryBackoff time.Duration `env:"EVENTS_RETRY_BACKOFF" env-default:"1s"`
+	StreamLength int64         `env:"EVENTS_STREAM_LENGTH" env-default:"100000"`
+}
+
 func ParseConfig() (*Config, error) {
 	cfg := new(Config)
 
@@ -3,6 +3,7 @@ module flove/job
 go 1.22.6
 
 require (
+	github.com/blevesearch/go-porterstemmer v1.0.3
 	github.com/gin-gonic/gin v1.10.0
 	github.com/swaggo/files v1.0.1
 	github.com/swaggo/gin-swagger v1.6.0
@@ -2,8 +2,18 @@ package http
 
 import (
 	"flove/job/internal/auth"
+	"flove/job/internal/collection"
+	"flove/job/internal/comment"
+	"flove/job/internal/deadletter"
+	"flove/job/internal/ingredient"
+	"flove/job/internal/mealplan"
+	"flove/job/internal/media"
+	"flove/job/internal/moderation"
+	"flove/job/internal/pantry"
 	"flove/job/internal/recipe"
 	"flove/job/internal/recommendation"
+	"flove/job/internal/shopping"
+	"flove/job/internal/substitution"
 	"flove/job/internal/user"
 )
 
@@ -12,4 +22,14 @@ type Handlers struct {
 	TokenHandler          *auth.AuthHandler
 	RecipeHandler         *recipe.RecipeHandler
 	RecommendationHandler *recommendation.RecommendationHandler
+	IngredientHandler     *ingredient.IngredientHandler
+	ModerationHandler     *moderation.ModerationHandler
+	CommentHandler        *comment.CommentHandler
+	MediaHandler          *media.MediaHandler
+	CollectionHandler     *collection.CollectionHandler
+	MealPlanHandler       *mealplan.MealPlanHandler
+	ShoppingListHandler   *shopping.ShoppingListHandler
+	PantryHandler         *pantry.PantryHandler
+	SubstitutionHandler   *substitution.SubstitutionHandler
+	DeadLetterHandler     *deadletter.DeadLetterHandler
 }
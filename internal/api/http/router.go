@@ -25,6 +25,57 @@ func newRouter(_ *config.Config, h Handlers) *gin.Engine {
 
 	r.PATCH("/admin/users/role/:id", h.TokenHandler.RequireRole(user.RoleAdmin), h.UserHandler.ChangeUserRole)
 
+	r.GET("/admin/moderation/items", h.TokenHandler.RequireRole(user.RoleAdmin), h.ModerationHandler.ListItems)
+	r.POST("/admin/moderation/items/bulk", h.TokenHandler.RequireRole(user.RoleAdmin), h.ModerationHandler.Bulk)
+	r.GET("/admin/moderation/items/:id", h.TokenHandler.RequireRole(user.RoleAdmin), h.ModerationHandler.GetItem)
+	r.POST("/admin/moderation/items/:id/approve", h.TokenHandler.RequireRole(user.RoleAdmin), h.ModerationHandler.Approve)
+	r.POST("/admin/moderation/items/:id/reject", h.TokenHandler.RequireRole(user.RoleAdmin), h.ModerationHandler.Reject)
+	r.POST("/admin/comments/:id/remove", h.TokenHandler.RequireRole(user.RoleAdmin), h.CommentHandler.RemoveComment)
+
+	r.GET("/admin/events/dead-letters", h.TokenHandler.RequireRole(user.RoleAdmin), h.DeadLetterHandler.ListDeadLetters)
+	r.POST("/admin/events/dead-letters/:id/replay", h.TokenHandler.RequireRole(user.RoleAdmin), h.DeadLetterHandler.ReplayDeadLetter)
+	r.DELETE("/admin/events/dead-letters/:id", h.TokenHandler.RequireRole(user.RoleAdmin), h.DeadLetterHandler.DeleteDeadLetter)
+
+	r.GET("/users/:id/collections", h.TokenHandler.RequireAuthenticatedUser(), h.CollectionHandler.ListUserCollections)
+
+	r.POST("/collections", h.TokenHandler.RequireAuthenticatedUser(), h.CollectionHandler.CreateCollection)
+	r.GET("/collections", h.TokenHandler.RequireAuthenticatedUser(), h.CollectionHandler.ListMyCollections)
+	r.GET("/collections/followed", h.TokenHandler.RequireAuthenticatedUser(), h.CollectionHandler.ListFollowed)
+	r.GET("/collections/:id", h.TokenHandler.RequireAuthenticatedUser(), h.CollectionHandler.GetCollection)
+	r.PATCH("/collections/:id", h.TokenHandler.RequireAuthenticatedUser(), h.CollectionHandler.UpdateCollection)
+	r.DELETE("/collections/:id", h.TokenHandler.RequireAuthenticatedUser(), h.CollectionHandler.DeleteCollection)
+	r.POST("/collections/:id/recipes", h.TokenHandler.RequireAuthenticatedUser(), h.CollectionHandler.AddRecipe)
+	r.PATCH("/collections/:id/recipes/:recipeID", h.TokenHandler.RequireAuthenticatedUser(), h.CollectionHandler.UpdateEntry)
+	r.DELETE("/collections/:id/recipes/:recipeID", h.TokenHandler.RequireAuthenticatedUser(), h.CollectionHandler.RemoveRecipe)
+	r.PUT("/collections/:id/follow", h.TokenHandler.RequireAuthenticatedUser(), h.CollectionHandler.Follow)
+	r.DELETE("/collections/:id/follow", h.TokenHandler.RequireAuthenticatedUser(), h.CollectionHandler.Unfollow)
+
+	r.GET("/meal-plans/week", h.TokenHandler.RequireAuthenticatedUser(), h.MealPlanHandler.GetWeek)
+	r.POST("/meal-plans/week/copy", h.TokenHandler.RequireAuthenticatedUser(), h.MealPlanHandler.CopyWeek)
+	r.GET("/meal-plans/days", h.TokenHandler.RequireAuthenticatedUser(), h.MealPlanHandler.GetDays)
+	r.PUT("/meal-plans/days/:date/:meal", h.TokenHandler.RequireAuthenticatedUser(), h.MealPlanHandler.SetSlot)
+	r.DELETE("/meal-plans/days/:date/:meal", h.TokenHandler.RequireAuthenticatedUser(), h.MealPlanHandler.ClearSlot)
+	r.POST("/meal-plans/templates", h.TokenHandler.RequireAuthenticatedUser(), h.MealPlanHandler.CreateTemplate)
+	r.GET("/meal-plans/templates", h.TokenHandler.RequireAuthenticatedUser(), h.MealPlanHandler.ListTemplates)
+	r.DELETE("/meal-plans/templates/:id", h.TokenHandler.RequireAuthenticatedUser(), h.MealPlanHandler.DeleteTemplate)
+	r.POST("/meal-plans/templates/:id/apply", h.TokenHandler.RequireAuthenticatedUser(), h.MealPlanHandler.ApplyTemplate)
+
+	r.POST("/shopping-lists", h.TokenHandler.RequireAuthenticatedUser(), h.ShoppingListHandler.CreateList)
+	r.GET("/shopping-lists", h.TokenHandler.RequireAuthenticatedUser(), h.ShoppingListHandler.ListLists)
+	r.GET("/shopping-lists/:id", h.TokenHandler.RequireAuthenticatedUser(), h.ShoppingListHandler.GetList)
+	r.DELETE("/shopping-lists/:id", h.TokenHandler.RequireAuthenticatedUser(), h.ShoppingListHandler.DeleteList)
+	r.POST("/shopping-lists/:id/items", h.TokenHandler.RequireAuthenticatedUser(), h.ShoppingListHandler.AddItem)
+	r.PATCH("/shopping-lists/:id/items/:itemID", h.TokenHandler.RequireAuthenticatedUser(), h.ShoppingListHandler.UpdateItem)
+	r.DELETE("/shopping-lists/:id/items/:itemID", h.TokenHandler.RequireAuthenticatedUser(), h.ShoppingListHandler.RemoveItem)
+	r.PUT("/shopping-lists/:id/members/:userID", h.TokenHandler.RequireAuthenticatedUser(), h.ShoppingListHandler.Share)
+	r.DELETE("/shopping-lists/:id/members/:userID", h.TokenHandler.RequireAuthenticatedUser(), h.ShoppingListHandler.Unshare)
+
+	r.POST("/pantry", h.TokenHandler.RequireAuthenticatedUser(), h.PantryHandler.AddItem)
+	r.GET("/pantry", h.TokenHandler.RequireAuthenticatedUser(), h.PantryHandler.ListItems)
+	r.GET("/pantry/recipes", h.TokenHandler.RequireAuthenticatedUser(), h.PantryHandler.MatchRecipes)
+	r.PATCH("/pantry/:id", h.TokenHandler.RequireAuthenticatedUser(), h.PantryHandler.UpdateItem)
+	r.DELETE("/pantry/:id", h.TokenHandler.RequireAuthenticatedUser(), h.PantryHandler.DeleteItem)
+
 	r.POST("/auth/sign-in", h.TokenHandler.SignIn)
 	r.POST("/auth/sign-out", h.TokenHandler.SignOut)
 
@@ -32,11 +83,50 @@ func newRouter(_ *config.Config, h Handlers) *gin.Engine {
 	r.GET("/recommendations/preferences", h.TokenHandler.RequireAuthenticatedUser(), h.RecommendationHandler.GetRecommendationByPreferences)
 	r.POST("/recommendations/interaction", h.TokenHandler.RequireAuthenticatedUser(), h.RecommendationHandler.NewInteraction)
 
-	r.POST("/recipes", h.TokenHandler.RequireRole(user.RoleAdmin), h.RecipeHandler.CreateRecipe)
+	r.GET("/ingredients", h.TokenHandler.RequireAuthenticatedUser(), h.IngredientHandler.SearchIngredients)
+	r.GET("/ingredients/:id", h.TokenHandler.RequireAuthenticatedUser(), h.IngredientHandler.GetIngredientByID)
+	r.GET("/ingredients/:id/substitutes", h.TokenHandler.RequireAuthenticatedUser(), h.SubstitutionHandler.ListSubstitutes)
+
+	r.POST("/recipes", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.CreateRecipe)
 	r.GET("/recipes", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.SearchRecipe)
+	r.GET("/recipes/suggest", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.Suggest)
+	r.GET("/recipes/mine", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.ListMyRecipes)
 	r.GET("/recipes/:id", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.GetRecipeByID)
-	r.PATCH("/recipes/:id", h.TokenHandler.RequireRole(user.RoleAdmin), h.RecipeHandler.UpdateRecipe)
-	r.DELETE("/recipes/:id", h.TokenHandler.RequireRole(user.RoleAdmin), h.RecipeHandler.DeleteRecipe)
+	r.PATCH("/recipes/:id", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.UpdateRecipe)
+	r.DELETE("/recipes/:id", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.DeleteRecipe)
+	r.POST("/recipes/:id/status", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.ChangeStatus)
+
+	r.GET("/recipes/:id/ratings", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.ListRatings)
+	r.GET("/recipes/:id/rating", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.GetRating)
+	r.PUT("/recipes/:id/rating", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.RateRecipe)
+	r.DELETE("/recipes/:id/rating", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.DeleteRating)
+
+	r.GET("/recipes/:id/steps", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.GetSteps)
+	r.POST("/recipes/:id/steps", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.AddStep)
+	r.PATCH("/recipes/:id/steps/:stepID", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.UpdateStep)
+	r.DELETE("/recipes/:id/steps/:stepID", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.DeleteStep)
+	r.POST("/recipes/:id/steps/:stepID/images", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.UploadStepImage)
+	r.DELETE("/recipes/:id/steps/:stepID/images/:imageID", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.DeleteStepImage)
+
+	r.POST("/recipes/:id/images", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.UploadRecipeImage)
+	r.DELETE("/recipes/:id/images/:imageID", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.DeleteRecipeImage)
+
+	r.GET("/media/*key", h.MediaHandler.ServeFile)
+	r.GET("/recipes/:id/cooking-mode", h.TokenHandler.RequireAuthenticatedUser(), h.RecipeHandler.GetCookingMode)
+	r.GET("/recipes/:id/substituted", h.TokenHandler.RequireAuthenticatedUser(), h.SubstitutionHandler.SubstituteRecipe)
+
+	r.GET("/recipes/:id/comments", h.TokenHandler.RequireAuthenticatedUser(), h.CommentHandler.ListComments)
+	r.POST("/recipes/:id/comments", h.TokenHandler.RequireAuthenticatedUser(), h.CommentHandler.CreateComment)
+	r.GET("/comments/:id/replies", h.TokenHandler.RequireAuthenticatedUser(), h.CommentHandler.ListReplies)
+	r.PATCH("/comments/:id", h.TokenHandler.RequireAuthenticatedUser(), h.CommentHandler.EditComment)
+	r.DELETE("/comments/:id", h.TokenHandler.RequireAuthenticatedUser(), h.CommentHandler.DeleteComment)
+	r.PUT("/comments/:id/reactions/:reaction", h.TokenHandler.RequireAuthenticatedUser(), h.CommentHandler.React)
+	r.DELETE("/comments/:id/reactions/:reaction", h.TokenHandler.RequireAuthenticatedUser(), h.CommentHandler.Unreact)
+
+	r.GET("/recipes/:id/revisions", h.TokenHandler.RequireRole(user.RoleAdmin), h.RecipeHandler.ListRevisions)
+	r.GET("/recipes/:id/revisions/diff", h.TokenHandler.RequireRole(user.RoleAdmin), h.RecipeHandler.DiffRevisions)
+	r.GET("/recipes/:id/revisions/:number", h.TokenHandler.RequireRole(user.RoleAdmin), h.RecipeHandler.GetRevision)
+	r.POST("/recipes/:id/revisions/:number/rollback", h.TokenHandler.RequireRole(user.RoleAdmin), h.RecipeHandler.RollbackRecipe)
 
 	return r
 }
@@ -0,0 +1,142 @@
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrUnknownEventVersion = errors.New("no upcaster for event version")
+	ErrEventType           = errors.New("event of another type")
+)
+
+// LegacyVersion is the version given to the colon-delimited messages published before
+// events were wrapped in an envelope. Their payload is the message as a JSON string.
+const LegacyVersion = 0
+
+// Envelope wraps every event published on the bus. Type names the event and the topic
+// it goes to, Version the schema of the payload.
+type Envelope struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Version    int             `json:"version"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Producer   string          `json:"producer"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// Event is the payload of an envelope. EventVersion is the schema version the struct
+// stands for, it goes up whenever a field changes meaning or goes away.
+type Event interface {
+	EventType() string
+	EventVersion() int
+}
+
+// Upcaster turns a payload of one schema version into the next one.
+type Upcaster func(payload json.RawMessage) (json.RawMessage, error)
+
+// Upcastable is implemented by events that still read payloads of older schemas, the
+// upcasters are keyed by the version they read.
+type Upcastable interface {
+	Upcasters() map[int]Upcaster
+}
+
+// NewEnvelope wraps an event for publishing.
+func NewEnvelope(event Event, producer string) (Envelope, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	return Envelope{
+		ID:         primitive.NewObjectID().Hex(),
+		Type:       event.EventType(),
+		Version:    event.EventVersion(),
+		OccurredAt: time.Now().UTC(),
+		Producer:   producer,
+		Payload:    payload,
+	}, nil
+}
+
+// ParseEnvelope reads a message received on a topic. Messages that are not an envelope
+// come from producers predating it and are wrapped as LegacyVersion.
+func ParseEnvelope(topic, message string) (Envelope, error) {
+	var envelope Envelope
+	if err := json.Unmarshal([]byte(message), &envelope); err == nil && envelope.Type != "" {
+		return envelope, nil
+	}
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	return Envelope{
+		Type:     topic,
+		Version:  LegacyVersion,
+		Producer: "legacy",
+		Payload:  payload,
+	}, nil
+}
+
+// Decode upcasts the payload of an envelope to the schema of T and decodes it.
+func Decode[T Event](envelope Envelope) (T, error) {
+	var event T
+	if envelope.Type != event.EventType() {
+		return event, fmt.Errorf("%w: %s", ErrEventType, envelope.Type)
+	}
+
+	payload := envelope.Payload
+	if envelope.Version < event.EventVersion() {
+		var upcasters map[int]Upcaster
+		if u, ok := any(event).(Upcastable); ok {
+			upcasters = u.Upcasters()
+		}
+
+		for version := envelope.Version; version < event.EventVersion(); version++ {
+			upcast, ok := upcasters[version]
+			if !ok {
+				return event, fmt.Errorf("%w: %s v%d", ErrUnknownEventVersion, envelope.Type, version)
+			}
+
+			var err error
+			if payload, err = upcast(payload); err != nil {
+				return event, fmt.Errorf("upcasting %s v%d: %w", envelope.Type, version, err)
+			}
+		}
+	}
+
+	// newer payloads decode as long as the fields the consumer knows kept their meaning
+	err := json.Unmarshal(payload, &event)
+	return event, err
+}
+
+// LegacyFields splits the payload of a LegacyVersion message on colons. The last field
+// takes the rest of the message when the message has more fields than n.
+func LegacyFields(payload json.RawMessage, n int) ([]string, error) {
+	var message string
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return nil, err
+	}
+
+	fields := strings.SplitN(message, ":", n)
+	if len(fields) < n {
+		return nil, fmt.Errorf("legacy message has %d fields, %d expected", len(fields), n)
+	}
+
+	return fields, nil
+}
+
+// LegacyList reads a comma separated field of a LegacyVersion message.
+func LegacyList(field string) []string {
+	if field == "" {
+		return []string{}
+	}
+
+	return strings.Split(field, ",")
+}
@@ -2,39 +2,172 @@ package database
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
+const (
+	EventBackendPubSub  = "pubsub"
+	EventBackendStreams = "streams"
+)
+
+// ErrMalformedEvent wraps the errors of events that no retry can deliver, they go to the
+// dead letters straight away.
+var ErrMalformedEvent = errors.New("malformed event")
+
+// DeadLetter is an event a consumer group gave up on. ID is the entry of the dead-letter
+// stream, Error the last error of the handler.
+type DeadLetter struct {
+	ID       string
+	Topic    string
+	Group    string
+	Consumer string
+	Error    string
+	Attempts int
+	FailedAt time.Time
+	Envelope Envelope
+}
+
+// transport carries the envelopes of an EventBus. Handlers run in the group, a transport
+// without groups hands every event to every handler.
+type transport interface {
+	publish(ctx context.Context, topic string, message []byte) error
+	subscribe(ctx context.Context, topic, group string, handler func(ctx context.Context, envelope Envelope) error)
+	deadLetters(ctx context.Context, limit int64) ([]DeadLetter, error)
+	replayDeadLetter(ctx context.Context, id string) error
+	deleteDeadLetter(ctx context.Context, id string) error
+}
+
 type EventBus struct {
-	client *redis.Client
-	ctx    context.Context
+	transport transport
+	ctx       context.Context
+	producer  string
+	consumer  string
+}
+
+// NewRedisEventBus initializes a new RedisEventBus on Pub/Sub. Events published while a
+// subscriber is down are lost and failed handlers are not retried. Producer names this
+// service in the envelope of the events it publishes.
+func NewRedisEventBus(redisClient *redis.Client, producer string) *EventBus {
+	return &EventBus{
+		transport: &pubSubTransport{client: redisClient},
+		ctx:       context.Background(),
+		producer:  producer,
+	}
 }
 
-// NewRedisEventBus initializes a new RedisEventBus
-func NewRedisEventBus(redisClient *redis.Client) *EventBus {
+// NewRedisStreamsEventBus initializes an event bus on Redis Streams. Every consumer group
+// gets each event once, events are kept until the group acknowledges them so nothing is
+// lost while the service is down, and failed handlers are retried before the event goes
+// to the dead letters.
+func NewRedisStreamsEventBus(redisClient *redis.Client, producer string, opts StreamsOptions) *EventBus {
 	return &EventBus{
-		client: redisClient,
-		ctx:    context.Background(),
+		transport: newStreamsTransport(redisClient, opts),
+		ctx:       context.Background(),
+		producer:  producer,
+		consumer:  opts.Consumer,
+	}
+}
+
+// LocalGroup names a consumer group of this instance alone, for handlers keeping state in
+// memory that every instance has to see all events for.
+func (bus *EventBus) LocalGroup(name string) string {
+	if bus.consumer == "" {
+		return name
 	}
+
+	return name + "@" + bus.consumer
 }
 
-func (bus *EventBus) Publish(topic string, message string) error {
-	err := bus.client.Publish(bus.ctx, topic, message).Err()
+// Publish wraps the event in an envelope and sends it to the topic of its type.
+func (bus *EventBus) Publish(event Event) error {
+	envelope, err := NewEnvelope(event, bus.producer)
+	if err != nil {
+		return err
+	}
+
+	message, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	err = bus.transport.publish(bus.ctx, envelope.Type, message)
 	if err != nil {
 		log.Printf("error publishing message: %v", err)
 	}
 	return err
 }
 
-func (bus *EventBus) Subscribe(topic string, handler func(message string)) {
+// Subscribe calls the handler with every event of type T delivered to the consumer group,
+// payloads of older schemas are upcast first. Handlers sharing a group share the events,
+// across instances too.
+func Subscribe[T Event](bus *EventBus, group string, handler func(ctx context.Context, event T) error) {
+	var event T
+	bus.transport.subscribe(bus.ctx, event.EventType(), group, func(ctx context.Context, envelope Envelope) error {
+		decoded, err := Decode[T](envelope)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrMalformedEvent, err)
+		}
+
+		return handler(ctx, decoded)
+	})
+}
+
+// DeadLetters lists the latest events the consumer groups gave up on, newest first.
+func (bus *EventBus) DeadLetters(ctx context.Context, limit int64) ([]DeadLetter, error) {
+	return bus.transport.deadLetters(ctx, limit)
+}
+
+// ReplayDeadLetter delivers a dead letter again to the group that gave up on it and
+// drops it from the dead letters.
+func (bus *EventBus) ReplayDeadLetter(ctx context.Context, id string) error {
+	return bus.transport.replayDeadLetter(ctx, id)
+}
+
+// DeleteDeadLetter drops a dead letter without delivering it.
+func (bus *EventBus) DeleteDeadLetter(ctx context.Context, id string) error {
+	return bus.transport.deleteDeadLetter(ctx, id)
+}
+
+// pubSubTransport is fire and forget, it keeps no dead letters.
+type pubSubTransport struct {
+	client *redis.Client
+}
+
+func (t *pubSubTransport) publish(ctx context.Context, topic string, message []byte) error {
+	return t.client.Publish(ctx, topic, message).Err()
+}
+
+func (t *pubSubTransport) subscribe(ctx context.Context, topic, _ string, handler func(ctx context.Context, envelope Envelope) error) {
 	go func() {
-		subscriber := bus.client.Subscribe(bus.ctx, topic)
+		subscriber := t.client.Subscribe(ctx, topic)
 		defer subscriber.Close()
 
 		for msg := range subscriber.Channel() {
-			handler(msg.Payload)
+			envelope, err := ParseEnvelope(topic, msg.Payload)
+			if err == nil {
+				err = handler(ctx, envelope)
+			}
+			if err != nil {
+				log.Printf("error handling %s event %s: %v", topic, envelope.ID, err)
+			}
 		}
 	}()
 }
+
+func (t *pubSubTransport) deadLetters(context.Context, int64) ([]DeadLetter, error) {
+	return []DeadLetter{}, nil
+}
+
+func (t *pubSubTransport) replayDeadLetter(context.Context, string) error {
+	return ErrNotFound
+}
+
+func (t *pubSubTransport) deleteDeadLetter(context.Context, string) error {
+	return ErrNotFound
+}
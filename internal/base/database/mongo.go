@@ -27,3 +27,30 @@ func NewMongoConnection(connectionString string) (*mongo.Client, error) {
 
 	return client, nil
 }
+
+// Transactor runs functions in Mongo transactions. The context handed to a function
+// carries the session, repositories called with it take part in the transaction.
+// Transactions need Mongo to run as a replica set.
+type Transactor struct {
+	client *mongo.Client
+}
+
+func NewTransactor(client *mongo.Client) *Transactor {
+	return &Transactor{client: client}
+}
+
+// WithTransaction commits the writes of fn together or not at all. Write conflicts with
+// concurrent transactions run fn again, so it must not act outside the database.
+func (t *Transactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := t.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(ctx mongo.SessionContext) (any, error) {
+		return nil, fn(ctx)
+	})
+
+	return err
+}
@@ -0,0 +1,315 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	streamPrefix     = "events:"
+	deadLetterStream = "events:dead-letters"
+
+	readCount = 16
+	readBlock = 5 * time.Second
+)
+
+// streamID matches the IDs of stream entries, anything else cannot name a dead letter.
+var streamID = regexp.MustCompile(`^\d+-\d+$`)
+
+// StreamsOptions tune the Redis Streams event bus. Consumer names this instance within
+// the consumer groups, a handler failing MaxAttempts times sends the event to the dead
+// letters, waiting Backoff after the first failure and twice as long after each next one.
+// Streams are trimmed to about MaxLen entries.
+type StreamsOptions struct {
+	Consumer    string
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxLen      int64
+}
+
+type streamsTransport struct {
+	client *redis.Client
+	opts   StreamsOptions
+	// claimIdle is how long an entry stays pending on a consumer before another one
+	// takes it over, longer than handling a whole read with all its retries takes.
+	claimIdle time.Duration
+}
+
+func newStreamsTransport(client *redis.Client, opts StreamsOptions) *streamsTransport {
+	opts.MaxAttempts = max(opts.MaxAttempts, 1)
+
+	retries := time.Duration(0)
+	for attempt := 1; attempt < opts.MaxAttempts; attempt++ {
+		retries += opts.Backoff << (attempt - 1)
+	}
+
+	// the entries of a read are handled one after the other and stay pending meanwhile,
+	// each of them may go through all its retries
+	return &streamsTransport{
+		client:    client,
+		opts:      opts,
+		claimIdle: max(time.Minute, 2*readCount*retries),
+	}
+}
+
+func (t *streamsTransport) publish(ctx context.Context, topic string, message []byte) error {
+	return t.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: streamPrefix + topic,
+		MaxLen: t.opts.MaxLen,
+		Approx: true,
+		Values: map[string]any{"envelope": message},
+	}).Err()
+}
+
+// subscribe reads the stream of the topic in the consumer group. Entries this consumer
+// read before a restart without acknowledging them are handled first, entries left
+// pending by consumers that went away are claimed as they turn idle. The group is created
+// before subscribe returns, so that it is there before the service starts publishing.
+func (t *streamsTransport) subscribe(ctx context.Context, topic, group string, handler func(ctx context.Context, envelope Envelope) error) {
+	stream := streamPrefix + topic
+	created := t.createGroup(ctx, stream, group)
+
+	go func() {
+		for !created {
+			time.Sleep(readBlock)
+			created = t.createGroup(ctx, stream, group)
+		}
+
+		start := "0"
+		lastClaim := time.Now()
+		for {
+			if time.Since(lastClaim) >= t.claimIdle {
+				t.claim(ctx, stream, topic, group, handler)
+				lastClaim = time.Now()
+			}
+
+			streams, err := t.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+				Group:    group,
+				Consumer: t.opts.Consumer,
+				Streams:  []string{stream, start},
+				Count:    readCount,
+				Block:    readBlock,
+			}).Result()
+			if errors.Is(err, redis.Nil) {
+				continue
+			}
+			if err != nil {
+				log.Printf("error reading %s in group %s: %v", stream, group, err)
+				time.Sleep(readBlock)
+				continue
+			}
+
+			messages := streams[0].Messages
+			if start != ">" {
+				if len(messages) == 0 {
+					start = ">"
+					continue
+				}
+				start = messages[len(messages)-1].ID
+			}
+
+			for _, msg := range messages {
+				t.deliver(ctx, stream, topic, group, msg, handler)
+			}
+		}
+	}()
+}
+
+// createGroup creates the consumer group on the stream unless it exists, and tells whether
+// the group is there. A new group only gets the events published after its creation.
+func (t *streamsTransport) createGroup(ctx context.Context, stream, group string) bool {
+	err := t.client.XGroupCreateMkStream(ctx, stream, group, "$").Err()
+	if err == nil || strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return true
+	}
+
+	log.Printf("error creating consumer group %s on %s: %v", group, stream, err)
+	return false
+}
+
+// claim takes over the entries other consumers of the group left pending for too long.
+func (t *streamsTransport) claim(ctx context.Context, stream, topic, group string, handler func(ctx context.Context, envelope Envelope) error) {
+	cursor := "0-0"
+	for {
+		messages, next, err := t.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   stream,
+			Group:    group,
+			Consumer: t.opts.Consumer,
+			MinIdle:  t.claimIdle,
+			Start:    cursor,
+			Count:    readCount,
+		}).Result()
+		if err != nil {
+			log.Printf("error claiming pending entries of %s in group %s: %v", stream, group, err)
+			return
+		}
+
+		for _, msg := range messages {
+			t.deliver(ctx, stream, topic, group, msg, handler)
+		}
+
+		if next == "0-0" {
+			return
+		}
+		cursor = next
+	}
+}
+
+// deliver hands an entry to the handler, retrying with backoff, and acknowledges it once
+// handled or dead-lettered. An entry that cannot be acknowledged stays pending and is
+// delivered again.
+func (t *streamsTransport) deliver(ctx context.Context, stream, topic, group string, msg redis.XMessage, handler func(ctx context.Context, envelope Envelope) error) {
+	// pending entries trimmed off the stream come back empty, and replayed dead letters
+	// are meant for the group that gave up on them only
+	if target, ok := msg.Values["group"].(string); len(msg.Values) == 0 || ok && target != group {
+		t.ack(ctx, stream, group, msg.ID)
+		return
+	}
+
+	raw, _ := msg.Values["envelope"].(string)
+	envelope, err := ParseEnvelope(topic, raw)
+	if err != nil {
+		err = errors.Join(ErrMalformedEvent, err)
+	}
+
+	attempts := 0
+	for err == nil {
+		attempts++
+		if err = handler(ctx, envelope); err == nil {
+			t.ack(ctx, stream, group, msg.ID)
+			return
+		}
+
+		if attempts >= t.opts.MaxAttempts || errors.Is(err, ErrMalformedEvent) {
+			break
+		}
+
+		backoff := t.opts.Backoff << (attempts - 1)
+		log.Printf("error handling %s event %s in group %s, retrying in %s: %v", topic, envelope.ID, group, backoff, err)
+		time.Sleep(backoff)
+		err = nil
+	}
+
+	log.Printf("error handling %s event %s in group %s, giving up after %d attempts: %v", topic, envelope.ID, group, attempts, err)
+
+	err = t.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: deadLetterStream,
+		MaxLen: t.opts.MaxLen,
+		Approx: true,
+		Values: map[string]any{
+			"topic":     topic,
+			"group":     group,
+			"consumer":  t.opts.Consumer,
+			"error":     err.Error(),
+			"attempts":  attempts,
+			"failed_at": time.Now().UTC().Format(time.RFC3339),
+			"envelope":  raw,
+		},
+	}).Err()
+	if err != nil {
+		log.Printf("error dead-lettering %s event %s: %v", topic, envelope.ID, err)
+		return
+	}
+
+	t.ack(ctx, stream, group, msg.ID)
+}
+
+func (t *streamsTransport) ack(ctx context.Context, stream, group, id string) {
+	if err := t.client.XAck(ctx, stream, group, id).Err(); err != nil {
+		log.Printf("error acknowledging %s entry %s in group %s: %v", stream, id, group, err)
+	}
+}
+
+func toDeadLetter(msg redis.XMessage) DeadLetter {
+	field := func(name string) string {
+		value, _ := msg.Values[name].(string)
+		return value
+	}
+
+	letter := DeadLetter{
+		ID:       msg.ID,
+		Topic:    field("topic"),
+		Group:    field("group"),
+		Consumer: field("consumer"),
+		Error:    field("error"),
+	}
+	letter.Attempts, _ = strconv.Atoi(field("attempts"))
+	letter.FailedAt, _ = time.Parse(time.RFC3339, field("failed_at"))
+	letter.Envelope, _ = ParseEnvelope(letter.Topic, field("envelope"))
+
+	return letter
+}
+
+func (t *streamsTransport) deadLetters(ctx context.Context, limit int64) ([]DeadLetter, error) {
+	messages, err := t.client.XRevRangeN(ctx, deadLetterStream, "+", "-", limit).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	letters := make([]DeadLetter, len(messages))
+	for i, msg := range messages {
+		letters[i] = toDeadLetter(msg)
+	}
+
+	return letters, nil
+}
+
+func (t *streamsTransport) deadLetter(ctx context.Context, id string) (redis.XMessage, error) {
+	if !streamID.MatchString(id) {
+		return redis.XMessage{}, ErrNotFound
+	}
+
+	messages, err := t.client.XRange(ctx, deadLetterStream, id, id).Result()
+	if err != nil {
+		return redis.XMessage{}, err
+	}
+	if len(messages) == 0 {
+		return redis.XMessage{}, ErrNotFound
+	}
+
+	return messages[0], nil
+}
+
+func (t *streamsTransport) replayDeadLetter(ctx context.Context, id string) error {
+	msg, err := t.deadLetter(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	letter := toDeadLetter(msg)
+	_, err = t.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: streamPrefix + letter.Topic,
+			MaxLen: t.opts.MaxLen,
+			Approx: true,
+			Values: map[string]any{"envelope": msg.Values["envelope"], "group": letter.Group},
+		})
+		pipe.XDel(ctx, deadLetterStream, id)
+		return nil
+	})
+
+	return err
+}
+
+func (t *streamsTransport) deleteDeadLetter(ctx context.Context, id string) error {
+	if !streamID.MatchString(id) {
+		return ErrNotFound
+	}
+
+	deleted, err := t.client.XDel(ctx, deadLetterStream, id).Result()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
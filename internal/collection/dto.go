@@ -0,0 +1,14 @@
+package collection
+
+type UpdateCollectionDTO struct {
+	Name        *string
+	Description *string
+	Visibility  *Visibility
+}
+
+// UpdateEntryDTO changes the note of an entry or moves it to another position, positions
+// past the end move it last.
+type UpdateEntryDTO struct {
+	Note     *string
+	Position *int
+}
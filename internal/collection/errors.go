@@ -0,0 +1,12 @@
+package collection
+
+import "errors"
+
+var (
+	ErrForbidden        = errors.New("collection belongs to another user")
+	ErrDuplicateEntry   = errors.New("recipe is already in the collection")
+	ErrCollectionFull   = errors.New("collection is full")
+	ErrNotFollowable    = errors.New("only public collections can be followed")
+	ErrOwnCollection    = errors.New("users cannot follow their own collections")
+	ErrConcurrentUpdate = errors.New("collection was changed by another request, try again")
+)
@@ -0,0 +1,32 @@
+package collection
+
+import (
+	"encoding/json"
+	"flove/job/internal/base/database"
+)
+
+// EventRecipeAdded is the type of the event published when a recipe is saved to a collection.
+const EventRecipeAdded = "collection:recipe_added"
+
+type RecipeAdded struct {
+	CollectionID string `json:"collection_id"`
+	RecipeID     string `json:"recipe_id"`
+	UserID       string `json:"user_id"`
+}
+
+func (RecipeAdded) EventType() string { return EventRecipeAdded }
+func (RecipeAdded) EventVersion() int { return 1 }
+
+// Upcasters reads the legacy collectionID:recipeID:userID messages.
+func (RecipeAdded) Upcasters() map[int]database.Upcaster {
+	return map[int]database.Upcaster{
+		database.LegacyVersion: func(payload json.RawMessage) (json.RawMessage, error) {
+			fields, err := database.LegacyFields(payload, 3)
+			if err != nil {
+				return nil, err
+			}
+
+			return json.Marshal(RecipeAdded{CollectionID: fields[0], RecipeID: fields[1], UserID: fields[2]})
+		},
+	}
+}
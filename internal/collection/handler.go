@@ -0,0 +1,511 @@
+package collection
+
+import (
+	"errors"
+	"flove/job/internal/base/database"
+	"flove/job/internal/base/response"
+	"flove/job/internal/recipe"
+	"flove/job/internal/user"
+	"flove/job/pkg/fp"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CollectionHandler struct {
+	collectionUC CollectionUC
+}
+
+func NewCollectionHandler(uc CollectionUC) *CollectionHandler {
+	return &CollectionHandler{
+		collectionUC: uc,
+	}
+}
+
+// actor identifies the authenticated user making the request.
+func actor(ctx *gin.Context) recipe.Actor {
+	role, _ := ctx.Get("role")
+	r, _ := role.(user.Role)
+
+	return recipe.Actor{
+		UserID: ctx.GetString("userID"),
+		Role:   r,
+	}
+}
+
+// writeError maps the errors of the collection use cases to a response.
+func writeError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrForbidden), errors.Is(err, recipe.ErrForbidden):
+		response.WriteResponse(ctx, http.StatusForbidden, err.Error())
+	case errors.Is(err, ErrDuplicateEntry), errors.Is(err, ErrCollectionFull), errors.Is(err, ErrConcurrentUpdate):
+		response.WriteResponse(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, ErrNotFollowable), errors.Is(err, ErrOwnCollection):
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+	default:
+		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+type entryResponse struct {
+	RecipeID string    `json:"recipe_id"`
+	Note     string    `json:"note"`
+	AddedAt  time.Time `json:"added_at"`
+}
+
+type collectionResponse struct {
+	ID            string          `json:"id"`
+	OwnerID       string          `json:"owner_id"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	Visibility    Visibility      `json:"visibility" example:"private"`
+	Entries       []entryResponse `json:"entries"`
+	FollowerCount int             `json:"follower_count"`
+	Following     bool            `json:"following"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+func toCollectionResponse(c *CollectionModel) collectionResponse {
+	return collectionResponse{
+		ID:          c.ID,
+		OwnerID:     c.OwnerID,
+		Name:        c.Name,
+		Description: c.Description,
+		Visibility:  c.Visibility,
+		Entries: fp.Map(c.Entries, func(e EntryModel) entryResponse {
+			return entryResponse(e)
+		}),
+		FollowerCount: c.FollowerCount,
+		Following:     c.Following,
+		CreatedAt:     c.CreatedAt,
+		UpdatedAt:     c.UpdatedAt,
+	}
+}
+
+type collectionSummaryResponse struct {
+	ID            string     `json:"id"`
+	OwnerID       string     `json:"owner_id"`
+	Name          string     `json:"name"`
+	Description   string     `json:"description"`
+	Visibility    Visibility `json:"visibility" example:"public"`
+	RecipeCount   int        `json:"recipe_count"`
+	FollowerCount int        `json:"follower_count"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+func toCollectionSummaryResponse(c *CollectionModel) collectionSummaryResponse {
+	return collectionSummaryResponse{
+		ID:            c.ID,
+		OwnerID:       c.OwnerID,
+		Name:          c.Name,
+		Description:   c.Description,
+		Visibility:    c.Visibility,
+		RecipeCount:   len(c.Entries),
+		FollowerCount: c.FollowerCount,
+		UpdatedAt:     c.UpdatedAt,
+	}
+}
+
+type createCollectionRequest struct {
+	Name        string     `json:"name" binding:"required,max=100"`
+	Description string     `json:"description" binding:"max=1000"`
+	Visibility  Visibility `json:"visibility" binding:"omitempty,oneof=private unlisted public" example:"private"`
+}
+
+// @Summary Create a collection
+// @Description Create a named collection of recipes, private unless another visibility is given
+// @Security BasicAuth
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param collection body createCollectionRequest true "Collection"
+// @Success 201 {object} response.Response{body=collectionResponse}
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /collections [post]
+func (h *CollectionHandler) CreateCollection(ctx *gin.Context) {
+	var req createCollectionRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	model := &CollectionModel{
+		Name:        req.Name,
+		Description: req.Description,
+		Visibility:  req.Visibility,
+	}
+
+	if err := h.collectionUC.CreateCollection(ctx, actor(ctx), model); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusCreated, "collection succesfully created", toCollectionResponse(model))
+}
+
+// @Summary List my collections
+// @Description List the collections of the user in any visibility, newest first
+// @Security BasicAuth
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.Response{body=[]collectionSummaryResponse}
+// @Failure 500 {object} response.Response
+// @Router /collections [get]
+func (h *CollectionHandler) ListMyCollections(ctx *gin.Context) {
+	collections, err := h.collectionUC.ListMyCollections(ctx, actor(ctx))
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", fp.Map(collections, toCollectionSummaryResponse))
+}
+
+// @Summary List followed collections
+// @Description List the public collections the user follows, most recently followed first
+// @Security BasicAuth
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.Response{body=[]collectionSummaryResponse}
+// @Failure 500 {object} response.Response
+// @Router /collections/followed [get]
+func (h *CollectionHandler) ListFollowed(ctx *gin.Context) {
+	collections, err := h.collectionUC.ListFollowed(ctx, actor(ctx))
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", fp.Map(collections, toCollectionSummaryResponse))
+}
+
+type collectionRequest struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+// @Summary List the collections of a user
+// @Description List the public collections of a user, newest first
+// @Security BasicAuth
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} response.Response{body=[]collectionSummaryResponse}
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/{id}/collections [get]
+func (h *CollectionHandler) ListUserCollections(ctx *gin.Context) {
+	var req collectionRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	collections, err := h.collectionUC.ListUserCollections(ctx, req.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", fp.Map(collections, toCollectionSummaryResponse))
+}
+
+// @Summary Get a collection
+// @Description Get a collection with its recipes in order. Private collections are only
+// @Description visible to their owner.
+// @Security BasicAuth
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Success 200 {object} response.Response{body=collectionResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /collections/{id} [get]
+func (h *CollectionHandler) GetCollection(ctx *gin.Context) {
+	var req collectionRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	collection, err := h.collectionUC.GetCollection(ctx, actor(ctx), req.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", toCollectionResponse(collection))
+}
+
+type updateCollectionRequest struct {
+	ID          string      `uri:"id" binding:"required"`
+	Name        *string     `json:"name" binding:"omitempty,min=1,max=100"`
+	Description *string     `json:"description" binding:"omitempty,max=1000"`
+	Visibility  *Visibility `json:"visibility" binding:"omitempty,oneof=private unlisted public" example:"public"`
+}
+
+// @Summary Update a collection
+// @Description Rename a collection, change its description or its visibility
+// @Security BasicAuth
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Param collection body updateCollectionRequest true "Fields to update"
+// @Success 200 {object} response.Response{body=collectionResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /collections/{id} [patch]
+func (h *CollectionHandler) UpdateCollection(ctx *gin.Context) {
+	var req updateCollectionRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	collection, err := h.collectionUC.UpdateCollection(ctx, actor(ctx), req.ID, UpdateCollectionDTO{
+		Name:        req.Name,
+		Description: req.Description,
+		Visibility:  req.Visibility,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "collection succesfully updated", toCollectionResponse(collection))
+}
+
+// @Summary Delete a collection
+// @Description Delete a collection, the recipes in it are left untouched
+// @Security BasicAuth
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /collections/{id} [delete]
+func (h *CollectionHandler) DeleteCollection(ctx *gin.Context) {
+	var req collectionRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.collectionUC.DeleteCollection(ctx, actor(ctx), req.ID); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponse(ctx, http.StatusOK, "collection succesfully deleted")
+}
+
+type addRecipeRequest struct {
+	ID       string `uri:"id" binding:"required"`
+	RecipeID string `json:"recipe_id" binding:"required"`
+	Note     string `json:"note" binding:"max=1000"`
+	Position *int   `json:"position" binding:"omitempty,gte=0"`
+}
+
+// @Summary Add a recipe to a collection
+// @Description Save a recipe into a collection, at the given position or last. Saving a recipe
+// @Description counts as a SAVED interaction for recommendations.
+// @Security BasicAuth
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Param entry body addRecipeRequest true "Recipe to add"
+// @Success 201 {object} response.Response{body=collectionResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /collections/{id}/recipes [post]
+func (h *CollectionHandler) AddRecipe(ctx *gin.Context) {
+	var req addRecipeRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	collection, err := h.collectionUC.AddRecipe(ctx, actor(ctx), req.ID, EntryModel{
+		RecipeID: req.RecipeID,
+		Note:     req.Note,
+	}, req.Position)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusCreated, "recipe succesfully added", toCollectionResponse(collection))
+}
+
+type updateEntryRequest struct {
+	ID       string  `uri:"id" binding:"required"`
+	RecipeID string  `uri:"recipeID" binding:"required"`
+	Note     *string `json:"note" binding:"omitempty,max=1000"`
+	Position *int    `json:"position" binding:"omitempty,gte=0"`
+}
+
+// @Summary Update a collection entry
+// @Description Change the note of a recipe in a collection or move it to another position
+// @Security BasicAuth
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Param recipeID path string true "Recipe ID"
+// @Param entry body updateEntryRequest true "Fields to update"
+// @Success 200 {object} response.Response{body=collectionResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /collections/{id}/recipes/{recipeID} [patch]
+func (h *CollectionHandler) UpdateEntry(ctx *gin.Context) {
+	var req updateEntryRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	collection, err := h.collectionUC.UpdateEntry(ctx, actor(ctx), req.ID, req.RecipeID, UpdateEntryDTO{
+		Note:     req.Note,
+		Position: req.Position,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "entry succesfully updated", toCollectionResponse(collection))
+}
+
+// @Summary Remove a recipe from a collection
+// @Description Take a recipe out of a collection
+// @Security BasicAuth
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Param recipeID path string true "Recipe ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /collections/{id}/recipes/{recipeID} [delete]
+func (h *CollectionHandler) RemoveRecipe(ctx *gin.Context) {
+	var req struct {
+		ID       string `uri:"id" binding:"required"`
+		RecipeID string `uri:"recipeID" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.collectionUC.RemoveRecipe(ctx, actor(ctx), req.ID, req.RecipeID); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponse(ctx, http.StatusOK, "recipe succesfully removed")
+}
+
+// @Summary Follow a collection
+// @Description Follow a public collection of another user, following twice is a no-op
+// @Security BasicAuth
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /collections/{id}/follow [put]
+func (h *CollectionHandler) Follow(ctx *gin.Context) {
+	var req collectionRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.collectionUC.Follow(ctx, actor(ctx), req.ID); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponse(ctx, http.StatusOK, "collection succesfully followed")
+}
+
+// @Summary Unfollow a collection
+// @Description Stop following a collection
+// @Security BasicAuth
+// @Tags Collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /collections/{id}/follow [delete]
+func (h *CollectionHandler) Unfollow(ctx *gin.Context) {
+	var req collectionRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.collectionUC.Unfollow(ctx, actor(ctx), req.ID); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponse(ctx, http.StatusOK, "collection succesfully unfollowed")
+}
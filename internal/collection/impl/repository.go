@@ -0,0 +1,381 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/collection"
+	"flove/job/pkg/fp"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	collectionsCollection = "collections"
+	followsCollection     = "collection_follows"
+)
+
+type collectionEntity struct {
+	ID            primitive.ObjectID    `bson:"_id,omitempty"`
+	OwnerID       string                `bson:"owner_id"`
+	Name          string                `bson:"name"`
+	Description   string                `bson:"description"`
+	Visibility    collection.Visibility `bson:"visibility"`
+	Entries       []entryEntity         `bson:"entries"`
+	FollowerCount int                   `bson:"follower_count"`
+	CreatedAt     time.Time             `bson:"created_at"`
+	UpdatedAt     time.Time             `bson:"updated_at"`
+}
+
+type entryEntity struct {
+	RecipeID string    `bson:"recipe_id"`
+	Note     string    `bson:"note"`
+	AddedAt  time.Time `bson:"added_at"`
+}
+
+type followEntity struct {
+	CollectionID string    `bson:"collection_id"`
+	UserID       string    `bson:"user_id"`
+	CreatedAt    time.Time `bson:"created_at"`
+}
+
+func (e *collectionEntity) toCollectionModel() *collection.CollectionModel {
+	return &collection.CollectionModel{
+		ID:          e.ID.Hex(),
+		OwnerID:     e.OwnerID,
+		Name:        e.Name,
+		Description: e.Description,
+		Visibility:  e.Visibility,
+		Entries: fp.Map(e.Entries, func(entry entryEntity) collection.EntryModel {
+			return collection.EntryModel(entry)
+		}),
+		FollowerCount: e.FollowerCount,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}
+}
+
+func toEntryEntity(e collection.EntryModel) entryEntity {
+	return entryEntity(e)
+}
+
+func toEntity(c *collection.CollectionModel) *collectionEntity {
+	return &collectionEntity{
+		OwnerID:     c.OwnerID,
+		Name:        c.Name,
+		Description: c.Description,
+		Visibility:  c.Visibility,
+		Entries:     fp.Map(c.Entries, toEntryEntity),
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+type repository struct {
+	config *config.Config
+	db     *mongo.Database
+}
+
+func NewCollectionRepository(config *config.Config, db *mongo.Database) collection.CollectionRepository {
+	ctx := context.Background()
+
+	db.Collection(collectionsCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "owner_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "entries.recipe_id", Value: 1}}},
+	})
+
+	db.Collection(followsCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "collection_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+
+	return &repository{
+		config: config,
+		db:     db,
+	}
+}
+
+func (repo *repository) CreateCollection(ctx context.Context, c *collection.CollectionModel) error {
+	result, err := repo.db.Collection(collectionsCollection).InsertOne(ctx, toEntity(c))
+	if err != nil {
+		return err
+	}
+
+	c.ID = result.InsertedID.(primitive.ObjectID).Hex()
+	return nil
+}
+
+func (repo *repository) GetCollection(ctx context.Context, id string) (*collection.CollectionModel, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, database.ErrNotFound
+	}
+
+	entity := &collectionEntity{}
+	if err := repo.db.Collection(collectionsCollection).FindOne(ctx, bson.M{"_id": objectID}).Decode(entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, database.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return entity.toCollectionModel(), nil
+}
+
+func (repo *repository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*collection.CollectionModel, error) {
+	cursor, err := repo.db.Collection(collectionsCollection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*collectionEntity
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return fp.Map(results, (*collectionEntity).toCollectionModel), nil
+}
+
+func (repo *repository) ListCollections(ctx context.Context, ownerID string, visibilities []collection.Visibility) ([]*collection.CollectionModel, error) {
+	filter := bson.M{"owner_id": ownerID}
+	if len(visibilities) > 0 {
+		filter["visibility"] = bson.M{"$in": visibilities}
+	}
+
+	return repo.find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+}
+
+func (repo *repository) UpdateCollection(ctx context.Context, id string, update collection.UpdateCollectionDTO) (*collection.CollectionModel, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, database.ErrNotFound
+	}
+
+	set := bson.M{"updated_at": time.Now()}
+
+	if update.Name != nil {
+		set["name"] = *update.Name
+	}
+	if update.Description != nil {
+		set["description"] = *update.Description
+	}
+	if update.Visibility != nil {
+		set["visibility"] = *update.Visibility
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	entity := &collectionEntity{}
+	if err := repo.db.Collection(collectionsCollection).FindOneAndUpdate(ctx, bson.M{"_id": objectID}, bson.M{"$set": set}, opts).Decode(entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, database.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return entity.toCollectionModel(), nil
+}
+
+func (repo *repository) DeleteCollection(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return database.ErrNotFound
+	}
+
+	result, err := repo.db.Collection(collectionsCollection).DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return database.ErrNotFound
+	}
+
+	return nil
+}
+
+// updateEntries runs an update on the entries of a collection. A filter that does not
+// match an existing collection is reported with the error given for it.
+func (repo *repository) updateEntries(ctx context.Context, id string, filter, update bson.M, unmatched error, opts ...*options.UpdateOptions) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return database.ErrNotFound
+	}
+
+	filter["_id"] = objectID
+
+	result, err := repo.db.Collection(collectionsCollection).UpdateOne(ctx, filter, update, opts...)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return unmatched
+	}
+
+	return nil
+}
+
+func (repo *repository) AddEntry(ctx context.Context, id string, entry collection.EntryModel, position *int) error {
+	push := bson.M{"$each": []entryEntity{toEntryEntity(entry)}}
+	if position != nil {
+		push["$position"] = *position
+	}
+
+	// the recipe must not be in the collection yet and there must be room left for it
+	filter := bson.M{
+		"entries.recipe_id": bson.M{"$ne": entry.RecipeID},
+		"entries." + strconv.Itoa(collection.MaxEntries-1): bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$push": bson.M{"entries": push},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	return repo.updateEntries(ctx, id, filter, update, collection.ErrConcurrentUpdate)
+}
+
+func (repo *repository) SetEntryNote(ctx context.Context, id, recipeID, note string) error {
+	filter := bson.M{"entries.recipe_id": recipeID}
+	update := bson.M{"$set": bson.M{"entries.$[entry].note": note, "updated_at": time.Now()}}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []any{bson.M{"entry.recipe_id": recipeID}},
+	})
+
+	return repo.updateEntries(ctx, id, filter, update, database.ErrNotFound, opts)
+}
+
+func (repo *repository) ReplaceEntries(ctx context.Context, id string, entries []collection.EntryModel, updatedAt time.Time) error {
+	filter := bson.M{"updated_at": updatedAt}
+	update := bson.M{"$set": bson.M{"entries": fp.Map(entries, toEntryEntity), "updated_at": time.Now()}}
+
+	return repo.updateEntries(ctx, id, filter, update, collection.ErrConcurrentUpdate)
+}
+
+func (repo *repository) RemoveEntry(ctx context.Context, id, recipeID string) error {
+	filter := bson.M{"entries.recipe_id": recipeID}
+	update := bson.M{
+		"$pull": bson.M{"entries": bson.M{"recipe_id": recipeID}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	return repo.updateEntries(ctx, id, filter, update, database.ErrNotFound)
+}
+
+func (repo *repository) RemoveRecipe(ctx context.Context, recipeID string) error {
+	filter := bson.M{"entries.recipe_id": recipeID}
+	update := bson.M{
+		"$pull": bson.M{"entries": bson.M{"recipe_id": recipeID}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	_, err := repo.db.Collection(collectionsCollection).UpdateMany(ctx, filter, update)
+	return err
+}
+
+func (repo *repository) Follow(ctx context.Context, id, userID string) (bool, error) {
+	_, err := repo.db.Collection(followsCollection).InsertOne(ctx, followEntity{
+		CollectionID: id,
+		UserID:       userID,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, repo.incrementFollowers(ctx, id, 1)
+}
+
+func (repo *repository) Unfollow(ctx context.Context, id, userID string) (bool, error) {
+	result, err := repo.db.Collection(followsCollection).DeleteOne(ctx, bson.M{"collection_id": id, "user_id": userID})
+	if err != nil {
+		return false, err
+	}
+
+	if result.DeletedCount == 0 {
+		return false, nil
+	}
+
+	return true, repo.incrementFollowers(ctx, id, -1)
+}
+
+func (repo *repository) incrementFollowers(ctx context.Context, id string, delta int) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return database.ErrNotFound
+	}
+
+	_, err = repo.db.Collection(collectionsCollection).UpdateByID(ctx, objectID, bson.M{"$inc": bson.M{"follower_count": delta}})
+	return err
+}
+
+func (repo *repository) IsFollowing(ctx context.Context, id, userID string) (bool, error) {
+	err := repo.db.Collection(followsCollection).FindOne(ctx, bson.M{"collection_id": id, "user_id": userID}).Err()
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (repo *repository) ListFollowed(ctx context.Context, userID string) ([]*collection.CollectionModel, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := repo.db.Collection(followsCollection).Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var follows []followEntity
+	if err := cursor.All(ctx, &follows); err != nil {
+		return nil, err
+	}
+
+	order := make(map[string]int, len(follows))
+	ids := make([]primitive.ObjectID, 0, len(follows))
+	for i, f := range follows {
+		objectID, err := primitive.ObjectIDFromHex(f.CollectionID)
+		if err != nil {
+			continue
+		}
+		order[f.CollectionID] = i
+		ids = append(ids, objectID)
+	}
+
+	collections, err := repo.find(ctx, bson.M{
+		"_id":        bson.M{"$in": ids},
+		"visibility": collection.VisibilityPublic,
+	}, options.Find())
+	if err != nil {
+		return nil, err
+	}
+
+	// keep the order in which the collections were followed
+	sorted := make([]*collection.CollectionModel, len(follows))
+	for _, c := range collections {
+		c.Following = true
+		sorted[order[c.ID]] = c
+	}
+
+	return fp.Filter(sorted, func(c *collection.CollectionModel) bool { return c != nil }), nil
+}
+
+func (repo *repository) DeleteFollows(ctx context.Context, id string) error {
+	_, err := repo.db.Collection(followsCollection).DeleteMany(ctx, bson.M{"collection_id": id})
+	return err
+}
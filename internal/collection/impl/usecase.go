@@ -0,0 +1,233 @@
+package impl
+
+import (
+	"context"
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/collection"
+	"flove/job/internal/recipe"
+	"fmt"
+	"slices"
+	"time"
+)
+
+type usecase struct {
+	config         *config.Config
+	eventBus       *database.EventBus
+	collectionRepo collection.CollectionRepository
+	recipeUC       recipe.RecipeUC
+}
+
+func NewCollectionUC(config *config.Config, eventBus *database.EventBus, repo collection.CollectionRepository, recipeUC recipe.RecipeUC) collection.CollectionUC {
+	return &usecase{
+		config:         config,
+		eventBus:       eventBus,
+		collectionRepo: repo,
+		recipeUC:       recipeUC,
+	}
+}
+
+// visibleCollection loads a collection the actor can see.
+func (uc *usecase) visibleCollection(ctx context.Context, actor recipe.Actor, id string) (*collection.CollectionModel, error) {
+	c, err := uc.collectionRepo.GetCollection(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.IsVisibleTo(actor.UserID) {
+		return nil, database.ErrNotFound
+	}
+
+	return c, nil
+}
+
+// editableCollection loads a collection the actor is allowed to change.
+func (uc *usecase) editableCollection(ctx context.Context, actor recipe.Actor, id string) (*collection.CollectionModel, error) {
+	c, err := uc.visibleCollection(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.CanEdit(actor.UserID) {
+		return nil, collection.ErrForbidden
+	}
+
+	return c, nil
+}
+
+func (uc *usecase) CreateCollection(ctx context.Context, actor recipe.Actor, c *collection.CollectionModel) error {
+	now := time.Now()
+	c.OwnerID = actor.UserID
+	c.Entries = []collection.EntryModel{}
+	c.FollowerCount = 0
+	c.CreatedAt = now
+	c.UpdatedAt = now
+
+	if c.Visibility == "" {
+		c.Visibility = collection.VisibilityPrivate
+	}
+
+	return uc.collectionRepo.CreateCollection(ctx, c)
+}
+
+func (uc *usecase) GetCollection(ctx context.Context, actor recipe.Actor, id string) (*collection.CollectionModel, error) {
+	c, err := uc.visibleCollection(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.CanEdit(actor.UserID) {
+		if c.Following, err = uc.collectionRepo.IsFollowing(ctx, id, actor.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+func (uc *usecase) ListMyCollections(ctx context.Context, actor recipe.Actor) ([]*collection.CollectionModel, error) {
+	return uc.collectionRepo.ListCollections(ctx, actor.UserID, nil)
+}
+
+func (uc *usecase) ListUserCollections(ctx context.Context, ownerID string) ([]*collection.CollectionModel, error) {
+	return uc.collectionRepo.ListCollections(ctx, ownerID, []collection.Visibility{collection.VisibilityPublic})
+}
+
+func (uc *usecase) UpdateCollection(ctx context.Context, actor recipe.Actor, id string, dto collection.UpdateCollectionDTO) (*collection.CollectionModel, error) {
+	if _, err := uc.editableCollection(ctx, actor, id); err != nil {
+		return nil, err
+	}
+
+	return uc.collectionRepo.UpdateCollection(ctx, id, dto)
+}
+
+func (uc *usecase) DeleteCollection(ctx context.Context, actor recipe.Actor, id string) error {
+	if _, err := uc.editableCollection(ctx, actor, id); err != nil {
+		return err
+	}
+
+	if err := uc.collectionRepo.DeleteCollection(ctx, id); err != nil {
+		return err
+	}
+
+	return uc.collectionRepo.DeleteFollows(ctx, id)
+}
+
+func (uc *usecase) AddRecipe(ctx context.Context, actor recipe.Actor, id string, entry collection.EntryModel, position *int) (*collection.CollectionModel, error) {
+	c, err := uc.editableCollection(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.recipeUC.GetRecipeByID(ctx, actor, entry.RecipeID); err != nil {
+		return nil, err
+	}
+
+	if c.Index(entry.RecipeID) >= 0 {
+		return nil, collection.ErrDuplicateEntry
+	}
+
+	if len(c.Entries) >= collection.MaxEntries {
+		return nil, collection.ErrCollectionFull
+	}
+
+	if position != nil {
+		*position = min(*position, len(c.Entries))
+	}
+
+	entry.AddedAt = time.Now()
+	if err := uc.collectionRepo.AddEntry(ctx, id, entry, position); err != nil {
+		return nil, err
+	}
+
+	// saving a recipe feeds the SAVED interaction of the recommendation graph
+	if err := uc.eventBus.Publish(collection.RecipeAdded{CollectionID: id, RecipeID: entry.RecipeID, UserID: actor.UserID}); err != nil {
+		return nil, err
+	}
+
+	return uc.collectionRepo.GetCollection(ctx, id)
+}
+
+func (uc *usecase) UpdateEntry(ctx context.Context, actor recipe.Actor, id, recipeID string, dto collection.UpdateEntryDTO) (*collection.CollectionModel, error) {
+	c, err := uc.editableCollection(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	i := c.Index(recipeID)
+	if i < 0 {
+		return nil, database.ErrNotFound
+	}
+
+	if dto.Position != nil {
+		// moving rewrites the whole list, the note goes along with it
+		entry := c.Entries[i]
+		if dto.Note != nil {
+			entry.Note = *dto.Note
+		}
+
+		entries := slices.Delete(slices.Clone(c.Entries), i, i+1)
+		entries = slices.Insert(entries, min(*dto.Position, len(entries)), entry)
+
+		if err := uc.collectionRepo.ReplaceEntries(ctx, id, entries, c.UpdatedAt); err != nil {
+			return nil, err
+		}
+	} else if dto.Note != nil {
+		if err := uc.collectionRepo.SetEntryNote(ctx, id, recipeID, *dto.Note); err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.collectionRepo.GetCollection(ctx, id)
+}
+
+func (uc *usecase) RemoveRecipe(ctx context.Context, actor recipe.Actor, id, recipeID string) error {
+	if _, err := uc.editableCollection(ctx, actor, id); err != nil {
+		return err
+	}
+
+	return uc.collectionRepo.RemoveEntry(ctx, id, recipeID)
+}
+
+func (uc *usecase) Follow(ctx context.Context, actor recipe.Actor, id string) error {
+	c, err := uc.visibleCollection(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+
+	if c.OwnerID == actor.UserID {
+		return collection.ErrOwnCollection
+	}
+
+	if c.Visibility != collection.VisibilityPublic {
+		return collection.ErrNotFollowable
+	}
+
+	_, err = uc.collectionRepo.Follow(ctx, id, actor.UserID)
+	return err
+}
+
+func (uc *usecase) Unfollow(ctx context.Context, actor recipe.Actor, id string) error {
+	// a collection that turned private can still be unfollowed
+	if _, err := uc.collectionRepo.GetCollection(ctx, id); err != nil {
+		return err
+	}
+
+	_, err := uc.collectionRepo.Unfollow(ctx, id, actor.UserID)
+	return err
+}
+
+func (uc *usecase) ListFollowed(ctx context.Context, actor recipe.Actor) ([]*collection.CollectionModel, error) {
+	return uc.collectionRepo.ListFollowed(ctx, actor.UserID)
+}
+
+// WatchRecipes takes deleted recipes out of the collections.
+func WatchRecipes(eventBus *database.EventBus, repo collection.CollectionRepository) {
+	database.Subscribe(eventBus, "collections", func(ctx context.Context, event recipe.RecipeDeleted) error {
+		if err := repo.RemoveRecipe(ctx, event.ID); err != nil {
+			return fmt.Errorf("removing recipe %s from collections: %w", event.ID, err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,58 @@
+package collection
+
+import (
+	"slices"
+	"time"
+)
+
+// Visibility tells who can see a collection. Unlisted collections are readable by anyone
+// who has their ID but are not listed on the profile of their owner, only public ones
+// can be followed.
+type Visibility string
+
+const (
+	VisibilityPrivate  Visibility = "private"
+	VisibilityUnlisted Visibility = "unlisted"
+	VisibilityPublic   Visibility = "public"
+)
+
+// MaxEntries bounds the recipes of a collection, they are kept in its document.
+const MaxEntries = 500
+
+// CollectionModel is a named list of recipes a user keeps in their own order. Following
+// tells whether the user reading the collection follows it.
+type CollectionModel struct {
+	ID            string
+	OwnerID       string
+	Name          string
+	Description   string
+	Visibility    Visibility
+	Entries       []EntryModel
+	FollowerCount int
+	Following     bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// EntryModel is a recipe in a collection with the note its owner left on it.
+type EntryModel struct {
+	RecipeID string
+	Note     string
+	AddedAt  time.Time
+}
+
+func (c *CollectionModel) IsVisibleTo(userID string) bool {
+	return c.Visibility != VisibilityPrivate || c.OwnerID == userID
+}
+
+// CanEdit tells whether the user may change the collection, only its owner may.
+func (c *CollectionModel) CanEdit(userID string) bool {
+	return c.OwnerID == userID
+}
+
+// Index returns the position of the recipe in the collection, -1 when it is not in it.
+func (c *CollectionModel) Index(recipeID string) int {
+	return slices.IndexFunc(c.Entries, func(e EntryModel) bool {
+		return e.RecipeID == recipeID
+	})
+}
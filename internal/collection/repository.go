@@ -0,0 +1,34 @@
+package collection
+
+import (
+	"context"
+	"time"
+)
+
+type CollectionRepository interface {
+	CreateCollection(ctx context.Context, collection *CollectionModel) error
+	GetCollection(ctx context.Context, id string) (*CollectionModel, error)
+	// ListCollections lists the collections of the owner in the visibilities, newest first.
+	ListCollections(ctx context.Context, ownerID string, visibilities []Visibility) ([]*CollectionModel, error)
+	UpdateCollection(ctx context.Context, id string, update UpdateCollectionDTO) (*CollectionModel, error)
+	DeleteCollection(ctx context.Context, id string) error
+
+	// AddEntry inserts the recipe at the position, or last when position is nil.
+	AddEntry(ctx context.Context, id string, entry EntryModel, position *int) error
+	SetEntryNote(ctx context.Context, id, recipeID, note string) error
+	// ReplaceEntries writes the entries in a new order unless the collection changed
+	// since updatedAt.
+	ReplaceEntries(ctx context.Context, id string, entries []EntryModel, updatedAt time.Time) error
+	RemoveEntry(ctx context.Context, id, recipeID string) error
+	// RemoveRecipe takes a recipe out of every collection.
+	RemoveRecipe(ctx context.Context, recipeID string) error
+
+	// Follow reports whether the follow is new, following twice is not an error.
+	Follow(ctx context.Context, id, userID string) (bool, error)
+	// Unfollow reports whether there was a follow to remove.
+	Unfollow(ctx context.Context, id, userID string) (bool, error)
+	IsFollowing(ctx context.Context, id, userID string) (bool, error)
+	// ListFollowed lists the public collections the user follows, most recently followed first.
+	ListFollowed(ctx context.Context, userID string) ([]*CollectionModel, error)
+	DeleteFollows(ctx context.Context, id string) error
+}
@@ -0,0 +1,25 @@
+package collection
+
+import (
+	"context"
+	"flove/job/internal/recipe"
+)
+
+type CollectionUC interface {
+	CreateCollection(ctx context.Context, actor recipe.Actor, collection *CollectionModel) error
+	GetCollection(ctx context.Context, actor recipe.Actor, id string) (*CollectionModel, error)
+	ListMyCollections(ctx context.Context, actor recipe.Actor) ([]*CollectionModel, error)
+	// ListUserCollections lists the public collections of another user.
+	ListUserCollections(ctx context.Context, ownerID string) ([]*CollectionModel, error)
+	UpdateCollection(ctx context.Context, actor recipe.Actor, id string, dto UpdateCollectionDTO) (*CollectionModel, error)
+	DeleteCollection(ctx context.Context, actor recipe.Actor, id string) error
+
+	// AddRecipe saves a recipe the actor can see into one of their collections.
+	AddRecipe(ctx context.Context, actor recipe.Actor, id string, entry EntryModel, position *int) (*CollectionModel, error)
+	UpdateEntry(ctx context.Context, actor recipe.Actor, id, recipeID string, dto UpdateEntryDTO) (*CollectionModel, error)
+	RemoveRecipe(ctx context.Context, actor recipe.Actor, id, recipeID string) error
+
+	Follow(ctx context.Context, actor recipe.Actor, id string) error
+	Unfollow(ctx context.Context, actor recipe.Actor, id string) error
+	ListFollowed(ctx context.Context, actor recipe.Actor) ([]*CollectionModel, error)
+}
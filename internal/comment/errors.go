@@ -0,0 +1,11 @@
+package comment
+
+import "errors"
+
+var (
+	ErrForbidden       = errors.New("comment belongs to another user")
+	ErrInvalidCursor   = errors.New("invalid cursor")
+	ErrThreadTooDeep   = errors.New("replies cannot be nested any deeper")
+	ErrUnknownReaction = errors.New("unknown reaction")
+	ErrNotVisible      = errors.New("comment has been deleted")
+)
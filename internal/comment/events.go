@@ -0,0 +1,84 @@
+package comment
+
+import (
+	"encoding/json"
+	"flove/job/internal/base/database"
+)
+
+// Types of the events published by the comment use cases.
+const (
+	EventCreated = "comment:created"
+	EventUpdated = "comment:updated"
+	EventDeleted = "comment:deleted"
+	EventRemoved = "comment:removed"
+)
+
+// CommentSnapshot identifies a comment in the comment:* events.
+type CommentSnapshot struct {
+	ID       string `json:"id"`
+	RecipeID string `json:"recipe_id"`
+	ParentID string `json:"parent_id,omitempty"`
+	AuthorID string `json:"author_id"`
+}
+
+func NewCommentSnapshot(c *CommentModel) CommentSnapshot {
+	return CommentSnapshot{
+		ID:       c.ID,
+		RecipeID: c.RecipeID,
+		ParentID: c.ParentID,
+		AuthorID: c.AuthorID,
+	}
+}
+
+func (CommentSnapshot) EventVersion() int { return 1 }
+
+// Upcasters reads the legacy id:recipeID:parentID:authorID messages.
+func (CommentSnapshot) Upcasters() map[int]database.Upcaster {
+	return map[int]database.Upcaster{
+		database.LegacyVersion: func(payload json.RawMessage) (json.RawMessage, error) {
+			fields, err := database.LegacyFields(payload, 4)
+			if err != nil {
+				return nil, err
+			}
+
+			return json.Marshal(CommentSnapshot{ID: fields[0], RecipeID: fields[1], ParentID: fields[2], AuthorID: fields[3]})
+		},
+	}
+}
+
+type CommentCreated struct{ CommentSnapshot }
+
+func (CommentCreated) EventType() string { return EventCreated }
+
+type CommentUpdated struct{ CommentSnapshot }
+
+func (CommentUpdated) EventType() string { return EventUpdated }
+
+type CommentDeleted struct{ CommentSnapshot }
+
+func (CommentDeleted) EventType() string { return EventDeleted }
+
+// CommentRemoved is published when an admin takes a comment down.
+type CommentRemoved struct {
+	CommentSnapshot
+	AdminID string `json:"admin_id"`
+}
+
+func (CommentRemoved) EventType() string { return EventRemoved }
+
+// Upcasters reads the legacy id:recipeID:parentID:authorID:adminID messages.
+func (CommentRemoved) Upcasters() map[int]database.Upcaster {
+	return map[int]database.Upcaster{
+		database.LegacyVersion: func(payload json.RawMessage) (json.RawMessage, error) {
+			fields, err := database.LegacyFields(payload, 5)
+			if err != nil {
+				return nil, err
+			}
+
+			return json.Marshal(CommentRemoved{
+				CommentSnapshot: CommentSnapshot{ID: fields[0], RecipeID: fields[1], ParentID: fields[2], AuthorID: fields[3]},
+				AdminID:         fields[4],
+			})
+		},
+	}
+}
@@ -0,0 +1,419 @@
+package comment
+
+import (
+	"errors"
+	"flove/job/internal/base/database"
+	"flove/job/internal/base/response"
+	"flove/job/internal/recipe"
+	"flove/job/internal/user"
+	"flove/job/pkg/fp"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CommentHandler struct {
+	commentUC CommentUC
+}
+
+func NewCommentHandler(uc CommentUC) *CommentHandler {
+	return &CommentHandler{
+		commentUC: uc,
+	}
+}
+
+// actor identifies the authenticated user making the request.
+func actor(ctx *gin.Context) recipe.Actor {
+	role, _ := ctx.Get("role")
+	r, _ := role.(user.Role)
+
+	return recipe.Actor{
+		UserID: ctx.GetString("userID"),
+		Role:   r,
+	}
+}
+
+// writeError maps the errors of the comment use cases to a response.
+func writeError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrForbidden):
+		response.WriteResponse(ctx, http.StatusForbidden, err.Error())
+	case errors.Is(err, ErrNotVisible):
+		response.WriteResponse(ctx, http.StatusGone, err.Error())
+	case errors.Is(err, ErrInvalidCursor), errors.Is(err, ErrThreadTooDeep), errors.Is(err, ErrUnknownReaction):
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+	default:
+		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+type commentResponse struct {
+	ID            string         `json:"id"`
+	RecipeID      string         `json:"recipe_id"`
+	ParentID      string         `json:"parent_id,omitempty"`
+	RootID        string         `json:"root_id"`
+	Depth         int            `json:"depth"`
+	AuthorID      string         `json:"author_id"`
+	Text          string         `json:"text"`
+	Status        Status         `json:"status" example:"visible"`
+	RemovalReason string         `json:"removal_reason,omitempty"`
+	ReplyCount    int            `json:"reply_count"`
+	Reactions     map[string]int `json:"reactions"`
+	MyReactions   []string       `json:"my_reactions"`
+	CreatedAt     time.Time      `json:"created_at"`
+	EditedAt      *time.Time     `json:"edited_at,omitempty"`
+}
+
+func toCommentResponse(c *CommentModel) commentResponse {
+	myReactions := c.MyReactions
+	if myReactions == nil {
+		myReactions = []string{}
+	}
+
+	return commentResponse{
+		ID:            c.ID,
+		RecipeID:      c.RecipeID,
+		ParentID:      c.ParentID,
+		RootID:        c.RootID,
+		Depth:         c.Depth,
+		AuthorID:      c.AuthorID,
+		Text:          c.Text,
+		Status:        c.Status,
+		RemovalReason: c.RemovalReason,
+		ReplyCount:    c.ReplyCount,
+		Reactions:     c.Reactions,
+		MyReactions:   myReactions,
+		CreatedAt:     c.CreatedAt,
+		EditedAt:      c.EditedAt,
+	}
+}
+
+type commentPageResponse struct {
+	Comments   []commentResponse `json:"comments"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+func toCommentPageResponse(page *CommentPage) commentPageResponse {
+	return commentPageResponse{
+		Comments:   fp.Map(page.Comments, toCommentResponse),
+		NextCursor: page.NextCursor,
+	}
+}
+
+type listCommentsRequest struct {
+	ID     string `uri:"id" binding:"required"`
+	Cursor string `form:"cursor" binding:"omitempty"`
+	Limit  int64  `form:"limit" binding:"omitempty,gte=0"`
+}
+
+// @Summary List the comments of a recipe
+// @Description List the top level comments of a recipe, newest first. Pass the next_cursor
+// @Description of a page as cursor to get the following page.
+// @Security BasicAuth
+// @Tags Comment
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param cursor query string false "Cursor returned by the previous page"
+// @Param limit query int false "Page size, 20 by default and at most 100"
+// @Success 200 {object} response.Response{body=commentPageResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/comments [get]
+func (h *CommentHandler) ListComments(ctx *gin.Context) {
+	var req listCommentsRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.commentUC.ListComments(ctx, actor(ctx), ListParams{
+		RecipeID: req.ID,
+		Cursor:   req.Cursor,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", toCommentPageResponse(page))
+}
+
+// @Summary List the replies to a comment
+// @Description List the direct replies to a comment, oldest first
+// @Security BasicAuth
+// @Tags Comment
+// @Accept json
+// @Produce json
+// @Param id path string true "Comment ID"
+// @Param cursor query string false "Cursor returned by the previous page"
+// @Param limit query int false "Page size, 20 by default and at most 100"
+// @Success 200 {object} response.Response{body=commentPageResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /comments/{id}/replies [get]
+func (h *CommentHandler) ListReplies(ctx *gin.Context) {
+	var req listCommentsRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.commentUC.ListReplies(ctx, actor(ctx), ListParams{
+		ParentID: req.ID,
+		Cursor:   req.Cursor,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", toCommentPageResponse(page))
+}
+
+type createCommentRequest struct {
+	ID       string `uri:"id" binding:"required"`
+	Text     string `json:"text" binding:"required,max=5000"`
+	ParentID string `json:"parent_id" binding:"omitempty"`
+}
+
+// @Summary Comment on a recipe
+// @Description Post a comment on a recipe, or a reply to one of its comments when parent_id is given
+// @Security BasicAuth
+// @Tags Comment
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param comment body createCommentRequest true "Comment"
+// @Success 201 {object} response.Response{body=commentResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 410 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/comments [post]
+func (h *CommentHandler) CreateComment(ctx *gin.Context) {
+	var req createCommentRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	model := &CommentModel{
+		RecipeID: req.ID,
+		ParentID: req.ParentID,
+		Text:     req.Text,
+	}
+
+	if err := h.commentUC.CreateComment(ctx, actor(ctx), model); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusCreated, "comment succesfully created", toCommentResponse(model))
+}
+
+type editCommentRequest struct {
+	ID   string `uri:"id" binding:"required"`
+	Text string `json:"text" binding:"required,max=5000"`
+}
+
+// @Summary Edit a comment
+// @Description Change the text of a comment, only its author can
+// @Security BasicAuth
+// @Tags Comment
+// @Accept json
+// @Produce json
+// @Param id path string true "Comment ID"
+// @Param comment body editCommentRequest true "Comment"
+// @Success 200 {object} response.Response{body=commentResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 410 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /comments/{id} [patch]
+func (h *CommentHandler) EditComment(ctx *gin.Context) {
+	var req editCommentRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	comment, err := h.commentUC.EditComment(ctx, actor(ctx), req.ID, req.Text)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "comment succesfully updated", toCommentResponse(comment))
+}
+
+type commentRequest struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+// @Summary Delete a comment
+// @Description Delete a comment, only its author can. Replies stay in the thread.
+// @Security BasicAuth
+// @Tags Comment
+// @Accept json
+// @Produce json
+// @Param id path string true "Comment ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 410 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /comments/{id} [delete]
+func (h *CommentHandler) DeleteComment(ctx *gin.Context) {
+	var req commentRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.commentUC.DeleteComment(ctx, actor(ctx), req.ID); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponse(ctx, http.StatusOK, "comment succesfully deleted")
+}
+
+type removeCommentRequest struct {
+	ID     string `uri:"id" binding:"required"`
+	Reason string `json:"reason" binding:"required,max=1000" example:"off topic"`
+}
+
+// @Summary Remove a comment
+// @Description Remove a comment of any user with a reason
+// @Security BasicAuth
+// @Tags Comment
+// @Accept json
+// @Produce json
+// @Param id path string true "Comment ID"
+// @Param request body removeCommentRequest true "Removal reason"
+// @Success 200 {object} response.Response{body=commentResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 410 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/comments/{id}/remove [post]
+func (h *CommentHandler) RemoveComment(ctx *gin.Context) {
+	var req removeCommentRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	comment, err := h.commentUC.RemoveComment(ctx, ctx.GetString("userID"), req.ID, req.Reason)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "comment succesfully removed", toCommentResponse(comment))
+}
+
+type reactionRequest struct {
+	ID       string `uri:"id" binding:"required"`
+	Reaction string `uri:"reaction" binding:"required,oneof=like love helpful funny"`
+}
+
+// @Summary React to a comment
+// @Description Leave a reaction on a comment, reacting twice with the same reaction is a no-op
+// @Security BasicAuth
+// @Tags Comment
+// @Accept json
+// @Produce json
+// @Param id path string true "Comment ID"
+// @Param reaction path string true "Reaction" Enums(like, love, helpful, funny)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 410 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /comments/{id}/reactions/{reaction} [put]
+func (h *CommentHandler) React(ctx *gin.Context) {
+	var req reactionRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.commentUC.React(ctx, actor(ctx), req.ID, req.Reaction); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponse(ctx, http.StatusOK, "reaction succesfully added")
+}
+
+// @Summary Remove a reaction from a comment
+// @Description Take back a reaction left on a comment
+// @Security BasicAuth
+// @Tags Comment
+// @Accept json
+// @Produce json
+// @Param id path string true "Comment ID"
+// @Param reaction path string true "Reaction" Enums(like, love, helpful, funny)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /comments/{id}/reactions/{reaction} [delete]
+func (h *CommentHandler) Unreact(ctx *gin.Context) {
+	var req reactionRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.commentUC.Unreact(ctx, actor(ctx), req.ID, req.Reaction); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponse(ctx, http.StatusOK, "reaction succesfully removed")
+}
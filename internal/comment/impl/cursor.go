@@ -0,0 +1,61 @@
+package impl
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"flove/job/internal/comment"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// listCursor points right after the last comment of a page. The comment ID breaks ties
+// between comments created in the same millisecond.
+type listCursor struct {
+	CreatedAt int64  `json:"t"`
+	ID        string `json:"id"`
+}
+
+func newListCursor(c *commentEntity) listCursor {
+	return listCursor{CreatedAt: c.CreatedAt.UnixMilli(), ID: c.ID.Hex()}
+}
+
+func (c listCursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeListCursor(token string) (*listCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, comment.ErrInvalidCursor
+	}
+
+	var c listCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, comment.ErrInvalidCursor
+	}
+
+	if _, err := primitive.ObjectIDFromHex(c.ID); err != nil {
+		return nil, comment.ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+// after matches the comments following the cursor in the given order.
+func (c *listCursor) after(ascending bool) bson.M {
+	id, _ := primitive.ObjectIDFromHex(c.ID)
+	createdAt := time.UnixMilli(c.CreatedAt)
+
+	op := "$lt"
+	if ascending {
+		op = "$gt"
+	}
+
+	return bson.M{"$or": bson.A{
+		bson.M{"created_at": bson.M{op: createdAt}},
+		bson.M{"created_at": createdAt, "_id": bson.M{op: id}},
+	}}
+}
@@ -0,0 +1,310 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/comment"
+	"flove/job/pkg/fp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	commentsCollection  = "comments"
+	reactionsCollection = "comment_reactions"
+)
+
+type commentEntity struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	RecipeID      string             `bson:"recipe_id"`
+	ParentID      string             `bson:"parent_id"`
+	RootID        string             `bson:"root_id"`
+	Depth         int                `bson:"depth"`
+	AuthorID      string             `bson:"author_id"`
+	Text          string             `bson:"text"`
+	Status        comment.Status     `bson:"status"`
+	RemovalReason string             `bson:"removal_reason,omitempty"`
+	ReplyCount    int                `bson:"reply_count"`
+	Reactions     map[string]int     `bson:"reactions"`
+	CreatedAt     time.Time          `bson:"created_at"`
+	UpdatedAt     time.Time          `bson:"updated_at"`
+	EditedAt      *time.Time         `bson:"edited_at,omitempty"`
+}
+
+type reactionEntity struct {
+	CommentID string    `bson:"comment_id"`
+	UserID    string    `bson:"user_id"`
+	Kind      string    `bson:"kind"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func (e *commentEntity) toCommentModel() *comment.CommentModel {
+	reactions := map[string]int{}
+	for kind, count := range e.Reactions {
+		if count > 0 {
+			reactions[kind] = count
+		}
+	}
+
+	return &comment.CommentModel{
+		ID:            e.ID.Hex(),
+		RecipeID:      e.RecipeID,
+		ParentID:      e.ParentID,
+		RootID:        e.RootID,
+		Depth:         e.Depth,
+		AuthorID:      e.AuthorID,
+		Text:          e.Text,
+		Status:        e.Status,
+		RemovalReason: e.RemovalReason,
+		ReplyCount:    e.ReplyCount,
+		Reactions:     reactions,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+		EditedAt:      e.EditedAt,
+	}
+}
+
+func toEntity(c *comment.CommentModel) *commentEntity {
+	return &commentEntity{
+		RecipeID:   c.RecipeID,
+		ParentID:   c.ParentID,
+		RootID:     c.RootID,
+		Depth:      c.Depth,
+		AuthorID:   c.AuthorID,
+		Text:       c.Text,
+		Status:     c.Status,
+		ReplyCount: c.ReplyCount,
+		Reactions:  map[string]int{},
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
+
+type repository struct {
+	config *config.Config
+	db     *mongo.Database
+}
+
+func NewCommentRepository(config *config.Config, db *mongo.Database) comment.CommentRepository {
+	ctx := context.Background()
+
+	db.Collection(commentsCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "recipe_id", Value: 1},
+			{Key: "parent_id", Value: 1},
+			{Key: "created_at", Value: -1},
+			{Key: "_id", Value: -1},
+		},
+	})
+
+	db.Collection(reactionsCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "comment_id", Value: 1}, {Key: "user_id", Value: 1}, {Key: "kind", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	return &repository{
+		config: config,
+		db:     db,
+	}
+}
+
+func (repo *repository) CreateComment(ctx context.Context, c *comment.CommentModel) error {
+	result, err := repo.db.Collection(commentsCollection).InsertOne(ctx, toEntity(c))
+	if err != nil {
+		return err
+	}
+
+	c.ID = result.InsertedID.(primitive.ObjectID).Hex()
+	if c.RootID == "" {
+		// a top level comment is the root of its own thread
+		c.RootID = c.ID
+		_, err = repo.db.Collection(commentsCollection).UpdateByID(ctx, result.InsertedID, bson.M{"$set": bson.M{"root_id": c.ID}})
+	}
+
+	return err
+}
+
+func (repo *repository) GetComment(ctx context.Context, id string) (*comment.CommentModel, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, database.ErrNotFound
+	}
+
+	entity := &commentEntity{}
+	if err := repo.db.Collection(commentsCollection).FindOne(ctx, bson.M{"_id": objectID}).Decode(entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, database.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return entity.toCommentModel(), nil
+}
+
+func (repo *repository) ListComments(ctx context.Context, params comment.ListParams) (*comment.CommentPage, error) {
+	// threads are read newest first, the replies within a thread in order
+	ascending := params.ParentID != ""
+	direction := -1
+	if ascending {
+		direction = 1
+	}
+
+	filter := bson.M{"recipe_id": params.RecipeID, "parent_id": params.ParentID}
+	if params.Cursor != "" {
+		cursor, err := decodeListCursor(params.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter = bson.M{"$and": bson.A{filter, cursor.after(ascending)}}
+	}
+
+	limit := params.PageSize()
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: direction}, {Key: "_id", Value: direction}}).
+		SetLimit(limit + 1)
+
+	cursor, err := repo.db.Collection(commentsCollection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*commentEntity
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	page := &comment.CommentPage{}
+	if int64(len(results)) > limit {
+		results = results[:limit]
+		page.NextCursor = newListCursor(results[len(results)-1]).encode()
+	}
+	page.Comments = fp.Map(results, (*commentEntity).toCommentModel)
+
+	return page, nil
+}
+
+func (repo *repository) UpdateText(ctx context.Context, id, text string, editedAt time.Time) (*comment.CommentModel, error) {
+	return repo.update(ctx, id, bson.M{"$set": bson.M{
+		"text":       text,
+		"edited_at":  editedAt,
+		"updated_at": editedAt,
+	}})
+}
+
+func (repo *repository) SetStatus(ctx context.Context, id string, status comment.Status, reason string) (*comment.CommentModel, error) {
+	set := bson.M{"status": status, "updated_at": time.Now()}
+	if status != comment.StatusVisible {
+		set["text"] = ""
+	}
+	if reason != "" {
+		set["removal_reason"] = reason
+	}
+
+	return repo.update(ctx, id, bson.M{"$set": set})
+}
+
+func (repo *repository) update(ctx context.Context, id string, update bson.M) (*comment.CommentModel, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, database.ErrNotFound
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	entity := &commentEntity{}
+	if err := repo.db.Collection(commentsCollection).FindOneAndUpdate(ctx, bson.M{"_id": objectID}, update, opts).Decode(entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, database.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return entity.toCommentModel(), nil
+}
+
+func (repo *repository) IncrementReplies(ctx context.Context, id string, delta int) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return database.ErrNotFound
+	}
+
+	result, err := repo.db.Collection(commentsCollection).UpdateByID(ctx, objectID, bson.M{"$inc": bson.M{"reply_count": delta}})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return database.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *repository) AddReaction(ctx context.Context, commentID, userID, kind string) (bool, error) {
+	_, err := repo.db.Collection(reactionsCollection).InsertOne(ctx, reactionEntity{
+		CommentID: commentID,
+		UserID:    userID,
+		Kind:      kind,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, repo.incrementReaction(ctx, commentID, kind, 1)
+}
+
+func (repo *repository) RemoveReaction(ctx context.Context, commentID, userID, kind string) (bool, error) {
+	filter := bson.M{"comment_id": commentID, "user_id": userID, "kind": kind}
+
+	result, err := repo.db.Collection(reactionsCollection).DeleteOne(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+
+	if result.DeletedCount == 0 {
+		return false, nil
+	}
+
+	return true, repo.incrementReaction(ctx, commentID, kind, -1)
+}
+
+func (repo *repository) incrementReaction(ctx context.Context, commentID, kind string, delta int) error {
+	objectID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return database.ErrNotFound
+	}
+
+	_, err = repo.db.Collection(commentsCollection).UpdateByID(ctx, objectID, bson.M{"$inc": bson.M{"reactions." + kind: delta}})
+	return err
+}
+
+func (repo *repository) UserReactions(ctx context.Context, userID string, commentIDs []string) (map[string][]string, error) {
+	filter := bson.M{"user_id": userID, "comment_id": bson.M{"$in": commentIDs}}
+
+	cursor, err := repo.db.Collection(reactionsCollection).Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []reactionEntity
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	reactions := map[string][]string{}
+	for _, r := range results {
+		reactions[r.CommentID] = append(reactions[r.CommentID], r.Kind)
+	}
+
+	return reactions, nil
+}
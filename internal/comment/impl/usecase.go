@@ -0,0 +1,235 @@
+package impl
+
+import (
+	"context"
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/comment"
+	"flove/job/internal/recipe"
+	"fmt"
+	"time"
+)
+
+type usecase struct {
+	config      *config.Config
+	eventBus    *database.EventBus
+	commentRepo comment.CommentRepository
+	recipeUC    recipe.RecipeUC
+}
+
+func NewCommentUC(config *config.Config, eventBus *database.EventBus, repo comment.CommentRepository, recipeUC recipe.RecipeUC) comment.CommentUC {
+	return &usecase{
+		config:      config,
+		eventBus:    eventBus,
+		commentRepo: repo,
+		recipeUC:    recipeUC,
+	}
+}
+
+// visibleComment loads a comment on a recipe the actor can see.
+func (uc *usecase) visibleComment(ctx context.Context, actor recipe.Actor, id string) (*comment.CommentModel, error) {
+	c, err := uc.commentRepo.GetComment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.recipeUC.GetRecipeByID(ctx, actor, c.RecipeID); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (uc *usecase) CreateComment(ctx context.Context, actor recipe.Actor, c *comment.CommentModel) error {
+	if _, err := uc.recipeUC.GetRecipeByID(ctx, actor, c.RecipeID); err != nil {
+		return err
+	}
+
+	if c.ParentID != "" {
+		parent, err := uc.commentRepo.GetComment(ctx, c.ParentID)
+		if err != nil {
+			return err
+		}
+
+		// a reply has to stay on the recipe of its thread
+		if parent.RecipeID != c.RecipeID {
+			return database.ErrNotFound
+		}
+
+		if parent.Status != comment.StatusVisible {
+			return comment.ErrNotVisible
+		}
+
+		if parent.Depth+1 > comment.MaxDepth {
+			return comment.ErrThreadTooDeep
+		}
+
+		c.RootID = parent.RootID
+		c.Depth = parent.Depth + 1
+	}
+
+	now := time.Now()
+	c.AuthorID = actor.UserID
+	c.Status = comment.StatusVisible
+	c.CreatedAt = now
+	c.UpdatedAt = now
+
+	if err := uc.commentRepo.CreateComment(ctx, c); err != nil {
+		return err
+	}
+
+	if c.ParentID != "" {
+		if err := uc.commentRepo.IncrementReplies(ctx, c.ParentID, 1); err != nil {
+			return err
+		}
+	}
+
+	return uc.eventBus.Publish(comment.CommentCreated{CommentSnapshot: comment.NewCommentSnapshot(c)})
+}
+
+func (uc *usecase) GetComment(ctx context.Context, id string) (*comment.CommentModel, error) {
+	return uc.commentRepo.GetComment(ctx, id)
+}
+
+func (uc *usecase) ListComments(ctx context.Context, actor recipe.Actor, params comment.ListParams) (*comment.CommentPage, error) {
+	if _, err := uc.recipeUC.GetRecipeByID(ctx, actor, params.RecipeID); err != nil {
+		return nil, err
+	}
+
+	params.ParentID = ""
+	return uc.list(ctx, actor, params)
+}
+
+func (uc *usecase) ListReplies(ctx context.Context, actor recipe.Actor, params comment.ListParams) (*comment.CommentPage, error) {
+	parent, err := uc.visibleComment(ctx, actor, params.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	params.RecipeID = parent.RecipeID
+	return uc.list(ctx, actor, params)
+}
+
+func (uc *usecase) list(ctx context.Context, actor recipe.Actor, params comment.ListParams) (*comment.CommentPage, error) {
+	params.Limit = params.PageSize()
+
+	page, err := uc.commentRepo.ListComments(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(page.Comments))
+	for i, c := range page.Comments {
+		ids[i] = c.ID
+	}
+
+	reactions, err := uc.commentRepo.UserReactions(ctx, actor.UserID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range page.Comments {
+		c.MyReactions = reactions[c.ID]
+	}
+
+	return page, nil
+}
+
+func (uc *usecase) EditComment(ctx context.Context, actor recipe.Actor, id, text string) (*comment.CommentModel, error) {
+	c, err := uc.visibleComment(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.CanEdit(actor.UserID) {
+		if c.Status != comment.StatusVisible {
+			return nil, comment.ErrNotVisible
+		}
+		return nil, comment.ErrForbidden
+	}
+
+	updated, err := uc.commentRepo.UpdateText(ctx, id, text, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.eventBus.Publish(comment.CommentUpdated{CommentSnapshot: comment.NewCommentSnapshot(updated)}); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (uc *usecase) DeleteComment(ctx context.Context, actor recipe.Actor, id string) error {
+	c, err := uc.visibleComment(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+
+	if !c.CanEdit(actor.UserID) {
+		if c.Status != comment.StatusVisible {
+			return comment.ErrNotVisible
+		}
+		return comment.ErrForbidden
+	}
+
+	deleted, err := uc.commentRepo.SetStatus(ctx, id, comment.StatusDeleted, "")
+	if err != nil {
+		return err
+	}
+
+	return uc.eventBus.Publish(comment.CommentDeleted{CommentSnapshot: comment.NewCommentSnapshot(deleted)})
+}
+
+func (uc *usecase) RemoveComment(ctx context.Context, adminID, id, reason string) (*comment.CommentModel, error) {
+	c, err := uc.commentRepo.GetComment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Status != comment.StatusVisible {
+		return nil, comment.ErrNotVisible
+	}
+
+	removed, err := uc.commentRepo.SetStatus(ctx, id, comment.StatusRemoved, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.eventBus.Publish(comment.CommentRemoved{CommentSnapshot: comment.NewCommentSnapshot(removed), AdminID: adminID}); err != nil {
+		return nil, err
+	}
+
+	return removed, nil
+}
+
+func (uc *usecase) React(ctx context.Context, actor recipe.Actor, id, kind string) error {
+	if !comment.IsReaction(kind) {
+		return fmt.Errorf("%w: %s", comment.ErrUnknownReaction, kind)
+	}
+
+	c, err := uc.visibleComment(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+
+	if c.Status != comment.StatusVisible {
+		return comment.ErrNotVisible
+	}
+
+	_, err = uc.commentRepo.AddReaction(ctx, id, actor.UserID, kind)
+	return err
+}
+
+func (uc *usecase) Unreact(ctx context.Context, actor recipe.Actor, id, kind string) error {
+	if !comment.IsReaction(kind) {
+		return fmt.Errorf("%w: %s", comment.ErrUnknownReaction, kind)
+	}
+
+	if _, err := uc.visibleComment(ctx, actor, id); err != nil {
+		return err
+	}
+
+	_, err := uc.commentRepo.RemoveReaction(ctx, id, actor.UserID, kind)
+	return err
+}
@@ -0,0 +1,90 @@
+package comment
+
+import (
+	"slices"
+	"time"
+)
+
+// Status tells whether a comment is shown. Deleted and removed comments stay in their
+// thread without their text, so that the replies keep their context.
+type Status string
+
+const (
+	StatusVisible Status = "visible"
+	StatusDeleted Status = "deleted"
+	StatusRemoved Status = "removed"
+)
+
+// Reactions a user can leave on a comment, at most one of each kind.
+const (
+	ReactionLike    = "like"
+	ReactionLove    = "love"
+	ReactionHelpful = "helpful"
+	ReactionFunny   = "funny"
+)
+
+var Reactions = []string{ReactionLike, ReactionLove, ReactionHelpful, ReactionFunny}
+
+func IsReaction(kind string) bool {
+	return slices.Contains(Reactions, kind)
+}
+
+// MaxDepth is the deepest a reply can be nested, top level comments have depth 0.
+const MaxDepth = 5
+
+// CommentModel is a comment on a recipe. ParentID is empty for top level comments and
+// RootID points to the top level comment of the thread. Reactions counts the reactions
+// per kind, MyReactions lists the ones of the user reading the comment.
+type CommentModel struct {
+	ID            string
+	RecipeID      string
+	ParentID      string
+	RootID        string
+	Depth         int
+	AuthorID      string
+	Text          string
+	Status        Status
+	RemovalReason string
+	ReplyCount    int
+	Reactions     map[string]int
+	MyReactions   []string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	EditedAt      *time.Time
+}
+
+// CanEdit tells whether the user may edit or delete the comment, only its author may.
+func (c *CommentModel) CanEdit(userID string) bool {
+	return c.Status == StatusVisible && c.AuthorID == userID
+}
+
+// ListParams selects a page of comments. An empty ParentID lists the top level comments
+// of the recipe, newest first, otherwise the replies to the parent are listed oldest first.
+type ListParams struct {
+	RecipeID string
+	ParentID string
+	Cursor   string
+	Limit    int64
+}
+
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+// PageSize is the limit with the default applied and capped.
+func (p ListParams) PageSize() int64 {
+	switch {
+	case p.Limit <= 0:
+		return DefaultListLimit
+	case p.Limit > MaxListLimit:
+		return MaxListLimit
+	default:
+		return p.Limit
+	}
+}
+
+type CommentPage struct {
+	Comments   []*CommentModel
+	NextCursor string
+}
@@ -0,0 +1,23 @@
+package comment
+
+import (
+	"context"
+	"time"
+)
+
+type CommentRepository interface {
+	CreateComment(ctx context.Context, comment *CommentModel) error
+	GetComment(ctx context.Context, id string) (*CommentModel, error)
+	ListComments(ctx context.Context, params ListParams) (*CommentPage, error)
+	UpdateText(ctx context.Context, id, text string, editedAt time.Time) (*CommentModel, error)
+	// SetStatus hides the text of a deleted or removed comment.
+	SetStatus(ctx context.Context, id string, status Status, reason string) (*CommentModel, error)
+	IncrementReplies(ctx context.Context, id string, delta int) error
+
+	// AddReaction reports whether the reaction was new, reacting twice is not an error.
+	AddReaction(ctx context.Context, commentID, userID, kind string) (bool, error)
+	// RemoveReaction reports whether there was a reaction to remove.
+	RemoveReaction(ctx context.Context, commentID, userID, kind string) (bool, error)
+	// UserReactions maps the comments to the reactions the user left on them.
+	UserReactions(ctx context.Context, userID string, commentIDs []string) (map[string][]string, error)
+}
@@ -0,0 +1,21 @@
+package comment
+
+import (
+	"context"
+	"flove/job/internal/recipe"
+)
+
+type CommentUC interface {
+	CreateComment(ctx context.Context, actor recipe.Actor, comment *CommentModel) error
+	// GetComment returns the comment regardless of the recipe it belongs to, it is meant
+	// for moderation.
+	GetComment(ctx context.Context, id string) (*CommentModel, error)
+	ListComments(ctx context.Context, actor recipe.Actor, params ListParams) (*CommentPage, error)
+	ListReplies(ctx context.Context, actor recipe.Actor, params ListParams) (*CommentPage, error)
+	EditComment(ctx context.Context, actor recipe.Actor, id, text string) (*CommentModel, error)
+	DeleteComment(ctx context.Context, actor recipe.Actor, id string) error
+	RemoveComment(ctx context.Context, adminID, id, reason string) (*CommentModel, error)
+
+	React(ctx context.Context, actor recipe.Actor, id, kind string) error
+	Unreact(ctx context.Context, actor recipe.Actor, id, kind string) error
+}
@@ -0,0 +1,178 @@
+package deadletter
+
+import (
+	"encoding/json"
+	"errors"
+	"flove/job/internal/base/database"
+	"flove/job/internal/base/response"
+	"flove/job/pkg/fp"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeadLetterHandler struct {
+	deadLetterUC DeadLetterUC
+}
+
+func NewDeadLetterHandler(uc DeadLetterUC) *DeadLetterHandler {
+	return &DeadLetterHandler{
+		deadLetterUC: uc,
+	}
+}
+
+// writeError maps the errors of the dead letter use cases to a response.
+func writeError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+	default:
+		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+type envelopeResponse struct {
+	ID         string          `json:"id,omitempty"`
+	Type       string          `json:"type" example:"recipe:created"`
+	Version    int             `json:"version"`
+	OccurredAt *time.Time      `json:"occurred_at,omitempty"`
+	Producer   string          `json:"producer"`
+	Payload    json.RawMessage `json:"payload" swaggertype:"object"`
+}
+
+type deadLetterResponse struct {
+	ID       string           `json:"id" example:"1718000000000-0"`
+	Topic    string           `json:"topic" example:"recipe:created"`
+	Group    string           `json:"group" example:"graph"`
+	Consumer string           `json:"consumer"`
+	Error    string           `json:"error"`
+	Attempts int              `json:"attempts"`
+	FailedAt time.Time        `json:"failed_at"`
+	Envelope envelopeResponse `json:"envelope"`
+}
+
+func toDeadLetterResponse(d database.DeadLetter) deadLetterResponse {
+	envelope := envelopeResponse{
+		ID:       d.Envelope.ID,
+		Type:     d.Envelope.Type,
+		Version:  d.Envelope.Version,
+		Producer: d.Envelope.Producer,
+		Payload:  d.Envelope.Payload,
+	}
+	// legacy messages carry no time
+	if !d.Envelope.OccurredAt.IsZero() {
+		envelope.OccurredAt = &d.Envelope.OccurredAt
+	}
+
+	return deadLetterResponse{
+		ID:       d.ID,
+		Topic:    d.Topic,
+		Group:    d.Group,
+		Consumer: d.Consumer,
+		Error:    d.Error,
+		Attempts: d.Attempts,
+		FailedAt: d.FailedAt,
+		Envelope: envelope,
+	}
+}
+
+type listDeadLettersRequest struct {
+	Limit int64 `form:"limit" binding:"omitempty,gte=1,lte=500" example:"50"`
+}
+
+// @Summary List dead letters
+// @Description List the events a consumer group of the event bus gave up on after retrying,
+// @Description newest first, with the last error. The limit defaults to 50. The Pub/Sub backend
+// @Description keeps no dead letters.
+// @Security BasicAuth
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Param request query listDeadLettersRequest false "Listing parameters"
+// @Success 200 {object} response.Response{body=[]deadLetterResponse}
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/events/dead-letters [get]
+func (h *DeadLetterHandler) ListDeadLetters(ctx *gin.Context) {
+	var req listDeadLettersRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = 50
+	}
+
+	letters, err := h.deadLetterUC.ListDeadLetters(ctx, limit)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", fp.Map(letters, toDeadLetterResponse))
+}
+
+// @Summary Replay a dead letter
+// @Description Deliver the event again to the consumer group that gave up on it, once the
+// @Description cause is fixed. The dead letter is removed, it comes back if the handler fails again.
+// @Security BasicAuth
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Param id path string true "Dead letter ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/events/dead-letters/{id}/replay [post]
+func (h *DeadLetterHandler) ReplayDeadLetter(ctx *gin.Context) {
+	var req struct {
+		ID string `uri:"id" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.deadLetterUC.ReplayDeadLetter(ctx, req.ID); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponse(ctx, http.StatusOK, "dead letter succesfully replayed")
+}
+
+// @Summary Delete a dead letter
+// @Description Drop a dead letter without delivering the event again
+// @Security BasicAuth
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Param id path string true "Dead letter ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/events/dead-letters/{id} [delete]
+func (h *DeadLetterHandler) DeleteDeadLetter(ctx *gin.Context) {
+	var req struct {
+		ID string `uri:"id" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.deadLetterUC.DeleteDeadLetter(ctx, req.ID); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponse(ctx, http.StatusOK, "dead letter succesfully deleted")
+}
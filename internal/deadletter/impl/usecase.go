@@ -0,0 +1,32 @@
+package impl
+
+import (
+	"context"
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/deadletter"
+)
+
+type usecase struct {
+	config   *config.Config
+	eventBus *database.EventBus
+}
+
+func NewDeadLetterUC(config *config.Config, eventBus *database.EventBus) deadletter.DeadLetterUC {
+	return &usecase{
+		config:   config,
+		eventBus: eventBus,
+	}
+}
+
+func (uc *usecase) ListDeadLetters(ctx context.Context, limit int64) ([]database.DeadLetter, error) {
+	return uc.eventBus.DeadLetters(ctx, limit)
+}
+
+func (uc *usecase) ReplayDeadLetter(ctx context.Context, id string) error {
+	return uc.eventBus.ReplayDeadLetter(ctx, id)
+}
+
+func (uc *usecase) DeleteDeadLetter(ctx context.Context, id string) error {
+	return uc.eventBus.DeleteDeadLetter(ctx, id)
+}
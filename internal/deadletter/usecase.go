@@ -0,0 +1,14 @@
+package deadletter
+
+import (
+	"context"
+	"flove/job/internal/base/database"
+)
+
+// DeadLetterUC inspects the events the consumer groups of the event bus gave up on.
+type DeadLetterUC interface {
+	ListDeadLetters(ctx context.Context, limit int64) ([]database.DeadLetter, error)
+	// ReplayDeadLetter delivers the event again to the consumer group that failed it.
+	ReplayDeadLetter(ctx context.Context, id string) error
+	DeleteDeadLetter(ctx context.Context, id string) error
+}
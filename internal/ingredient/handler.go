@@ -0,0 +1,97 @@
+package ingredient
+
+import (
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/base/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IngredientHandler struct {
+	config       *config.Config
+	ingredientUC IngredientUC
+}
+
+func NewIngredientHandler(config *config.Config, uc IngredientUC) *IngredientHandler {
+	return &IngredientHandler{
+		config:       config,
+		ingredientUC: uc,
+	}
+}
+
+type searchIngredientsRequest struct {
+	Query string `form:"query" binding:"omitempty"`
+	Limit int    `form:"limit" binding:"omitempty,gte=1,lte=100"`
+}
+
+// @Summary Search ingredients
+// @Description Search the canonical ingredient catalogue by name or alias
+// @Security BasicAuth
+// @Tags Ingredient
+// @Accept json
+// @Produce json
+// @Param query query string false "Name or alias to search for"
+// @Param limit query int false "Maximum number of results"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /ingredients [get]
+func (h *IngredientHandler) SearchIngredients(ctx *gin.Context) {
+	var req searchIngredientsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = 20
+	}
+
+	ingredients, err := h.ingredientUC.SearchIngredients(ctx, req.Query, limit)
+	if err != nil {
+		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", ingredients)
+}
+
+// @Summary Get an ingredient by ID
+// @Description Get a canonical ingredient with its nutrition per 100 g
+// @Security BasicAuth
+// @Tags Ingredient
+// @Accept json
+// @Produce json
+// @Param id path string true "Ingredient ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /ingredients/{id} [get]
+func (h *IngredientHandler) GetIngredientByID(ctx *gin.Context) {
+	var req struct {
+		ID string `uri:"id" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ingredient, err := h.ingredientUC.GetIngredientByID(ctx, req.ID)
+	if err != nil {
+		switch err {
+		case database.ErrNotFound:
+			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", ingredient)
+}
@@ -0,0 +1,148 @@
+package impl
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/ingredient"
+	"sort"
+	"strings"
+)
+
+//go:embed data/ingredients.json
+var dataset []byte
+
+type ingredientEntity struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Aliases     []string        `json:"aliases"`
+	Density     float64         `json:"density"`
+	PieceWeight float64         `json:"piece_weight"`
+	Origin      string          `json:"origin"`
+	Aisle       string          `json:"aisle"`
+	Allergens   []string        `json:"allergens"`
+	Nutrition   nutritionEntity `json:"nutrition"`
+}
+
+type nutritionEntity struct {
+	Calories      float64 `json:"calories"`
+	Protein       float64 `json:"protein"`
+	Fat           float64 `json:"fat"`
+	Carbohydrates float64 `json:"carbohydrates"`
+	Fiber         float64 `json:"fiber"`
+	Sugar         float64 `json:"sugar"`
+	Sodium        float64 `json:"sodium"`
+}
+
+func (e *ingredientEntity) toIngredientModel() *ingredient.IngredientModel {
+	return &ingredient.IngredientModel{
+		ID:          e.ID,
+		Name:        e.Name,
+		Aliases:     e.Aliases,
+		Density:     e.Density,
+		PieceWeight: e.PieceWeight,
+		Origin:      e.Origin,
+		Aisle:       e.Aisle,
+		Allergens:   e.Allergens,
+		Nutrition:   ingredient.Nutrition(e.Nutrition),
+	}
+}
+
+// repository serves the catalogue from the dataset bundled with the binary,
+// it is read-only and kept in memory.
+type repository struct {
+	config      *config.Config
+	ingredients []*ingredient.IngredientModel
+	byID        map[string]*ingredient.IngredientModel
+}
+
+func NewIngredientRepository(config *config.Config) (ingredient.IngredientRepository, error) {
+	var entities []ingredientEntity
+	if err := json.Unmarshal(dataset, &entities); err != nil {
+		return nil, err
+	}
+
+	repo := &repository{
+		config:      config,
+		ingredients: make([]*ingredient.IngredientModel, len(entities)),
+		byID:        make(map[string]*ingredient.IngredientModel, len(entities)),
+	}
+
+	for i := range entities {
+		model := entities[i].toIngredientModel()
+		repo.ingredients[i] = model
+		repo.byID[model.ID] = model
+	}
+
+	return repo, nil
+}
+
+func (repo *repository) GetIngredientByID(_ context.Context, id string) (*ingredient.IngredientModel, error) {
+	model, ok := repo.byID[id]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+
+	return model, nil
+}
+
+func (repo *repository) SearchIngredients(_ context.Context, query string, limit int) ([]*ingredient.IngredientModel, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	type match struct {
+		model *ingredient.IngredientModel
+		rank  int
+	}
+
+	var matches []match
+	for _, model := range repo.ingredients {
+		if rank, ok := matchRank(model, query); ok {
+			matches = append(matches, match{model: model, rank: rank})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].rank < matches[j].rank
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	results := make([]*ingredient.IngredientModel, len(matches))
+	for i, m := range matches {
+		results[i] = m.model
+	}
+
+	return results, nil
+}
+
+// matchRank prefers exact names over prefixes and prefixes over substrings.
+func matchRank(model *ingredient.IngredientModel, query string) (int, bool) {
+	if query == "" {
+		return 0, true
+	}
+
+	best, found := 0, false
+	for _, name := range append([]string{model.Name}, model.Aliases...) {
+		var rank int
+		switch {
+		case name == query:
+			rank = 0
+		case strings.HasPrefix(name, query):
+			rank = 1
+		case strings.Contains(name, query):
+			rank = 2
+		default:
+			continue
+		}
+
+		if !found || rank < best {
+			best, found = rank, true
+		}
+	}
+
+	return best, found
+}
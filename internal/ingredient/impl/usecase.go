@@ -0,0 +1,27 @@
+package impl
+
+import (
+	"context"
+	"flove/job/config"
+	"flove/job/internal/ingredient"
+)
+
+type usecase struct {
+	config         *config.Config
+	ingredientRepo ingredient.IngredientRepository
+}
+
+func NewIngredientUC(config *config.Config, repo ingredient.IngredientRepository) ingredient.IngredientUC {
+	return &usecase{
+		config:         config,
+		ingredientRepo: repo,
+	}
+}
+
+func (uc *usecase) GetIngredientByID(ctx context.Context, id string) (*ingredient.IngredientModel, error) {
+	return uc.ingredientRepo.GetIngredientByID(ctx, id)
+}
+
+func (uc *usecase) SearchIngredients(ctx context.Context, query string, limit int) ([]*ingredient.IngredientModel, error) {
+	return uc.ingredientRepo.SearchIngredients(ctx, query, limit)
+}
@@ -0,0 +1,78 @@
+package ingredient
+
+// IngredientModel is a canonical ingredient of the catalogue. Nutrition is given per 100 g,
+// Density in g/ml and PieceWeight in grams for ingredients that are counted.
+type IngredientModel struct {
+	ID          string
+	Name        string
+	Aliases     []string
+	Density     float64
+	PieceWeight float64
+	Origin      string
+	Aisle       string
+	Allergens   []string
+	Nutrition   Nutrition
+}
+
+const (
+	OriginPlant = "plant"
+	OriginMeat  = "meat"
+	OriginFish  = "fish"
+	OriginDairy = "dairy"
+	OriginEgg   = "egg"
+	OriginHoney = "honey"
+)
+
+// Aisles group ingredients the way a grocery store lays them out, Aisles lists
+// them in walking order.
+const (
+	AisleProduce    = "produce"
+	AisleBakery     = "bakery"
+	AisleMeat       = "meat"
+	AisleSeafood    = "seafood"
+	AisleDairy      = "dairy"
+	AisleBaking     = "baking"
+	AislePantry     = "pantry"
+	AisleCanned     = "canned"
+	AisleSpices     = "spices"
+	AisleCondiments = "condiments"
+	AisleBeverages  = "beverages"
+	AisleOther      = "other"
+)
+
+var Aisles = []string{
+	AisleProduce,
+	AisleBakery,
+	AisleMeat,
+	AisleSeafood,
+	AisleDairy,
+	AisleBaking,
+	AislePantry,
+	AisleCanned,
+	AisleSpices,
+	AisleCondiments,
+	AisleBeverages,
+	AisleOther,
+}
+
+const (
+	AllergenGluten    = "gluten"
+	AllergenDairy     = "dairy"
+	AllergenEgg       = "egg"
+	AllergenNuts      = "nuts"
+	AllergenPeanuts   = "peanuts"
+	AllergenSoy       = "soy"
+	AllergenFish      = "fish"
+	AllergenShellfish = "shellfish"
+	AllergenSesame    = "sesame"
+)
+
+type Nutrition struct {
+	Calories      float64
+	Protein       float64
+	Fat           float64
+	Carbohydrates float64
+	Fiber         float64
+	Sugar         float64
+	Sodium        float64
+}
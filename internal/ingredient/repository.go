@@ -0,0 +1,8 @@
+package ingredient
+
+import "context"
+
+type IngredientRepository interface {
+	GetIngredientByID(ctx context.Context, id string) (*IngredientModel, error)
+	SearchIngredients(ctx context.Context, query string, limit int) ([]*IngredientModel, error)
+}
@@ -0,0 +1,8 @@
+package ingredient
+
+import "context"
+
+type IngredientUC interface {
+	GetIngredientByID(ctx context.Context, id string) (*IngredientModel, error)
+	SearchIngredients(ctx context.Context, query string, limit int) ([]*IngredientModel, error)
+}
@@ -0,0 +1,8 @@
+package mealplan
+
+import "errors"
+
+var (
+	ErrInvalidRange = errors.New("invalid date range")
+	ErrEmptyWeek    = errors.New("week has no planned meals")
+)
@@ -0,0 +1,82 @@
+package mealplan
+
+import (
+	"flove/job/internal/recipe"
+	"flove/job/internal/user"
+	"math"
+)
+
+// GoalStatus tells how a day compares with a goal.
+type GoalStatus string
+
+const (
+	GoalUnder GoalStatus = "under"
+	GoalMet   GoalStatus = "met"
+	GoalOver  GoalStatus = "over"
+)
+
+// GoalTolerance is how far from a target a day can be and still meet it.
+const GoalTolerance = 0.1
+
+// GoalComparison compares the amount of a nutrient planned for a day with the goal of
+// the user. Percent is the share of the goal reached.
+type GoalComparison struct {
+	Nutrient string
+	Goal     float64
+	Actual   float64
+	Percent  float64
+	Limit    bool
+	Status   GoalStatus
+}
+
+type goal struct {
+	nutrient string
+	goal     float64
+	actual   float64
+	limit    bool
+}
+
+// CompareGoals compares the totals of a day with every goal the user has set. Targets
+// are met within GoalTolerance, limits are met as long as they are not exceeded.
+func CompareGoals(totals recipe.NutritionInfo, goals user.NutritionGoals) []GoalComparison {
+	all := []goal{
+		{"calories", goals.Calories, totals.Calories, false},
+		{"protein", goals.Protein, totals.Protein, false},
+		{"fat", goals.Fat, totals.Fat, false},
+		{"carbohydrates", goals.Carbohydrates, totals.Carbohydrates, false},
+		{"fiber", goals.Fiber, totals.Fiber, false},
+		{"sugar", goals.Sugar, totals.Sugar, true},
+		{"sodium", goals.Sodium, totals.Sodium, true},
+	}
+
+	comparisons := []GoalComparison{}
+	for _, g := range all {
+		if g.goal <= 0 {
+			continue
+		}
+
+		ratio := g.actual / g.goal
+		status := GoalMet
+		switch {
+		case g.limit && ratio > 1:
+			status = GoalOver
+		case g.limit:
+			status = GoalMet
+		case ratio < 1-GoalTolerance:
+			status = GoalUnder
+		case ratio > 1+GoalTolerance:
+			status = GoalOver
+		}
+
+		comparisons = append(comparisons, GoalComparison{
+			Nutrient: g.nutrient,
+			Goal:     g.goal,
+			Actual:   g.actual,
+			Percent:  math.Round(ratio * 100),
+			Limit:    g.limit,
+			Status:   status,
+		})
+	}
+
+	return comparisons
+}
@@ -0,0 +1,463 @@
+package mealplan
+
+import (
+	"errors"
+	"flove/job/internal/base/database"
+	"flove/job/internal/base/response"
+	"flove/job/internal/recipe"
+	"flove/job/internal/user"
+	"flove/job/pkg/fp"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MealPlanHandler struct {
+	mealPlanUC MealPlanUC
+}
+
+func NewMealPlanHandler(uc MealPlanUC) *MealPlanHandler {
+	return &MealPlanHandler{
+		mealPlanUC: uc,
+	}
+}
+
+// actor identifies the authenticated user making the request.
+func actor(ctx *gin.Context) recipe.Actor {
+	role, _ := ctx.Get("role")
+	r, _ := role.(user.Role)
+
+	return recipe.Actor{
+		UserID: ctx.GetString("userID"),
+		Role:   r,
+	}
+}
+
+// writeError maps the errors of the meal plan use cases to a response.
+func writeError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrInvalidRange), errors.Is(err, ErrEmptyWeek):
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+	default:
+		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// parseDate reads a plan date, an empty value stands for today.
+func parseDate(value string) time.Time {
+	if value == "" {
+		return Day(time.Now())
+	}
+
+	// the request binding has validated the layout already
+	date, _ := time.Parse(DateLayout, value)
+	return date
+}
+
+type nutrition struct {
+	Calories      float64 `json:"calories"`
+	Protein       float64 `json:"protein"`
+	Fat           float64 `json:"fat"`
+	Carbohydrates float64 `json:"carbohydrates"`
+	Fiber         float64 `json:"fiber"`
+	Sugar         float64 `json:"sugar"`
+	Sodium        float64 `json:"sodium"`
+}
+
+type goalResponse struct {
+	Nutrient string     `json:"nutrient" example:"calories"`
+	Goal     float64    `json:"goal"`
+	Actual   float64    `json:"actual"`
+	Percent  float64    `json:"percent"`
+	Limit    bool       `json:"limit"`
+	Status   GoalStatus `json:"status" example:"met"`
+}
+
+type plannedMealResponse struct {
+	Meal        Meal      `json:"meal" example:"dinner"`
+	RecipeID    string    `json:"recipe_id"`
+	RecipeName  string    `json:"recipe_name,omitempty"`
+	Servings    int       `json:"servings"`
+	Nutrition   nutrition `json:"nutrition"`
+	Unavailable bool      `json:"unavailable,omitempty"`
+}
+
+type dayPlanResponse struct {
+	Date      string                `json:"date" example:"2024-06-03"`
+	Meals     []plannedMealResponse `json:"meals"`
+	Nutrition nutrition             `json:"nutrition"`
+	Goals     []goalResponse        `json:"goals"`
+}
+
+func toDayPlanResponse(d DayPlanModel) dayPlanResponse {
+	return dayPlanResponse{
+		Date: d.Date.Format(DateLayout),
+		Meals: fp.Map(d.Meals, func(m PlannedMealModel) plannedMealResponse {
+			return plannedMealResponse{
+				Meal:        m.Slot.Meal,
+				RecipeID:    m.Slot.RecipeID,
+				RecipeName:  m.RecipeName,
+				Servings:    m.Slot.Servings,
+				Nutrition:   nutrition(m.Nutrition),
+				Unavailable: m.Unavailable,
+			}
+		}),
+		Nutrition: nutrition(d.Nutrition),
+		Goals: fp.Map(d.Goals, func(g GoalComparison) goalResponse {
+			return goalResponse(g)
+		}),
+	}
+}
+
+type weekPlanResponse struct {
+	Start     string            `json:"start" example:"2024-06-03"`
+	Days      []dayPlanResponse `json:"days"`
+	Nutrition nutrition         `json:"nutrition"`
+}
+
+type getWeekRequest struct {
+	Date string `form:"date" binding:"omitempty,datetime=2006-01-02" example:"2024-06-05"`
+}
+
+// @Summary Get a week of the meal plan
+// @Description Get the planned meals of the week (Monday to Sunday) containing the date, with
+// @Description the nutrition totals of every day compared with the nutrition goals of the user.
+// @Security BasicAuth
+// @Tags MealPlan
+// @Accept json
+// @Produce json
+// @Param date query string false "Any date of the week, today by default" format(date)
+// @Success 200 {object} response.Response{body=weekPlanResponse}
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /meal-plans/week [get]
+func (h *MealPlanHandler) GetWeek(ctx *gin.Context) {
+	var req getWeekRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	week, err := h.mealPlanUC.GetWeek(ctx, actor(ctx), parseDate(req.Date))
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", weekPlanResponse{
+		Start:     week.Start.Format(DateLayout),
+		Days:      fp.Map(week.Days, toDayPlanResponse),
+		Nutrition: nutrition(week.Nutrition),
+	})
+}
+
+type getDaysRequest struct {
+	From string `form:"from" binding:"required,datetime=2006-01-02" example:"2024-06-03"`
+	To   string `form:"to" binding:"required,datetime=2006-01-02" example:"2024-06-09"`
+}
+
+// @Summary Get days of the meal plan
+// @Description Get the planned meals from one date to another, both included and at most 62
+// @Description days, with the nutrition totals of every day compared with the goals of the user.
+// @Security BasicAuth
+// @Tags MealPlan
+// @Accept json
+// @Produce json
+// @Param from query string true "First day" format(date)
+// @Param to query string true "Last day" format(date)
+// @Success 200 {object} response.Response{body=[]dayPlanResponse}
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /meal-plans/days [get]
+func (h *MealPlanHandler) GetDays(ctx *gin.Context) {
+	var req getDaysRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	days, err := h.mealPlanUC.GetDays(ctx, actor(ctx), parseDate(req.From), parseDate(req.To))
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", fp.Map(days, toDayPlanResponse))
+}
+
+type slotRequest struct {
+	Date string `uri:"date" binding:"required,datetime=2006-01-02"`
+	Meal Meal   `uri:"meal" binding:"required,oneof=breakfast lunch dinner snack"`
+}
+
+type setSlotRequest struct {
+	RecipeID string `json:"recipe_id" binding:"required"`
+	Servings int    `json:"servings" binding:"required,gte=1,lte=100" example:"2"`
+}
+
+// @Summary Plan a meal
+// @Description Place a recipe in a meal of a day, replacing the recipe planned there
+// @Security BasicAuth
+// @Tags MealPlan
+// @Accept json
+// @Produce json
+// @Param date path string true "Day" format(date)
+// @Param meal path string true "Meal" Enums(breakfast, lunch, dinner, snack)
+// @Param slot body setSlotRequest true "Recipe and servings"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /meal-plans/days/{date}/{meal} [put]
+func (h *MealPlanHandler) SetSlot(ctx *gin.Context) {
+	var uri slotRequest
+	var req setSlotRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := h.mealPlanUC.SetSlot(ctx, actor(ctx), &SlotModel{
+		Date:     parseDate(uri.Date),
+		Meal:     uri.Meal,
+		RecipeID: req.RecipeID,
+		Servings: req.Servings,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponse(ctx, http.StatusOK, "meal succesfully planned")
+}
+
+// @Summary Clear a meal
+// @Description Remove the recipe planned for a meal of a day
+// @Security BasicAuth
+// @Tags MealPlan
+// @Accept json
+// @Produce json
+// @Param date path string true "Day" format(date)
+// @Param meal path string true "Meal" Enums(breakfast, lunch, dinner, snack)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /meal-plans/days/{date}/{meal} [delete]
+func (h *MealPlanHandler) ClearSlot(ctx *gin.Context) {
+	var uri slotRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.mealPlanUC.ClearSlot(ctx, actor(ctx), parseDate(uri.Date), uri.Meal); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponse(ctx, http.StatusOK, "meal succesfully cleared")
+}
+
+type copyWeekRequest struct {
+	From      string `json:"from" binding:"required,datetime=2006-01-02" example:"2024-06-03"`
+	To        string `json:"to" binding:"required,datetime=2006-01-02" example:"2024-06-10"`
+	Overwrite bool   `json:"overwrite"`
+}
+
+type copiedResponse struct {
+	Copied int `json:"copied"`
+}
+
+// @Summary Copy a week
+// @Description Copy the meals of the week containing one date to the week containing another.
+// @Description Meals already planned in the target week are kept unless overwrite is set.
+// @Security BasicAuth
+// @Tags MealPlan
+// @Accept json
+// @Produce json
+// @Param request body copyWeekRequest true "Source and target weeks"
+// @Success 200 {object} response.Response{body=copiedResponse}
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /meal-plans/week/copy [post]
+func (h *MealPlanHandler) CopyWeek(ctx *gin.Context) {
+	var req copyWeekRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	copied, err := h.mealPlanUC.CopyWeek(ctx, actor(ctx), parseDate(req.From), parseDate(req.To), req.Overwrite)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "week succesfully copied", copiedResponse{Copied: copied})
+}
+
+type templateSlotResponse struct {
+	Weekday  int    `json:"weekday" example:"0"`
+	Meal     Meal   `json:"meal" example:"dinner"`
+	RecipeID string `json:"recipe_id"`
+	Servings int    `json:"servings"`
+}
+
+type templateResponse struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	Slots     []templateSlotResponse `json:"slots"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+func toTemplateResponse(t *TemplateModel) templateResponse {
+	return templateResponse{
+		ID:   t.ID,
+		Name: t.Name,
+		Slots: fp.Map(t.Slots, func(s TemplateSlotModel) templateSlotResponse {
+			return templateSlotResponse(s)
+		}),
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+type createTemplateRequest struct {
+	Name string `json:"name" binding:"required,max=100" example:"Busy week"`
+	Week string `json:"week" binding:"required,datetime=2006-01-02" example:"2024-06-03"`
+}
+
+// @Summary Save a week as a template
+// @Description Save the meals of the week containing the date as a reusable template. Weekdays
+// @Description of the template slots start at 0 for Monday.
+// @Security BasicAuth
+// @Tags MealPlan
+// @Accept json
+// @Produce json
+// @Param template body createTemplateRequest true "Template"
+// @Success 201 {object} response.Response{body=templateResponse}
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /meal-plans/templates [post]
+func (h *MealPlanHandler) CreateTemplate(ctx *gin.Context) {
+	var req createTemplateRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	template, err := h.mealPlanUC.CreateTemplate(ctx, actor(ctx), req.Name, parseDate(req.Week))
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusCreated, "template succesfully created", toTemplateResponse(template))
+}
+
+// @Summary List templates
+// @Description List the meal plan templates of the user, newest first
+// @Security BasicAuth
+// @Tags MealPlan
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.Response{body=[]templateResponse}
+// @Failure 500 {object} response.Response
+// @Router /meal-plans/templates [get]
+func (h *MealPlanHandler) ListTemplates(ctx *gin.Context) {
+	templates, err := h.mealPlanUC.ListTemplates(ctx, actor(ctx))
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", fp.Map(templates, toTemplateResponse))
+}
+
+type templateRequest struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+// @Summary Delete a template
+// @Description Delete a meal plan template, weeks filled from it are left untouched
+// @Security BasicAuth
+// @Tags MealPlan
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /meal-plans/templates/{id} [delete]
+func (h *MealPlanHandler) DeleteTemplate(ctx *gin.Context) {
+	var req templateRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.mealPlanUC.DeleteTemplate(ctx, actor(ctx), req.ID); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponse(ctx, http.StatusOK, "template succesfully deleted")
+}
+
+type applyTemplateRequest struct {
+	Week      string `json:"week" binding:"required,datetime=2006-01-02" example:"2024-06-10"`
+	Overwrite bool   `json:"overwrite"`
+}
+
+// @Summary Apply a template
+// @Description Fill the week containing the date from a template. Meals already planned are
+// @Description kept unless overwrite is set, recipes that are no longer available are skipped.
+// @Security BasicAuth
+// @Tags MealPlan
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param request body applyTemplateRequest true "Target week"
+// @Success 200 {object} response.Response{body=copiedResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /meal-plans/templates/{id}/apply [post]
+func (h *MealPlanHandler) ApplyTemplate(ctx *gin.Context) {
+	var uri templateRequest
+	var req applyTemplateRequest
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	copied, err := h.mealPlanUC.ApplyTemplate(ctx, actor(ctx), uri.ID, parseDate(req.Week), req.Overwrite)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "template succesfully applied", copiedResponse{Copied: copied})
+}
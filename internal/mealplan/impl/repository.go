@@ -0,0 +1,223 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/mealplan"
+	"flove/job/pkg/fp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	slotsCollection     = "meal_plan_slots"
+	templatesCollection = "meal_plan_templates"
+)
+
+type slotEntity struct {
+	UserID    string        `bson:"user_id"`
+	Date      time.Time     `bson:"date"`
+	Meal      mealplan.Meal `bson:"meal"`
+	RecipeID  string        `bson:"recipe_id"`
+	Servings  int           `bson:"servings"`
+	UpdatedAt time.Time     `bson:"updated_at"`
+}
+
+type templateEntity struct {
+	ID        primitive.ObjectID   `bson:"_id,omitempty"`
+	UserID    string               `bson:"user_id"`
+	Name      string               `bson:"name"`
+	Slots     []templateSlotEntity `bson:"slots"`
+	CreatedAt time.Time            `bson:"created_at"`
+}
+
+type templateSlotEntity struct {
+	Weekday  int           `bson:"weekday"`
+	Meal     mealplan.Meal `bson:"meal"`
+	RecipeID string        `bson:"recipe_id"`
+	Servings int           `bson:"servings"`
+}
+
+func (e *slotEntity) toSlotModel() *mealplan.SlotModel {
+	return &mealplan.SlotModel{
+		UserID:    e.UserID,
+		Date:      e.Date.UTC(),
+		Meal:      e.Meal,
+		RecipeID:  e.RecipeID,
+		Servings:  e.Servings,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+func (e *templateEntity) toTemplateModel() *mealplan.TemplateModel {
+	return &mealplan.TemplateModel{
+		ID:     e.ID.Hex(),
+		UserID: e.UserID,
+		Name:   e.Name,
+		Slots: fp.Map(e.Slots, func(s templateSlotEntity) mealplan.TemplateSlotModel {
+			return mealplan.TemplateSlotModel(s)
+		}),
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+type repository struct {
+	config *config.Config
+	db     *mongo.Database
+}
+
+func NewMealPlanRepository(config *config.Config, db *mongo.Database) mealplan.MealPlanRepository {
+	ctx := context.Background()
+
+	db.Collection(slotsCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "date", Value: 1}, {Key: "meal", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "recipe_id", Value: 1}}},
+	})
+
+	db.Collection(templatesCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+	})
+
+	return &repository{
+		config: config,
+		db:     db,
+	}
+}
+
+func (repo *repository) SaveSlot(ctx context.Context, slot *mealplan.SlotModel, overwrite bool) (bool, error) {
+	filter := bson.M{"user_id": slot.UserID, "date": slot.Date, "meal": slot.Meal}
+	fields := bson.M{"recipe_id": slot.RecipeID, "servings": slot.Servings, "updated_at": slot.UpdatedAt}
+
+	update := bson.M{"$setOnInsert": fields}
+	if overwrite {
+		update = bson.M{"$set": fields}
+	}
+
+	result, err := repo.db.Collection(slotsCollection).UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return false, err
+	}
+
+	return overwrite || result.UpsertedCount > 0, nil
+}
+
+func (repo *repository) DeleteSlot(ctx context.Context, userID string, date time.Time, meal mealplan.Meal) error {
+	result, err := repo.db.Collection(slotsCollection).DeleteOne(ctx, bson.M{"user_id": userID, "date": date, "meal": meal})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return database.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *repository) ListSlots(ctx context.Context, userID string, from, to time.Time) ([]*mealplan.SlotModel, error) {
+	filter := bson.M{"user_id": userID, "date": bson.M{"$gte": from, "$lte": to}}
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}})
+
+	cursor, err := repo.db.Collection(slotsCollection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*slotEntity
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return fp.Map(results, (*slotEntity).toSlotModel), nil
+}
+
+func (repo *repository) DeleteRecipeSlots(ctx context.Context, recipeID string) error {
+	if _, err := repo.db.Collection(slotsCollection).DeleteMany(ctx, bson.M{"recipe_id": recipeID}); err != nil {
+		return err
+	}
+
+	_, err := repo.db.Collection(templatesCollection).UpdateMany(ctx,
+		bson.M{"slots.recipe_id": recipeID},
+		bson.M{"$pull": bson.M{"slots": bson.M{"recipe_id": recipeID}}},
+	)
+	return err
+}
+
+func (repo *repository) CreateTemplate(ctx context.Context, t *mealplan.TemplateModel) error {
+	entity := &templateEntity{
+		UserID: t.UserID,
+		Name:   t.Name,
+		Slots: fp.Map(t.Slots, func(s mealplan.TemplateSlotModel) templateSlotEntity {
+			return templateSlotEntity(s)
+		}),
+		CreatedAt: t.CreatedAt,
+	}
+
+	result, err := repo.db.Collection(templatesCollection).InsertOne(ctx, entity)
+	if err != nil {
+		return err
+	}
+
+	t.ID = result.InsertedID.(primitive.ObjectID).Hex()
+	return nil
+}
+
+func (repo *repository) GetTemplate(ctx context.Context, id string) (*mealplan.TemplateModel, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, database.ErrNotFound
+	}
+
+	entity := &templateEntity{}
+	if err := repo.db.Collection(templatesCollection).FindOne(ctx, bson.M{"_id": objectID}).Decode(entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, database.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return entity.toTemplateModel(), nil
+}
+
+func (repo *repository) ListTemplates(ctx context.Context, userID string) ([]*mealplan.TemplateModel, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := repo.db.Collection(templatesCollection).Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*templateEntity
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return fp.Map(results, (*templateEntity).toTemplateModel), nil
+}
+
+func (repo *repository) DeleteTemplate(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return database.ErrNotFound
+	}
+
+	result, err := repo.db.Collection(templatesCollection).DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return database.ErrNotFound
+	}
+
+	return nil
+}
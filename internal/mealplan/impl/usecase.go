@@ -0,0 +1,280 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/mealplan"
+	"flove/job/internal/recipe"
+	"flove/job/internal/user"
+	"fmt"
+	"slices"
+	"time"
+)
+
+type usecase struct {
+	config       *config.Config
+	eventBus     *database.EventBus
+	mealPlanRepo mealplan.MealPlanRepository
+	recipeUC     recipe.RecipeUC
+	userUC       user.UserUC
+}
+
+func NewMealPlanUC(config *config.Config, eventBus *database.EventBus, repo mealplan.MealPlanRepository, recipeUC recipe.RecipeUC, userUC user.UserUC) mealplan.MealPlanUC {
+	return &usecase{
+		config:       config,
+		eventBus:     eventBus,
+		mealPlanRepo: repo,
+		recipeUC:     recipeUC,
+		userUC:       userUC,
+	}
+}
+
+// weekDays returns the first and last day of the week of the date.
+func weekDays(date time.Time) (time.Time, time.Time) {
+	start := mealplan.WeekStart(date)
+	return start, start.AddDate(0, 0, 6)
+}
+
+func (uc *usecase) GetWeek(ctx context.Context, actor recipe.Actor, date time.Time) (*mealplan.WeekPlanModel, error) {
+	start, end := weekDays(date)
+
+	days, err := uc.GetDays(ctx, actor, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	week := &mealplan.WeekPlanModel{Start: start, Days: days}
+	for _, day := range days {
+		week.Nutrition = week.Nutrition.Add(day.Nutrition)
+	}
+
+	return week, nil
+}
+
+func (uc *usecase) GetDays(ctx context.Context, actor recipe.Actor, from, to time.Time) ([]mealplan.DayPlanModel, error) {
+	from, to = mealplan.Day(from), mealplan.Day(to)
+
+	slots, err := uc.ListSlots(ctx, actor, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := uc.userUC.GetUserByID(ctx, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// a recipe planned several times is loaded once, nil marks the ones out of reach
+	recipes := map[string]*recipe.RecipeModel{}
+	meals := map[time.Time][]mealplan.PlannedMealModel{}
+	for _, slot := range slots {
+		r, ok := recipes[slot.RecipeID]
+		if !ok {
+			r, err = uc.recipeUC.GetRecipeByID(ctx, actor, slot.RecipeID)
+			if err != nil && !errors.Is(err, database.ErrNotFound) {
+				return nil, err
+			}
+			recipes[slot.RecipeID] = r
+		}
+
+		meal := mealplan.PlannedMealModel{Slot: *slot, Unavailable: r == nil}
+		if r != nil {
+			meal.RecipeName = r.Name
+			meal.Nutrition = r.NutritionPerServing.Scale(float64(slot.Servings))
+		}
+
+		meals[slot.Date] = append(meals[slot.Date], meal)
+	}
+
+	var days []mealplan.DayPlanModel
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		day := mealplan.DayPlanModel{Date: date, Meals: meals[date]}
+		if day.Meals == nil {
+			day.Meals = []mealplan.PlannedMealModel{}
+		}
+
+		slices.SortFunc(day.Meals, func(a, b mealplan.PlannedMealModel) int {
+			return slices.Index(mealplan.Meals, a.Slot.Meal) - slices.Index(mealplan.Meals, b.Slot.Meal)
+		})
+
+		for _, meal := range day.Meals {
+			day.Nutrition = day.Nutrition.Add(meal.Nutrition)
+		}
+		day.Goals = mealplan.CompareGoals(day.Nutrition, u.Goals)
+
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+func (uc *usecase) ListSlots(ctx context.Context, actor recipe.Actor, from, to time.Time) ([]*mealplan.SlotModel, error) {
+	from, to = mealplan.Day(from), mealplan.Day(to)
+	if to.Before(from) || to.Sub(from) >= mealplan.MaxSpan*24*time.Hour {
+		return nil, mealplan.ErrInvalidRange
+	}
+
+	return uc.mealPlanRepo.ListSlots(ctx, actor.UserID, from, to)
+}
+
+func (uc *usecase) SetSlot(ctx context.Context, actor recipe.Actor, slot *mealplan.SlotModel) error {
+	if _, err := uc.recipeUC.GetRecipeByID(ctx, actor, slot.RecipeID); err != nil {
+		return err
+	}
+
+	slot.UserID = actor.UserID
+	slot.Date = mealplan.Day(slot.Date)
+	slot.UpdatedAt = time.Now()
+
+	_, err := uc.mealPlanRepo.SaveSlot(ctx, slot, true)
+	return err
+}
+
+func (uc *usecase) ClearSlot(ctx context.Context, actor recipe.Actor, date time.Time, meal mealplan.Meal) error {
+	return uc.mealPlanRepo.DeleteSlot(ctx, actor.UserID, mealplan.Day(date), meal)
+}
+
+func (uc *usecase) CopyWeek(ctx context.Context, actor recipe.Actor, from, to time.Time, overwrite bool) (int, error) {
+	source, sourceEnd := weekDays(from)
+	target := mealplan.WeekStart(to)
+	if source.Equal(target) {
+		return 0, mealplan.ErrInvalidRange
+	}
+
+	slots, err := uc.mealPlanRepo.ListSlots(ctx, actor.UserID, source, sourceEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(slots) == 0 {
+		return 0, mealplan.ErrEmptyWeek
+	}
+
+	now := time.Now()
+	copied := 0
+	for _, slot := range slots {
+		slot.Date = target.Add(slot.Date.Sub(source))
+		slot.UpdatedAt = now
+
+		saved, err := uc.mealPlanRepo.SaveSlot(ctx, slot, overwrite)
+		if err != nil {
+			return copied, err
+		}
+		if saved {
+			copied++
+		}
+	}
+
+	return copied, nil
+}
+
+func (uc *usecase) CreateTemplate(ctx context.Context, actor recipe.Actor, name string, week time.Time) (*mealplan.TemplateModel, error) {
+	start, end := weekDays(week)
+
+	slots, err := uc.mealPlanRepo.ListSlots(ctx, actor.UserID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(slots) == 0 {
+		return nil, mealplan.ErrEmptyWeek
+	}
+
+	template := &mealplan.TemplateModel{
+		UserID:    actor.UserID,
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+
+	for _, slot := range slots {
+		template.Slots = append(template.Slots, mealplan.TemplateSlotModel{
+			Weekday:  int(slot.Date.Sub(start).Hours() / 24),
+			Meal:     slot.Meal,
+			RecipeID: slot.RecipeID,
+			Servings: slot.Servings,
+		})
+	}
+
+	if err := uc.mealPlanRepo.CreateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (uc *usecase) ListTemplates(ctx context.Context, actor recipe.Actor) ([]*mealplan.TemplateModel, error) {
+	return uc.mealPlanRepo.ListTemplates(ctx, actor.UserID)
+}
+
+// ownTemplate loads a template of the actor, the templates of other users do not exist
+// for them.
+func (uc *usecase) ownTemplate(ctx context.Context, actor recipe.Actor, id string) (*mealplan.TemplateModel, error) {
+	template, err := uc.mealPlanRepo.GetTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if template.UserID != actor.UserID {
+		return nil, database.ErrNotFound
+	}
+
+	return template, nil
+}
+
+func (uc *usecase) DeleteTemplate(ctx context.Context, actor recipe.Actor, id string) error {
+	if _, err := uc.ownTemplate(ctx, actor, id); err != nil {
+		return err
+	}
+
+	return uc.mealPlanRepo.DeleteTemplate(ctx, id)
+}
+
+func (uc *usecase) ApplyTemplate(ctx context.Context, actor recipe.Actor, id string, week time.Time, overwrite bool) (int, error) {
+	template, err := uc.ownTemplate(ctx, actor, id)
+	if err != nil {
+		return 0, err
+	}
+
+	start := mealplan.WeekStart(week)
+	now := time.Now()
+	applied := 0
+	for _, s := range template.Slots {
+		// recipes unpublished since the template was saved are left out
+		if _, err := uc.recipeUC.GetRecipeByID(ctx, actor, s.RecipeID); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				continue
+			}
+			return applied, err
+		}
+
+		saved, err := uc.mealPlanRepo.SaveSlot(ctx, &mealplan.SlotModel{
+			UserID:    actor.UserID,
+			Date:      start.AddDate(0, 0, s.Weekday),
+			Meal:      s.Meal,
+			RecipeID:  s.RecipeID,
+			Servings:  s.Servings,
+			UpdatedAt: now,
+		}, overwrite)
+		if err != nil {
+			return applied, err
+		}
+		if saved {
+			applied++
+		}
+	}
+
+	return applied, nil
+}
+
+// WatchRecipes takes deleted recipes out of the plans and templates.
+func WatchRecipes(eventBus *database.EventBus, repo mealplan.MealPlanRepository) {
+	database.Subscribe(eventBus, "meal-plans", func(ctx context.Context, event recipe.RecipeDeleted) error {
+		if err := repo.DeleteRecipeSlots(ctx, event.ID); err != nil {
+			return fmt.Errorf("removing recipe %s from meal plans: %w", event.ID, err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,94 @@
+package mealplan
+
+import (
+	"flove/job/internal/recipe"
+	"slices"
+	"time"
+)
+
+// Meal is a slot of a day in the plan.
+type Meal string
+
+const (
+	MealBreakfast Meal = "breakfast"
+	MealLunch     Meal = "lunch"
+	MealDinner    Meal = "dinner"
+	MealSnack     Meal = "snack"
+)
+
+// Meals lists the meals in the order they are eaten.
+var Meals = []Meal{MealBreakfast, MealLunch, MealDinner, MealSnack}
+
+func IsMeal(m Meal) bool {
+	return slices.Contains(Meals, m)
+}
+
+// DateLayout is the format of plan dates in requests and responses.
+const DateLayout = "2006-01-02"
+
+// Day truncates a time to the start of its day in UTC, plan dates carry no time of day.
+func Day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// WeekStart returns the Monday of the week of the date.
+func WeekStart(t time.Time) time.Time {
+	day := Day(t)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// MaxSpan bounds the days a single plan query covers.
+const MaxSpan = 62
+
+// SlotModel is a recipe planned for a meal of a day, one recipe per meal.
+type SlotModel struct {
+	UserID    string
+	Date      time.Time
+	Meal      Meal
+	RecipeID  string
+	Servings  int
+	UpdatedAt time.Time
+}
+
+// TemplateModel is a reusable week, its slots are placed by weekday.
+type TemplateModel struct {
+	ID        string
+	UserID    string
+	Name      string
+	Slots     []TemplateSlotModel
+	CreatedAt time.Time
+}
+
+// TemplateSlotModel places a recipe in a template, Weekday 0 is Monday.
+type TemplateSlotModel struct {
+	Weekday  int
+	Meal     Meal
+	RecipeID string
+	Servings int
+}
+
+// PlannedMealModel is a slot with the recipe it refers to. Nutrition is the recipe
+// nutrition per serving times the servings of the slot. Unavailable is set when the
+// recipe can no longer be seen, such a meal does not count towards the totals.
+type PlannedMealModel struct {
+	Slot        SlotModel
+	RecipeName  string
+	Nutrition   recipe.NutritionInfo
+	Unavailable bool
+}
+
+// DayPlanModel is the plan of a single day with its nutrition totals compared with the
+// goals of the user.
+type DayPlanModel struct {
+	Date      time.Time
+	Meals     []PlannedMealModel
+	Nutrition recipe.NutritionInfo
+	Goals     []GoalComparison
+}
+
+type WeekPlanModel struct {
+	Start     time.Time
+	Days      []DayPlanModel
+	Nutrition recipe.NutritionInfo
+}
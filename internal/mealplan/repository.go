@@ -0,0 +1,22 @@
+package mealplan
+
+import (
+	"context"
+	"time"
+)
+
+type MealPlanRepository interface {
+	// SaveSlot writes the slot, an existing slot is only replaced when overwrite is set.
+	// It reports whether the slot was written.
+	SaveSlot(ctx context.Context, slot *SlotModel, overwrite bool) (bool, error)
+	DeleteSlot(ctx context.Context, userID string, date time.Time, meal Meal) error
+	// ListSlots lists the slots of the user from one date to another, both included.
+	ListSlots(ctx context.Context, userID string, from, to time.Time) ([]*SlotModel, error)
+	// DeleteRecipeSlots takes a recipe out of every plan and template.
+	DeleteRecipeSlots(ctx context.Context, recipeID string) error
+
+	CreateTemplate(ctx context.Context, template *TemplateModel) error
+	GetTemplate(ctx context.Context, id string) (*TemplateModel, error)
+	ListTemplates(ctx context.Context, userID string) ([]*TemplateModel, error)
+	DeleteTemplate(ctx context.Context, id string) error
+}
@@ -0,0 +1,29 @@
+package mealplan
+
+import (
+	"context"
+	"flove/job/internal/recipe"
+	"time"
+)
+
+type MealPlanUC interface {
+	// GetWeek returns the plan of the week of the date.
+	GetWeek(ctx context.Context, actor recipe.Actor, date time.Time) (*WeekPlanModel, error)
+	// GetDays returns the plan from one date to another, both included.
+	GetDays(ctx context.Context, actor recipe.Actor, from, to time.Time) ([]DayPlanModel, error)
+	// ListSlots lists the planned meals from one date to another, both included, without
+	// loading their recipes.
+	ListSlots(ctx context.Context, actor recipe.Actor, from, to time.Time) ([]*SlotModel, error)
+	SetSlot(ctx context.Context, actor recipe.Actor, slot *SlotModel) error
+	ClearSlot(ctx context.Context, actor recipe.Actor, date time.Time, meal Meal) error
+	// CopyWeek copies the week of one date to the week of another and returns the number
+	// of slots written. Planned meals of the target week are kept unless overwrite is set.
+	CopyWeek(ctx context.Context, actor recipe.Actor, from, to time.Time, overwrite bool) (int, error)
+
+	// CreateTemplate saves the week of the date as a template.
+	CreateTemplate(ctx context.Context, actor recipe.Actor, name string, week time.Time) (*TemplateModel, error)
+	ListTemplates(ctx context.Context, actor recipe.Actor) ([]*TemplateModel, error)
+	DeleteTemplate(ctx context.Context, actor recipe.Actor, id string) error
+	// ApplyTemplate fills the week of the date from a template, like CopyWeek.
+	ApplyTemplate(ctx context.Context, actor recipe.Actor, id string, week time.Time, overwrite bool) (int, error)
+}
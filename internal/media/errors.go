@@ -0,0 +1,9 @@
+package media
+
+import "errors"
+
+var (
+	ErrUnsupportedType = errors.New("unsupported image type")
+	ErrTooLarge        = errors.New("image is too large")
+	ErrInvalidKey      = errors.New("invalid media key")
+)
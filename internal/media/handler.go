@@ -0,0 +1,63 @@
+package media
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flove/job/internal/base/database"
+	"flove/job/internal/base/response"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cacheControl lets clients keep files forever, a key is never reused for other content.
+const cacheControl = "public, max-age=31536000, immutable"
+
+type MediaHandler struct {
+	imageUC ImageUC
+}
+
+func NewMediaHandler(uc ImageUC) *MediaHandler {
+	return &MediaHandler{
+		imageUC: uc,
+	}
+}
+
+// @Summary Get a media file
+// @Description Serve an uploaded file. Files never change, so they are cached for a year.
+// @Tags Media
+// @Produce image/jpeg,image/png
+// @Param key path string true "Media key"
+// @Success 200 {file} binary
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /media/{key} [get]
+func (h *MediaHandler) ServeFile(ctx *gin.Context) {
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+
+	object, err := h.imageUC.Open(ctx, key)
+	if err != nil {
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrInvalidKey):
+			response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	defer object.Content.Close()
+
+	etag := sha256.Sum256([]byte(key))
+
+	ctx.Header("Cache-Control", cacheControl)
+	ctx.Header("ETag", `"`+hex.EncodeToString(etag[:16])+`"`)
+	ctx.Header("Content-Type", object.ContentType)
+	ctx.Header("X-Content-Type-Options", "nosniff")
+
+	http.ServeContent(ctx.Writer, ctx.Request, "", object.ModTime, object.Content)
+}
@@ -0,0 +1,127 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flove/job/config"
+	"flove/job/internal/media"
+	"flove/job/pkg/imaging"
+	"fmt"
+	"image"
+	"net/http"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type usecase struct {
+	config  *config.Config
+	storage media.Storage
+}
+
+func NewImageUC(config *config.Config, storage media.Storage) media.ImageUC {
+	return &usecase{
+		config:  config,
+		storage: storage,
+	}
+}
+
+func (uc *usecase) UploadImage(ctx context.Context, prefix string, data []byte) (*media.ImageModel, error) {
+	if int64(len(data)) > uc.config.Media.MaxUploadSize {
+		return nil, media.ErrTooLarge
+	}
+
+	// trust the content, not the type the client declared
+	if !slices.Contains(media.AllowedContentTypes, http.DetectContentType(data)) {
+		return nil, media.ErrUnsupportedType
+	}
+
+	img, format, err := imaging.Decode(data)
+	if err != nil {
+		switch {
+		case errors.Is(err, imaging.ErrTooManyPixels):
+			return nil, media.ErrTooLarge
+		default:
+			return nil, fmt.Errorf("%w: %v", media.ErrUnsupportedType, err)
+		}
+	}
+
+	id := primitive.NewObjectID().Hex()
+	model := &media.ImageModel{ID: id}
+
+	// remove what was already written when a later file fails
+	var stored []string
+	rollback := func() {
+		for _, key := range stored {
+			_ = uc.storage.Delete(ctx, key)
+		}
+	}
+
+	original, err := uc.store(ctx, fmt.Sprintf("%s/%s/original", prefix, id), imaging.Fit(img, media.OriginalSize), format)
+	if err != nil {
+		return nil, err
+	}
+	stored = append(stored, original.Key)
+
+	model.Key = original.Key
+	model.URL = original.URL
+	model.Width = original.Width
+	model.Height = original.Height
+
+	for _, variant := range media.Variants {
+		v, err := uc.store(ctx, fmt.Sprintf("%s/%s/%s", prefix, id, variant.Name), imaging.Fit(img, variant.Size), format)
+		if err != nil {
+			rollback()
+			return nil, err
+		}
+		stored = append(stored, v.Key)
+
+		v.Name = variant.Name
+		model.Variants = append(model.Variants, *v)
+	}
+
+	return model, nil
+}
+
+// store encodes the image and writes it under the name with the extension of its format.
+func (uc *usecase) store(ctx context.Context, name string, img image.Image, format string) (*media.VariantModel, error) {
+	var buf bytes.Buffer
+
+	format, err := imaging.Encode(&buf, img, format)
+	if err != nil {
+		return nil, err
+	}
+
+	key, contentType := name+".png", "image/png"
+	if format == imaging.FormatJPEG {
+		key, contentType = name+".jpg", "image/jpeg"
+	}
+
+	if err := uc.storage.Put(ctx, key, contentType, &buf); err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	return &media.VariantModel{
+		Key:    key,
+		URL:    uc.storage.URL(key),
+		Width:  bounds.Dx(),
+		Height: bounds.Dy(),
+	}, nil
+}
+
+func (uc *usecase) DeleteImage(ctx context.Context, image media.ImageModel) error {
+	var errs []error
+
+	for _, variant := range image.Variants {
+		errs = append(errs, uc.storage.Delete(ctx, variant.Key))
+	}
+	errs = append(errs, uc.storage.Delete(ctx, image.Key))
+
+	return errors.Join(errs...)
+}
+
+func (uc *usecase) Open(ctx context.Context, key string) (*media.Object, error) {
+	return uc.storage.Open(ctx, key)
+}
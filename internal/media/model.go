@@ -0,0 +1,42 @@
+package media
+
+// ImageModel is an uploaded picture. URL points to the original re-encoded without its
+// metadata, Variants are the same picture scaled down. Keys locate the files in the
+// storage and are not shown to clients.
+type ImageModel struct {
+	ID       string
+	Key      string `json:"-"`
+	URL      string
+	Width    int
+	Height   int
+	Variants []VariantModel
+}
+
+type VariantModel struct {
+	Name   string
+	Key    string `json:"-"`
+	URL    string
+	Width  int
+	Height int
+}
+
+// Variant describes a thumbnail size, Size bounds the longest side in pixels.
+type Variant struct {
+	Name string
+	Size int
+}
+
+var (
+	// OriginalSize bounds the stored original, pictures straight from a camera are
+	// larger than any page needs.
+	OriginalSize = 2048
+
+	Variants = []Variant{
+		{Name: "small", Size: 160},
+		{Name: "medium", Size: 480},
+		{Name: "large", Size: 1024},
+	}
+)
+
+// AllowedContentTypes lists the content types accepted for upload.
+var AllowedContentTypes = []string{"image/jpeg", "image/png", "image/gif"}
@@ -0,0 +1,24 @@
+package media
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Storage keeps media files under slash separated keys.
+type Storage interface {
+	Put(ctx context.Context, key, contentType string, content io.Reader) error
+	Open(ctx context.Context, key string) (*Object, error)
+	Delete(ctx context.Context, key string) error
+	// URL returns the address the file is served from.
+	URL(key string) string
+}
+
+// Object is an opened media file, the caller closes Content.
+type Object struct {
+	Content     io.ReadSeekCloser
+	ContentType string
+	Size        int64
+	ModTime     time.Time
+}
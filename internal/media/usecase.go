@@ -0,0 +1,11 @@
+package media
+
+import "context"
+
+type ImageUC interface {
+	// UploadImage validates and stores a picture and its variants under the prefix.
+	UploadImage(ctx context.Context, prefix string, data []byte) (*ImageModel, error)
+	// DeleteImage removes the files of a picture, missing files are ignored.
+	DeleteImage(ctx context.Context, image ImageModel) error
+	Open(ctx context.Context, key string) (*Object, error)
+}
@@ -0,0 +1,28 @@
+package moderation
+
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+// ListParams filters the queue. Items are listed flagged first, then oldest first, so
+// that the most suspicious and the longest waiting content is reviewed first.
+type ListParams struct {
+	Status      Status
+	Kind        Kind
+	FlaggedOnly bool
+	Page        int64
+	Limit       int64
+}
+
+// PageSize is the limit with the default applied and capped.
+func (p ListParams) PageSize() int64 {
+	switch {
+	case p.Limit <= 0:
+		return DefaultListLimit
+	case p.Limit > MaxListLimit:
+		return MaxListLimit
+	default:
+		return p.Limit
+	}
+}
@@ -0,0 +1,8 @@
+package moderation
+
+import "errors"
+
+var (
+	ErrAlreadyReviewed = errors.New("item has already been reviewed")
+	ErrUnknownKind     = errors.New("unknown content kind")
+)
@@ -0,0 +1,78 @@
+package moderation
+
+import (
+	"encoding/json"
+	"flove/job/internal/base/database"
+	"strings"
+)
+
+// Types of the events published by the moderation use cases.
+const (
+	EventSubmitted = "moderation:submitted"
+	EventFlagged   = "moderation:flagged"
+	EventWithdrawn = "moderation:withdrawn"
+	EventApproved  = "moderation:approved"
+	EventRejected  = "moderation:rejected"
+)
+
+// ItemSnapshot identifies a moderation item in the moderation:* events, Reason is only
+// set on rejections.
+type ItemSnapshot struct {
+	ID       string `json:"id"`
+	Kind     Kind   `json:"kind"`
+	TargetID string `json:"target_id"`
+	AuthorID string `json:"author_id"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+func NewItemSnapshot(item *ItemModel) ItemSnapshot {
+	snapshot := ItemSnapshot{
+		ID:       item.ID,
+		Kind:     item.Kind,
+		TargetID: item.TargetID,
+		AuthorID: item.AuthorID,
+	}
+	if item.Status == StatusRejected {
+		snapshot.Reason = item.Reason
+	}
+
+	return snapshot
+}
+
+func (ItemSnapshot) EventVersion() int { return 1 }
+
+// Upcasters reads the legacy id:kind:targetID:authorID messages, rejections carry the
+// reason last as it may contain colons.
+func (ItemSnapshot) Upcasters() map[int]database.Upcaster {
+	return map[int]database.Upcaster{
+		database.LegacyVersion: func(payload json.RawMessage) (json.RawMessage, error) {
+			fields, err := database.LegacyFields(payload, 4)
+			if err != nil {
+				return nil, err
+			}
+
+			authorID, reason, _ := strings.Cut(fields[3], ":")
+			return json.Marshal(ItemSnapshot{ID: fields[0], Kind: Kind(fields[1]), TargetID: fields[2], AuthorID: authorID, Reason: reason})
+		},
+	}
+}
+
+type ItemSubmitted struct{ ItemSnapshot }
+
+func (ItemSubmitted) EventType() string { return EventSubmitted }
+
+type ItemFlagged struct{ ItemSnapshot }
+
+func (ItemFlagged) EventType() string { return EventFlagged }
+
+type ItemWithdrawn struct{ ItemSnapshot }
+
+func (ItemWithdrawn) EventType() string { return EventWithdrawn }
+
+type ItemApproved struct{ ItemSnapshot }
+
+func (ItemApproved) EventType() string { return EventApproved }
+
+type ItemRejected struct{ ItemSnapshot }
+
+func (ItemRejected) EventType() string { return EventRejected }
@@ -0,0 +1,277 @@
+package moderation
+
+import (
+	"errors"
+	"flove/job/internal/base/database"
+	"flove/job/internal/base/response"
+	"flove/job/pkg/fp"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ModerationHandler struct {
+	moderationUC ModerationUC
+}
+
+func NewModerationHandler(uc ModerationUC) *ModerationHandler {
+	return &ModerationHandler{
+		moderationUC: uc,
+	}
+}
+
+type flagResponse struct {
+	Rule   string `json:"rule" example:"link_spam"`
+	Detail string `json:"detail"`
+}
+
+type itemResponse struct {
+	ID         string         `json:"id"`
+	Kind       Kind           `json:"kind" example:"recipe"`
+	TargetID   string         `json:"target_id"`
+	AuthorID   string         `json:"author_id"`
+	Text       string         `json:"text"`
+	Flags      []flagResponse `json:"flags"`
+	Status     Status         `json:"status" example:"pending"`
+	Reason     string         `json:"reason,omitempty"`
+	ReviewerID string         `json:"reviewer_id,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	ReviewedAt *time.Time     `json:"reviewed_at,omitempty"`
+}
+
+func toItemResponse(i *ItemModel) itemResponse {
+	return itemResponse{
+		ID:         i.ID,
+		Kind:       i.Kind,
+		TargetID:   i.TargetID,
+		AuthorID:   i.AuthorID,
+		Text:       i.Text,
+		Flags:      fp.Map(i.Flags, func(f FlagModel) flagResponse { return flagResponse(f) }),
+		Status:     i.Status,
+		Reason:     i.Reason,
+		ReviewerID: i.ReviewerID,
+		CreatedAt:  i.CreatedAt,
+		ReviewedAt: i.ReviewedAt,
+	}
+}
+
+// writeError maps the errors of a review to a response.
+func writeError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrAlreadyReviewed):
+		response.WriteResponse(ctx, http.StatusConflict, err.Error())
+	default:
+		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+type listItemsRequest struct {
+	Status  Status `form:"status" binding:"omitempty,oneof=pending approved rejected withdrawn" example:"pending"`
+	Kind    Kind   `form:"kind" binding:"omitempty,oneof=recipe comment"`
+	Flagged bool   `form:"flagged"`
+	Page    int64  `form:"page" binding:"omitempty,gte=0"`
+	Limit   int64  `form:"limit" binding:"omitempty,gte=0"`
+}
+
+// @Summary List the moderation queue
+// @Description List the moderation items, flagged items first and then the oldest first.
+// @Description Only pending items are listed unless another status is asked for.
+// @Security BasicAuth
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param request query listItemsRequest false "Filters"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/moderation/items [get]
+func (h *ModerationHandler) ListItems(ctx *gin.Context) {
+	var req listItemsRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	params := ListParams{
+		Status:      req.Status,
+		Kind:        req.Kind,
+		FlaggedOnly: req.Flagged,
+		Page:        req.Page,
+		Limit:       req.Limit,
+	}
+	if params.Status == "" {
+		params.Status = StatusPending
+	}
+
+	items, total, err := h.moderationUC.ListItems(ctx, params)
+	if err != nil {
+		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", struct {
+		Items []itemResponse `json:"items"`
+		Total int64          `json:"total"`
+		Page  int64          `json:"page"`
+		Limit int64          `json:"limit"`
+	}{
+		Items: fp.Map(items, toItemResponse),
+		Total: total,
+		Page:  params.Page,
+		Limit: params.PageSize(),
+	})
+}
+
+type itemRequest struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+// @Summary Get a moderation item
+// @Description Get a moderation item with the flags raised by the rules
+// @Security BasicAuth
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param id path string true "Item ID"
+// @Success 200 {object} response.Response{body=itemResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/moderation/items/{id} [get]
+func (h *ModerationHandler) GetItem(ctx *gin.Context) {
+	var req itemRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	item, err := h.moderationUC.GetItem(ctx, req.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", toItemResponse(item))
+}
+
+// @Summary Approve a moderation item
+// @Description Approve the content of a pending item, an approved recipe is published
+// @Security BasicAuth
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param id path string true "Item ID"
+// @Success 200 {object} response.Response{body=itemResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/moderation/items/{id}/approve [post]
+func (h *ModerationHandler) Approve(ctx *gin.Context) {
+	var req itemRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	item, err := h.moderationUC.Approve(ctx, ctx.GetString("userID"), req.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "item succesfully approved", toItemResponse(item))
+}
+
+type rejectRequest struct {
+	ID     string `uri:"id" binding:"required"`
+	Reason string `json:"reason" binding:"required,max=1000" example:"contains advertising"`
+}
+
+// @Summary Reject a moderation item
+// @Description Reject the content of a pending item with a reason, a rejected recipe goes
+// @Description back to its author as a draft
+// @Security BasicAuth
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param id path string true "Item ID"
+// @Param request body rejectRequest true "Rejection reason"
+// @Success 200 {object} response.Response{body=itemResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/moderation/items/{id}/reject [post]
+func (h *ModerationHandler) Reject(ctx *gin.Context) {
+	var req rejectRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	item, err := h.moderationUC.Reject(ctx, ctx.GetString("userID"), req.ID, req.Reason)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "item succesfully rejected", toItemResponse(item))
+}
+
+type bulkRequest struct {
+	IDs    []string `json:"ids" binding:"required,min=1,max=100,dive,required"`
+	Action Action   `json:"action" binding:"required,oneof=approve reject" example:"approve"`
+	Reason string   `json:"reason" binding:"required_if=Action reject,max=1000"`
+}
+
+type bulkResultResponse struct {
+	ID    string        `json:"id"`
+	Item  *itemResponse `json:"item,omitempty"`
+	Error string        `json:"error,omitempty"`
+}
+
+// @Summary Review moderation items in bulk
+// @Description Approve or reject several items at once. Every item is reviewed on its own,
+// @Description the result of each is reported and a failure does not stop the others.
+// @Security BasicAuth
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param request body bulkRequest true "Items and action"
+// @Success 200 {object} response.Response{body=[]bulkResultResponse}
+// @Failure 400 {object} response.Response
+// @Router /admin/moderation/items/bulk [post]
+func (h *ModerationHandler) Bulk(ctx *gin.Context) {
+	var req bulkRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	results := h.moderationUC.Bulk(ctx, ctx.GetString("userID"), req.Action, req.IDs, req.Reason)
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", fp.Map(results, func(r BulkResult) bulkResultResponse {
+		resp := bulkResultResponse{ID: r.ItemID}
+		if r.Err != nil {
+			resp.Error = r.Err.Error()
+		} else {
+			item := toItemResponse(r.Item)
+			resp.Item = &item
+		}
+		return resp
+	}))
+}
@@ -0,0 +1,73 @@
+package impl
+
+import (
+	"context"
+	"flove/job/internal/base/database"
+	"flove/job/internal/comment"
+	"flove/job/internal/moderation"
+	"fmt"
+)
+
+// commentTarget moderates comments after the fact: comments are shown as soon as they
+// are posted, rejecting one removes it.
+type commentTarget struct {
+	commentUC comment.CommentUC
+}
+
+func NewCommentTarget(commentUC comment.CommentUC) moderation.Target {
+	return &commentTarget{commentUC: commentUC}
+}
+
+func (t *commentTarget) Content(ctx context.Context, id string) (*moderation.ContentModel, error) {
+	c, err := t.commentUC.GetComment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &moderation.ContentModel{
+		AuthorID: c.AuthorID,
+		Text:     c.Text,
+	}, nil
+}
+
+func (t *commentTarget) Approve(context.Context, string, string) error {
+	return nil
+}
+
+func (t *commentTarget) Reject(ctx context.Context, reviewerID, id, reason string) error {
+	_, err := t.commentUC.RemoveComment(ctx, reviewerID, id, reason)
+	return err
+}
+
+// WatchComments queues every new or edited comment and withdraws the comments deleted
+// or removed before they were reviewed.
+func WatchComments(eventBus *database.EventBus, uc moderation.ModerationUC) {
+	submit := func(ctx context.Context, commentID string) error {
+		if _, err := uc.Submit(ctx, moderation.KindComment, commentID); err != nil {
+			return fmt.Errorf("moderating comment %s: %w", commentID, err)
+		}
+
+		return nil
+	}
+
+	withdraw := func(ctx context.Context, commentID string) error {
+		if err := uc.Withdraw(ctx, moderation.KindComment, commentID); err != nil {
+			return fmt.Errorf("withdrawing comment %s from moderation: %w", commentID, err)
+		}
+
+		return nil
+	}
+
+	database.Subscribe(eventBus, "moderation", func(ctx context.Context, event comment.CommentCreated) error {
+		return submit(ctx, event.ID)
+	})
+	database.Subscribe(eventBus, "moderation", func(ctx context.Context, event comment.CommentUpdated) error {
+		return submit(ctx, event.ID)
+	})
+	database.Subscribe(eventBus, "moderation", func(ctx context.Context, event comment.CommentDeleted) error {
+		return withdraw(ctx, event.ID)
+	})
+	database.Subscribe(eventBus, "moderation", func(ctx context.Context, event comment.CommentRemoved) error {
+		return withdraw(ctx, event.ID)
+	})
+}
@@ -0,0 +1,82 @@
+package impl
+
+import (
+	"context"
+	"flove/job/internal/base/database"
+	"flove/job/internal/moderation"
+	"flove/job/internal/recipe"
+	"flove/job/internal/user"
+	"fmt"
+	"strings"
+)
+
+// recipeTarget moderates recipes submitted for review. Approving publishes the recipe,
+// rejecting sends it back to its author as a draft.
+type recipeTarget struct {
+	recipeUC recipe.RecipeUC
+}
+
+func NewRecipeTarget(recipeUC recipe.RecipeUC) moderation.Target {
+	return &recipeTarget{recipeUC: recipeUC}
+}
+
+func reviewer(reviewerID string) recipe.Actor {
+	return recipe.Actor{UserID: reviewerID, Role: user.RoleAdmin}
+}
+
+func (t *recipeTarget) Content(ctx context.Context, id string) (*moderation.ContentModel, error) {
+	r, err := t.recipeUC.GetRecipeByID(ctx, reviewer(""), id)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := []string{r.Name, r.Description, strings.Join(r.Tags, ", ")}
+	for _, line := range r.Ingredients {
+		parts = append(parts, line.Name, line.Note)
+	}
+	for _, step := range r.Steps {
+		parts = append(parts, step.Text)
+	}
+
+	return &moderation.ContentModel{
+		AuthorID: r.AuthorID,
+		Text:     strings.Join(parts, "\n"),
+	}, nil
+}
+
+func (t *recipeTarget) Approve(ctx context.Context, reviewerID, id string) error {
+	_, err := t.recipeUC.ChangeStatus(ctx, reviewer(reviewerID), id, recipe.StatusPublished)
+	return err
+}
+
+func (t *recipeTarget) Reject(ctx context.Context, reviewerID, id, _ string) error {
+	_, err := t.recipeUC.ChangeStatus(ctx, reviewer(reviewerID), id, recipe.StatusDraft)
+	return err
+}
+
+// WatchRecipes queues recipes as they are submitted for review and withdraws them when
+// they leave the review any other way, for example when the author pulls them back.
+func WatchRecipes(eventBus *database.EventBus, uc moderation.ModerationUC) {
+	database.Subscribe(eventBus, "moderation", func(ctx context.Context, event recipe.RecipeUpdated) error {
+		var err error
+		if event.Status == recipe.StatusPendingReview {
+			_, err = uc.Submit(ctx, moderation.KindRecipe, event.ID)
+		} else {
+			err = uc.Withdraw(ctx, moderation.KindRecipe, event.ID)
+		}
+
+		if err != nil {
+			return fmt.Errorf("moderating recipe %s: %w", event.ID, err)
+		}
+
+		return nil
+	})
+
+	database.Subscribe(eventBus, "moderation", func(ctx context.Context, event recipe.RecipeDeleted) error {
+		if err := uc.Withdraw(ctx, moderation.KindRecipe, event.ID); err != nil {
+			return fmt.Errorf("withdrawing recipe %s from moderation: %w", event.ID, err)
+		}
+
+		return nil
+	})
+}
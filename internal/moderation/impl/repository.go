@@ -0,0 +1,282 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/moderation"
+	"flove/job/pkg/fp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	itemsCollection = "moderation_items"
+)
+
+type itemEntity struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Kind        moderation.Kind    `bson:"kind"`
+	TargetID    string             `bson:"target_id"`
+	AuthorID    string             `bson:"author_id"`
+	Text        string             `bson:"text"`
+	Fingerprint string             `bson:"fingerprint"`
+	Flags       []flagEntity       `bson:"flags"`
+	FlagCount   int                `bson:"flag_count"`
+	Status      moderation.Status  `bson:"status"`
+	Reason      string             `bson:"reason,omitempty"`
+	ReviewerID  string             `bson:"reviewer_id,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	ReviewedAt  *time.Time         `bson:"reviewed_at,omitempty"`
+}
+
+type flagEntity struct {
+	Rule   string `bson:"rule"`
+	Detail string `bson:"detail"`
+}
+
+func (e *itemEntity) toItemModel() *moderation.ItemModel {
+	return &moderation.ItemModel{
+		ID:          e.ID.Hex(),
+		Kind:        e.Kind,
+		TargetID:    e.TargetID,
+		AuthorID:    e.AuthorID,
+		Text:        e.Text,
+		Fingerprint: e.Fingerprint,
+		Flags:       fp.Map(e.Flags, func(f flagEntity) moderation.FlagModel { return moderation.FlagModel(f) }),
+		Status:      e.Status,
+		Reason:      e.Reason,
+		ReviewerID:  e.ReviewerID,
+		CreatedAt:   e.CreatedAt,
+		ReviewedAt:  e.ReviewedAt,
+	}
+}
+
+func toItemEntity(i *moderation.ItemModel) *itemEntity {
+	return &itemEntity{
+		Kind:        i.Kind,
+		TargetID:    i.TargetID,
+		AuthorID:    i.AuthorID,
+		Text:        i.Text,
+		Fingerprint: i.Fingerprint,
+		Flags:       fp.Map(i.Flags, func(f moderation.FlagModel) flagEntity { return flagEntity(f) }),
+		FlagCount:   len(i.Flags),
+		Status:      i.Status,
+		Reason:      i.Reason,
+		ReviewerID:  i.ReviewerID,
+		CreatedAt:   i.CreatedAt,
+		ReviewedAt:  i.ReviewedAt,
+	}
+}
+
+type repository struct {
+	config *config.Config
+	db     *mongo.Database
+}
+
+func NewModerationRepository(config *config.Config, db *mongo.Database) moderation.ModerationRepository {
+	ctx := context.Background()
+
+	db.Collection(itemsCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			// a target has at most one item waiting for review
+			Keys: bson.D{{Key: "kind", Value: 1}, {Key: "target_id", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": moderation.StatusPending}),
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "flag_count", Value: -1}, {Key: "created_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "fingerprint", Value: 1}},
+		},
+	})
+
+	return &repository{
+		config: config,
+		db:     db,
+	}
+}
+
+func (repo *repository) SavePending(ctx context.Context, item *moderation.ItemModel) error {
+	item.Status = moderation.StatusPending
+
+	filter := bson.M{"kind": item.Kind, "target_id": item.TargetID, "status": moderation.StatusPending}
+	opts := options.FindOneAndReplace().SetUpsert(true).SetReturnDocument(options.After)
+
+	entity := &itemEntity{}
+	if err := repo.db.Collection(itemsCollection).FindOneAndReplace(ctx, filter, toItemEntity(item), opts).Decode(entity); err != nil {
+		return err
+	}
+
+	item.ID = entity.ID.Hex()
+	return nil
+}
+
+func (repo *repository) GetItem(ctx context.Context, id string) (*moderation.ItemModel, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, database.ErrNotFound
+	}
+
+	entity := &itemEntity{}
+	if err := repo.db.Collection(itemsCollection).FindOne(ctx, bson.M{"_id": objectID}).Decode(entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, database.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return entity.toItemModel(), nil
+}
+
+func (repo *repository) ListItems(ctx context.Context, params moderation.ListParams) ([]*moderation.ItemModel, int64, error) {
+	filter := bson.M{}
+	if params.Status != "" {
+		filter["status"] = params.Status
+	}
+	if params.Kind != "" {
+		filter["kind"] = params.Kind
+	}
+	if params.FlaggedOnly {
+		filter["flag_count"] = bson.M{"$gt": 0}
+	}
+
+	total, err := repo.db.Collection(itemsCollection).CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	limit := params.PageSize()
+	opts := options.Find().
+		SetSort(bson.D{{Key: "flag_count", Value: -1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip(params.Page * limit).
+		SetLimit(limit)
+
+	cursor, err := repo.db.Collection(itemsCollection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var results []*itemEntity
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, 0, err
+	}
+
+	return fp.Map(results, (*itemEntity).toItemModel), total, nil
+}
+
+func (repo *repository) Decide(ctx context.Context, id string, decision moderation.Decision) (*moderation.ItemModel, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, database.ErrNotFound
+	}
+
+	filter := bson.M{"_id": objectID, "status": moderation.StatusPending}
+	update := bson.M{"$set": bson.M{
+		"status":      decision.Status,
+		"reason":      decision.Reason,
+		"reviewer_id": decision.ReviewerID,
+		"reviewed_at": decision.ReviewedAt,
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	entity := &itemEntity{}
+	if err := repo.db.Collection(itemsCollection).FindOneAndUpdate(ctx, filter, update, opts).Decode(entity); err != nil {
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+
+		// tell a missing item from one that is not pending anymore
+		if _, err := repo.GetItem(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, moderation.ErrAlreadyReviewed
+	}
+
+	return entity.toItemModel(), nil
+}
+
+func (repo *repository) Reopen(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return database.ErrNotFound
+	}
+
+	filter := bson.M{"_id": objectID}
+	update := bson.M{
+		"$set":   bson.M{"status": moderation.StatusPending},
+		"$unset": bson.M{"reason": "", "reviewer_id": "", "reviewed_at": ""},
+	}
+
+	result, err := repo.db.Collection(itemsCollection).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return database.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *repository) Withdraw(ctx context.Context, kind moderation.Kind, targetID string) (*moderation.ItemModel, error) {
+	filter := bson.M{"kind": kind, "target_id": targetID, "status": moderation.StatusPending}
+	update := bson.M{"$set": bson.M{"status": moderation.StatusWithdrawn, "reviewed_at": time.Now()}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	entity := &itemEntity{}
+	if err := repo.db.Collection(itemsCollection).FindOneAndUpdate(ctx, filter, update, opts).Decode(entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, database.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return entity.toItemModel(), nil
+}
+
+func (repo *repository) FindDuplicates(ctx context.Context, fingerprint string, kind moderation.Kind, targetID string, limit int64) ([]*moderation.ItemModel, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"fingerprint": fingerprint,
+			"$nor":        bson.A{bson.M{"kind": kind, "target_id": targetID}},
+		}}},
+		// a target submitted several times is a single duplicate
+		{{Key: "$group", Value: bson.M{
+			"_id":        bson.M{"kind": "$kind", "target_id": "$target_id"},
+			"created_at": bson.M{"$min": "$created_at"},
+		}}},
+		{{Key: "$sort", Value: bson.M{"created_at": 1}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := repo.db.Collection(itemsCollection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []struct {
+		ID struct {
+			Kind     moderation.Kind `bson:"kind"`
+			TargetID string          `bson:"target_id"`
+		} `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	items := make([]*moderation.ItemModel, len(results))
+	for i, r := range results {
+		items[i] = &moderation.ItemModel{Kind: r.ID.Kind, TargetID: r.ID.TargetID}
+	}
+
+	return items, nil
+}
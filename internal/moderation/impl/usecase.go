@@ -0,0 +1,176 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/moderation"
+	"fmt"
+	"log"
+	"time"
+)
+
+type usecase struct {
+	config         *config.Config
+	eventBus       *database.EventBus
+	moderationRepo moderation.ModerationRepository
+	rules          *moderation.RuleEngine
+	targets        map[moderation.Kind]moderation.Target
+}
+
+func NewModerationUC(config *config.Config, eventBus *database.EventBus, repo moderation.ModerationRepository, rules *moderation.RuleEngine, targets map[moderation.Kind]moderation.Target) moderation.ModerationUC {
+	return &usecase{
+		config:         config,
+		eventBus:       eventBus,
+		moderationRepo: repo,
+		rules:          rules,
+		targets:        targets,
+	}
+}
+
+func (uc *usecase) target(kind moderation.Kind) (moderation.Target, error) {
+	target, ok := uc.targets[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", moderation.ErrUnknownKind, kind)
+	}
+
+	return target, nil
+}
+
+func (uc *usecase) Submit(ctx context.Context, kind moderation.Kind, targetID string) (*moderation.ItemModel, error) {
+	target, err := uc.target(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := target.Content(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &moderation.ItemModel{
+		Kind:        kind,
+		TargetID:    targetID,
+		AuthorID:    content.AuthorID,
+		Text:        content.Text,
+		Fingerprint: moderation.Fingerprint(content.Text),
+		CreatedAt:   time.Now(),
+	}
+
+	item.Flags, err = uc.rules.Check(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.moderationRepo.SavePending(ctx, item); err != nil {
+		return nil, err
+	}
+
+	if err := uc.eventBus.Publish(moderation.ItemSubmitted{ItemSnapshot: moderation.NewItemSnapshot(item)}); err != nil {
+		return nil, err
+	}
+
+	if len(item.Flags) > 0 {
+		if err := uc.eventBus.Publish(moderation.ItemFlagged{ItemSnapshot: moderation.NewItemSnapshot(item)}); err != nil {
+			return nil, err
+		}
+	}
+
+	return item, nil
+}
+
+func (uc *usecase) Withdraw(ctx context.Context, kind moderation.Kind, targetID string) error {
+	item, err := uc.moderationRepo.Withdraw(ctx, kind, targetID)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	return uc.eventBus.Publish(moderation.ItemWithdrawn{ItemSnapshot: moderation.NewItemSnapshot(item)})
+}
+
+func (uc *usecase) ListItems(ctx context.Context, params moderation.ListParams) ([]*moderation.ItemModel, int64, error) {
+	params.Limit = params.PageSize()
+	return uc.moderationRepo.ListItems(ctx, params)
+}
+
+func (uc *usecase) GetItem(ctx context.Context, id string) (*moderation.ItemModel, error) {
+	return uc.moderationRepo.GetItem(ctx, id)
+}
+
+func (uc *usecase) Approve(ctx context.Context, reviewerID, id string) (*moderation.ItemModel, error) {
+	return uc.decide(ctx, reviewerID, id, moderation.StatusApproved, "")
+}
+
+func (uc *usecase) Reject(ctx context.Context, reviewerID, id, reason string) (*moderation.ItemModel, error) {
+	return uc.decide(ctx, reviewerID, id, moderation.StatusRejected, reason)
+}
+
+// decide records the decision before applying it to the content, so that the events the
+// content publishes on its way out of review do not withdraw the item. The item goes
+// back to the queue when the content cannot be changed.
+func (uc *usecase) decide(ctx context.Context, reviewerID, id string, status moderation.Status, reason string) (*moderation.ItemModel, error) {
+	item, err := uc.moderationRepo.GetItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := uc.target(item.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err = uc.moderationRepo.Decide(ctx, id, moderation.Decision{
+		Status:     status,
+		Reason:     reason,
+		ReviewerID: reviewerID,
+		ReviewedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if status == moderation.StatusApproved {
+		err = target.Approve(ctx, reviewerID, item.TargetID)
+	} else {
+		err = target.Reject(ctx, reviewerID, item.TargetID, reason)
+	}
+	if err != nil {
+		if reopenErr := uc.moderationRepo.Reopen(ctx, id); reopenErr != nil {
+			log.Printf("Error reopening moderation item %s: %v", id, reopenErr)
+		}
+		return nil, err
+	}
+
+	var event database.Event = moderation.ItemApproved{ItemSnapshot: moderation.NewItemSnapshot(item)}
+	if status == moderation.StatusRejected {
+		event = moderation.ItemRejected{ItemSnapshot: moderation.NewItemSnapshot(item)}
+	}
+
+	if err := uc.eventBus.Publish(event); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (uc *usecase) Bulk(ctx context.Context, reviewerID string, action moderation.Action, ids []string, reason string) []moderation.BulkResult {
+	results := make([]moderation.BulkResult, len(ids))
+	for i, id := range ids {
+		results[i].ItemID = id
+
+		switch action {
+		case moderation.ActionApprove:
+			results[i].Item, results[i].Err = uc.Approve(ctx, reviewerID, id)
+		case moderation.ActionReject:
+			results[i].Item, results[i].Err = uc.Reject(ctx, reviewerID, id, reason)
+		default:
+			results[i].Err = fmt.Errorf("unknown action %q", action)
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,74 @@
+package moderation
+
+import "time"
+
+// Kind is the type of content an item of the queue refers to.
+type Kind string
+
+const (
+	KindRecipe  Kind = "recipe"
+	KindComment Kind = "comment"
+)
+
+// Status is the state of an item of the queue. An item is withdrawn when its content
+// leaves the review before anyone looked at it, for example when the author deletes it.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusApproved  Status = "approved"
+	StatusRejected  Status = "rejected"
+	StatusWithdrawn Status = "withdrawn"
+)
+
+// ItemModel is a piece of user content waiting for, or having had, a review. Fingerprint
+// identifies the normalized text and is used to spot duplicate submissions.
+type ItemModel struct {
+	ID          string
+	Kind        Kind
+	TargetID    string
+	AuthorID    string
+	Text        string
+	Fingerprint string
+	Flags       []FlagModel
+	Status      Status
+	Reason      string
+	ReviewerID  string
+	CreatedAt   time.Time
+	ReviewedAt  *time.Time
+}
+
+// FlagModel is raised by a rule on suspicious content, Detail says what was found.
+type FlagModel struct {
+	Rule   string
+	Detail string
+}
+
+// ContentModel is what the queue needs to know about the content under review.
+type ContentModel struct {
+	AuthorID string
+	Text     string
+}
+
+// Decision is the outcome of a review.
+type Decision struct {
+	Status     Status
+	Reason     string
+	ReviewerID string
+	ReviewedAt time.Time
+}
+
+// Action is a review applied to several items at once.
+type Action string
+
+const (
+	ActionApprove Action = "approve"
+	ActionReject  Action = "reject"
+)
+
+// BulkResult reports the outcome of an action for a single item, Err is nil on success.
+type BulkResult struct {
+	ItemID string
+	Item   *ItemModel
+	Err    error
+}
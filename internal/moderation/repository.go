@@ -0,0 +1,20 @@
+package moderation
+
+import "context"
+
+type ModerationRepository interface {
+	// SavePending stores the item as the pending item of its target, replacing the
+	// previous pending one if the content is submitted again.
+	SavePending(ctx context.Context, item *ItemModel) error
+	GetItem(ctx context.Context, id string) (*ItemModel, error)
+	ListItems(ctx context.Context, params ListParams) ([]*ItemModel, int64, error)
+	// Decide records the decision on a pending item, ErrAlreadyReviewed is returned for
+	// an item that is not pending anymore.
+	Decide(ctx context.Context, id string, decision Decision) (*ItemModel, error)
+	// Reopen puts a decided item back into the queue.
+	Reopen(ctx context.Context, id string) error
+	// Withdraw drops the pending item of the target, if any.
+	Withdraw(ctx context.Context, kind Kind, targetID string) (*ItemModel, error)
+	// FindDuplicates returns up to limit items of other targets with the same fingerprint.
+	FindDuplicates(ctx context.Context, fingerprint string, kind Kind, targetID string, limit int64) ([]*ItemModel, error)
+}
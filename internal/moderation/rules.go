@@ -0,0 +1,162 @@
+package moderation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	RuleBannedWords = "banned_words"
+	RuleLinkSpam    = "link_spam"
+	RuleDuplicate   = "duplicate"
+)
+
+// Rule inspects an item before it enters the queue and raises flags on anything
+// suspicious. Rules only flag, the decision is left to the reviewer.
+type Rule interface {
+	Name() string
+	Check(ctx context.Context, item *ItemModel) ([]FlagModel, error)
+}
+
+// RuleEngine runs every rule on an item and collects the flags.
+type RuleEngine struct {
+	rules []Rule
+}
+
+func NewRuleEngine(rules ...Rule) *RuleEngine {
+	return &RuleEngine{rules: rules}
+}
+
+func (e *RuleEngine) Check(ctx context.Context, item *ItemModel) ([]FlagModel, error) {
+	flags := []FlagModel{}
+	for _, rule := range e.rules {
+		found, err := rule.Check(ctx, item)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.Name(), err)
+		}
+		flags = append(flags, found...)
+	}
+
+	return flags, nil
+}
+
+// normalize lowercases the text and collapses everything but letters and digits into
+// single spaces.
+func normalize(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// Fingerprint identifies the text regardless of case, punctuation and spacing. Empty
+// text has no fingerprint.
+func Fingerprint(text string) string {
+	normalized := normalize(text)
+	if normalized == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// BannedWordsRule flags every banned word or phrase used in the text. Only whole words
+// match, so banning "ass" does not flag "molasses".
+type BannedWordsRule struct {
+	phrases []string
+}
+
+func NewBannedWordsRule(phrases []string) *BannedWordsRule {
+	rule := &BannedWordsRule{}
+	for _, phrase := range phrases {
+		if normalized := normalize(phrase); normalized != "" {
+			rule.phrases = append(rule.phrases, normalized)
+		}
+	}
+
+	return rule
+}
+
+func (r *BannedWordsRule) Name() string {
+	return RuleBannedWords
+}
+
+func (r *BannedWordsRule) Check(_ context.Context, item *ItemModel) ([]FlagModel, error) {
+	text := " " + normalize(item.Text) + " "
+
+	var flags []FlagModel
+	for _, phrase := range r.phrases {
+		if strings.Contains(text, " "+phrase+" ") {
+			flags = append(flags, FlagModel{Rule: RuleBannedWords, Detail: fmt.Sprintf("contains %q", phrase)})
+		}
+	}
+
+	return flags, nil
+}
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s]+`)
+
+// LinkSpamRule flags text with more links than allowed, a recipe rarely needs any.
+type LinkSpamRule struct {
+	maxLinks int
+}
+
+func NewLinkSpamRule(maxLinks int) *LinkSpamRule {
+	return &LinkSpamRule{maxLinks: maxLinks}
+}
+
+func (r *LinkSpamRule) Name() string {
+	return RuleLinkSpam
+}
+
+func (r *LinkSpamRule) Check(_ context.Context, item *ItemModel) ([]FlagModel, error) {
+	links := linkPattern.FindAllString(item.Text, -1)
+	if len(links) <= r.maxLinks {
+		return nil, nil
+	}
+
+	return []FlagModel{{
+		Rule:   RuleLinkSpam,
+		Detail: fmt.Sprintf("contains %d links, at most %d are allowed", len(links), r.maxLinks),
+	}}, nil
+}
+
+// maxDuplicates bounds the number of duplicates listed in a flag.
+const maxDuplicates = 5
+
+// DuplicateRule flags content already submitted for another target, which catches the
+// same comment posted under many recipes as well as copied recipes.
+type DuplicateRule struct {
+	repo ModerationRepository
+}
+
+func NewDuplicateRule(repo ModerationRepository) *DuplicateRule {
+	return &DuplicateRule{repo: repo}
+}
+
+func (r *DuplicateRule) Name() string {
+	return RuleDuplicate
+}
+
+func (r *DuplicateRule) Check(ctx context.Context, item *ItemModel) ([]FlagModel, error) {
+	if item.Fingerprint == "" {
+		return nil, nil
+	}
+
+	duplicates, err := r.repo.FindDuplicates(ctx, item.Fingerprint, item.Kind, item.TargetID, maxDuplicates)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := make([]FlagModel, len(duplicates))
+	for i, d := range duplicates {
+		flags[i] = FlagModel{Rule: RuleDuplicate, Detail: fmt.Sprintf("same content as %s %s", d.Kind, d.TargetID)}
+	}
+
+	return flags, nil
+}
@@ -0,0 +1,23 @@
+package moderation
+
+import "context"
+
+type ModerationUC interface {
+	// Submit queues the current content of the target and runs the rules on it.
+	Submit(ctx context.Context, kind Kind, targetID string) (*ItemModel, error)
+	Withdraw(ctx context.Context, kind Kind, targetID string) error
+
+	ListItems(ctx context.Context, params ListParams) ([]*ItemModel, int64, error)
+	GetItem(ctx context.Context, id string) (*ItemModel, error)
+	Approve(ctx context.Context, reviewerID, id string) (*ItemModel, error)
+	Reject(ctx context.Context, reviewerID, id, reason string) (*ItemModel, error)
+	Bulk(ctx context.Context, reviewerID string, action Action, ids []string, reason string) []BulkResult
+}
+
+// Target gives the queue access to the content of one kind. Approve and Reject apply the
+// decision to the content itself, for example by publishing a recipe.
+type Target interface {
+	Content(ctx context.Context, id string) (*ContentModel, error)
+	Approve(ctx context.Context, reviewerID, id string) error
+	Reject(ctx context.Context, reviewerID, id, reason string) error
+}
@@ -0,0 +1,18 @@
+package pantry
+
+import "time"
+
+// UpdateItemDTO changes a pantry item, a zero ExpiresOn removes the expiry date.
+type UpdateItemDTO struct {
+	Quantity  *float64
+	Unit      *string
+	ExpiresOn *time.Time
+}
+
+// MatchOptions tune the recipes suggested for a pantry. Recipes violating the dietary
+// profile of the user are left out unless IgnoreProfile is set.
+type MatchOptions struct {
+	Limit         int
+	MinCoverage   float64
+	IgnoreProfile bool
+}
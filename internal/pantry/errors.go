@@ -0,0 +1,5 @@
+package pantry
+
+import "errors"
+
+var ErrPantryFull = errors.New("pantry is full")
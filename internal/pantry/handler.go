@@ -0,0 +1,336 @@
+package pantry
+
+import (
+	"errors"
+	"flove/job/internal/base/database"
+	"flove/job/internal/base/response"
+	"flove/job/internal/recipe"
+	"flove/job/internal/user"
+	"flove/job/pkg/fp"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DateLayout is the format of expiry dates in requests and responses.
+const DateLayout = "2006-01-02"
+
+type PantryHandler struct {
+	pantryUC PantryUC
+}
+
+func NewPantryHandler(uc PantryUC) *PantryHandler {
+	return &PantryHandler{
+		pantryUC: uc,
+	}
+}
+
+// actor identifies the authenticated user making the request.
+func actor(ctx *gin.Context) recipe.Actor {
+	role, _ := ctx.Get("role")
+	r, _ := role.(user.Role)
+
+	return recipe.Actor{
+		UserID: ctx.GetString("userID"),
+		Role:   r,
+	}
+}
+
+// writeError maps the errors of the pantry use cases to a response.
+func writeError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrPantryFull):
+		response.WriteResponse(ctx, http.StatusConflict, err.Error())
+	default:
+		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+type itemResponse struct {
+	ID           string    `json:"id"`
+	IngredientID string    `json:"ingredient_id"`
+	Name         string    `json:"name"`
+	Quantity     float64   `json:"quantity"`
+	Unit         string    `json:"unit"`
+	ExpiresOn    string    `json:"expires_on,omitempty" example:"2024-06-05"`
+	DaysLeft     *int      `json:"days_left,omitempty"`
+	Expiring     bool      `json:"expiring"`
+	Expired      bool      `json:"expired"`
+	AddedAt      time.Time `json:"added_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func toItemResponse(i *PantryItemModel) itemResponse {
+	now := time.Now()
+	resp := itemResponse{
+		ID:           i.ID,
+		IngredientID: i.IngredientID,
+		Name:         i.Name,
+		Quantity:     i.Quantity,
+		Unit:         i.Unit,
+		Expiring:     i.IsExpiring(now),
+		Expired:      i.IsExpired(now),
+		AddedAt:      i.AddedAt,
+		UpdatedAt:    i.UpdatedAt,
+	}
+
+	if i.ExpiresOn != nil {
+		daysLeft := i.DaysLeft(now)
+		resp.ExpiresOn = i.ExpiresOn.Format(DateLayout)
+		resp.DaysLeft = &daysLeft
+	}
+
+	return resp
+}
+
+// parseExpiry reads an expiry date, the request binding has validated the layout already.
+func parseExpiry(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+
+	date, _ := time.Parse(DateLayout, value)
+	return &date
+}
+
+type addItemRequest struct {
+	IngredientID string  `json:"ingredient_id" binding:"required" example:"milk"`
+	Quantity     float64 `json:"quantity" binding:"gte=0" example:"1"`
+	Unit         string  `json:"unit" binding:"max=20" example:"l"`
+	ExpiresOn    string  `json:"expires_on" binding:"omitempty,datetime=2006-01-02" example:"2024-06-05"`
+}
+
+// @Summary Add a pantry item
+// @Description Record a catalogue ingredient the user has at home. A zero quantity means the
+// @Description amount is not known and is taken as enough when matching recipes.
+// @Security BasicAuth
+// @Tags Pantry
+// @Accept json
+// @Produce json
+// @Param item body addItemRequest true "Pantry item"
+// @Success 201 {object} response.Response{body=itemResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /pantry [post]
+func (h *PantryHandler) AddItem(ctx *gin.Context) {
+	var req addItemRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	item := &PantryItemModel{
+		IngredientID: req.IngredientID,
+		Quantity:     req.Quantity,
+		Unit:         req.Unit,
+		ExpiresOn:    parseExpiry(req.ExpiresOn),
+	}
+
+	if err := h.pantryUC.AddItem(ctx, actor(ctx), item); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusCreated, "pantry item succesfully added", toItemResponse(item))
+}
+
+// @Summary List the pantry
+// @Description List the ingredients the user has at home, soonest to expire first. Items
+// @Description expiring within 3 days are flagged.
+// @Security BasicAuth
+// @Tags Pantry
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.Response{body=[]itemResponse}
+// @Failure 500 {object} response.Response
+// @Router /pantry [get]
+func (h *PantryHandler) ListItems(ctx *gin.Context) {
+	items, err := h.pantryUC.ListItems(ctx, actor(ctx))
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", fp.Map(items, toItemResponse))
+}
+
+type updateItemRequest struct {
+	ID        string   `uri:"id" binding:"required"`
+	Quantity  *float64 `json:"quantity" binding:"omitempty,gte=0"`
+	Unit      *string  `json:"unit" binding:"omitempty,max=20"`
+	ExpiresOn *string  `json:"expires_on" binding:"omitempty,datetime=2006-01-02|eq=" example:"2024-06-05"`
+}
+
+// @Summary Update a pantry item
+// @Description Change the quantity, unit or expiry date of a pantry item, an empty expiry date
+// @Description removes it.
+// @Security BasicAuth
+// @Tags Pantry
+// @Accept json
+// @Produce json
+// @Param id path string true "Pantry item ID"
+// @Param item body updateItemRequest true "Fields to update"
+// @Success 200 {object} response.Response{body=itemResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /pantry/{id} [patch]
+func (h *PantryHandler) UpdateItem(ctx *gin.Context) {
+	var req updateItemRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dto := UpdateItemDTO{
+		Quantity: req.Quantity,
+		Unit:     req.Unit,
+	}
+
+	if req.ExpiresOn != nil {
+		dto.ExpiresOn = &time.Time{}
+		if expiresOn := parseExpiry(*req.ExpiresOn); expiresOn != nil {
+			dto.ExpiresOn = expiresOn
+		}
+	}
+
+	item, err := h.pantryUC.UpdateItem(ctx, actor(ctx), req.ID, dto)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "pantry item succesfully updated", toItemResponse(item))
+}
+
+// @Summary Delete a pantry item
+// @Description Remove an ingredient from the pantry
+// @Security BasicAuth
+// @Tags Pantry
+// @Accept json
+// @Produce json
+// @Param id path string true "Pantry item ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /pantry/{id} [delete]
+func (h *PantryHandler) DeleteItem(ctx *gin.Context) {
+	var req struct {
+		ID string `uri:"id" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.pantryUC.DeleteItem(ctx, actor(ctx), req.ID); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponse(ctx, http.StatusOK, "pantry item succesfully deleted")
+}
+
+type missingResponse struct {
+	IngredientID string  `json:"ingredient_id,omitempty"`
+	Name         string  `json:"name"`
+	Quantity     float64 `json:"quantity"`
+	Unit         string  `json:"unit"`
+	Partial      bool    `json:"partial"`
+}
+
+type expiringResponse struct {
+	IngredientID string `json:"ingredient_id"`
+	Name         string `json:"name"`
+	ExpiresOn    string `json:"expires_on" example:"2024-06-05"`
+	DaysLeft     int    `json:"days_left"`
+}
+
+type matchResponse struct {
+	Recipe   *recipe.RecipeModel `json:"recipe" swaggertype:"object"`
+	Coverage float64             `json:"coverage" example:"0.75"`
+	Score    float64             `json:"score"`
+	Missing  []missingResponse   `json:"missing"`
+	Expiring []expiringResponse  `json:"expiring"`
+}
+
+func toMatchResponse(m MatchModel) matchResponse {
+	return matchResponse{
+		Recipe:   m.Recipe,
+		Coverage: math.Round(m.Coverage*100) / 100,
+		Score:    math.Round(m.Score*1000) / 1000,
+		Missing: fp.Map(m.Missing, func(missing MissingModel) missingResponse {
+			return missingResponse(missing)
+		}),
+		Expiring: fp.Map(m.Expiring, func(e ExpiringModel) expiringResponse {
+			return expiringResponse{
+				IngredientID: e.IngredientID,
+				Name:         e.Name,
+				ExpiresOn:    e.ExpiresOn.Format(DateLayout),
+				DaysLeft:     e.DaysLeft,
+			}
+		}),
+	}
+}
+
+type matchRecipesRequest struct {
+	Limit         int     `form:"limit" binding:"omitempty,gte=1,lte=50" example:"20"`
+	MinCoverage   float64 `form:"min_coverage" binding:"omitempty,gte=0,lte=1" example:"0.5"`
+	IgnoreProfile bool    `form:"ignore_profile"`
+}
+
+// @Summary What can I cook now
+// @Description Rank the published recipes by how much of their ingredients the pantry covers,
+// @Description listing what is missing. Recipes using up ingredients that expire within 3 days
+// @Description rank higher, expired items do not count. Water, salt and pepper are assumed to be
+// @Description at hand. Recipes violating the dietary profile of the user are left out unless
+// @Description ignore_profile is set. The limit defaults to 20.
+// @Security BasicAuth
+// @Tags Pantry
+// @Accept json
+// @Produce json
+// @Param request query matchRecipesRequest false "Match parameters"
+// @Success 200 {object} response.Response{body=[]matchResponse}
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /pantry/recipes [get]
+func (h *PantryHandler) MatchRecipes(ctx *gin.Context) {
+	var req matchRecipesRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = 20
+	}
+
+	matches, err := h.pantryUC.MatchRecipes(ctx, actor(ctx), MatchOptions{
+		Limit:         limit,
+		MinCoverage:   req.MinCoverage,
+		IgnoreProfile: req.IgnoreProfile,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", fp.Map(matches, toMatchResponse))
+}
@@ -0,0 +1,173 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/pantry"
+	"flove/job/pkg/fp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var itemsCollection = "pantry_items"
+
+type itemEntity struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"`
+	UserID       string             `bson:"user_id"`
+	IngredientID string             `bson:"ingredient_id"`
+	Name         string             `bson:"name"`
+	Quantity     float64            `bson:"quantity"`
+	Unit         string             `bson:"unit"`
+	ExpiresOn    *time.Time         `bson:"expires_on"`
+	AddedAt      time.Time          `bson:"added_at"`
+	UpdatedAt    time.Time          `bson:"updated_at"`
+}
+
+func (e *itemEntity) toItemModel() *pantry.PantryItemModel {
+	return &pantry.PantryItemModel{
+		ID:           e.ID.Hex(),
+		UserID:       e.UserID,
+		IngredientID: e.IngredientID,
+		Name:         e.Name,
+		Quantity:     e.Quantity,
+		Unit:         e.Unit,
+		ExpiresOn:    e.ExpiresOn,
+		AddedAt:      e.AddedAt,
+		UpdatedAt:    e.UpdatedAt,
+	}
+}
+
+func toEntity(i *pantry.PantryItemModel) *itemEntity {
+	return &itemEntity{
+		UserID:       i.UserID,
+		IngredientID: i.IngredientID,
+		Name:         i.Name,
+		Quantity:     i.Quantity,
+		Unit:         i.Unit,
+		ExpiresOn:    i.ExpiresOn,
+		AddedAt:      i.AddedAt,
+		UpdatedAt:    i.UpdatedAt,
+	}
+}
+
+type repository struct {
+	config *config.Config
+	db     *mongo.Database
+}
+
+func NewPantryRepository(config *config.Config, db *mongo.Database) pantry.PantryRepository {
+	db.Collection(itemsCollection).Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "expires_on", Value: 1}},
+	})
+
+	return &repository{
+		config: config,
+		db:     db,
+	}
+}
+
+func (repo *repository) CreateItem(ctx context.Context, item *pantry.PantryItemModel) error {
+	result, err := repo.db.Collection(itemsCollection).InsertOne(ctx, toEntity(item))
+	if err != nil {
+		return err
+	}
+
+	item.ID = result.InsertedID.(primitive.ObjectID).Hex()
+	return nil
+}
+
+func (repo *repository) GetItem(ctx context.Context, id string) (*pantry.PantryItemModel, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, database.ErrNotFound
+	}
+
+	entity := &itemEntity{}
+	if err := repo.db.Collection(itemsCollection).FindOne(ctx, bson.M{"_id": objectID}).Decode(entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, database.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return entity.toItemModel(), nil
+}
+
+func (repo *repository) ListItems(ctx context.Context, userID string) ([]*pantry.PantryItemModel, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "expires_on", Value: 1}, {Key: "name", Value: 1}})
+
+	cursor, err := repo.db.Collection(itemsCollection).Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*itemEntity
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return fp.Map(results, (*itemEntity).toItemModel), nil
+}
+
+func (repo *repository) CountItems(ctx context.Context, userID string) (int64, error) {
+	return repo.db.Collection(itemsCollection).CountDocuments(ctx, bson.M{"user_id": userID})
+}
+
+func (repo *repository) UpdateItem(ctx context.Context, id string, update pantry.UpdateItemDTO) (*pantry.PantryItemModel, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, database.ErrNotFound
+	}
+
+	set := bson.M{"updated_at": time.Now()}
+
+	if update.Quantity != nil {
+		set["quantity"] = *update.Quantity
+	}
+	if update.Unit != nil {
+		set["unit"] = *update.Unit
+	}
+	if update.ExpiresOn != nil {
+		if update.ExpiresOn.IsZero() {
+			set["expires_on"] = nil
+		} else {
+			set["expires_on"] = *update.ExpiresOn
+		}
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	entity := &itemEntity{}
+	if err := repo.db.Collection(itemsCollection).FindOneAndUpdate(ctx, bson.M{"_id": objectID}, bson.M{"$set": set}, opts).Decode(entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, database.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return entity.toItemModel(), nil
+}
+
+func (repo *repository) DeleteItem(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return database.ErrNotFound
+	}
+
+	result, err := repo.db.Collection(itemsCollection).DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return database.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/ingredient"
+	"flove/job/internal/pantry"
+	"flove/job/internal/recipe"
+	"flove/job/internal/user"
+	"slices"
+	"time"
+)
+
+type usecase struct {
+	config       *config.Config
+	pantryRepo   pantry.PantryRepository
+	recipeUC     recipe.RecipeUC
+	ingredientUC ingredient.IngredientUC
+	userUC       user.UserUC
+}
+
+func NewPantryUC(config *config.Config, repo pantry.PantryRepository, recipeUC recipe.RecipeUC, ingredientUC ingredient.IngredientUC, userUC user.UserUC) pantry.PantryUC {
+	return &usecase{
+		config:       config,
+		pantryRepo:   repo,
+		recipeUC:     recipeUC,
+		ingredientUC: ingredientUC,
+		userUC:       userUC,
+	}
+}
+
+// ownItem loads an item of the pantry of the actor, the items of other users do not
+// exist for them.
+func (uc *usecase) ownItem(ctx context.Context, actor recipe.Actor, id string) (*pantry.PantryItemModel, error) {
+	item, err := uc.pantryRepo.GetItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.UserID != actor.UserID {
+		return nil, database.ErrNotFound
+	}
+
+	return item, nil
+}
+
+func (uc *usecase) AddItem(ctx context.Context, actor recipe.Actor, item *pantry.PantryItemModel) error {
+	known, err := uc.ingredientUC.GetIngredientByID(ctx, item.IngredientID)
+	if err != nil {
+		return err
+	}
+
+	count, err := uc.pantryRepo.CountItems(ctx, actor.UserID)
+	if err != nil {
+		return err
+	}
+
+	if count >= pantry.MaxItems {
+		return pantry.ErrPantryFull
+	}
+
+	now := time.Now()
+	item.UserID = actor.UserID
+	item.Name = known.Name
+	item.AddedAt = now
+	item.UpdatedAt = now
+
+	return uc.pantryRepo.CreateItem(ctx, item)
+}
+
+func (uc *usecase) ListItems(ctx context.Context, actor recipe.Actor) ([]*pantry.PantryItemModel, error) {
+	items, err := uc.pantryRepo.ListItems(ctx, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// items that keep come last rather than first
+	slices.SortStableFunc(items, func(a, b *pantry.PantryItemModel) int {
+		switch {
+		case a.ExpiresOn == nil && b.ExpiresOn == nil:
+			return 0
+		case a.ExpiresOn == nil:
+			return 1
+		case b.ExpiresOn == nil:
+			return -1
+		default:
+			return a.ExpiresOn.Compare(*b.ExpiresOn)
+		}
+	})
+
+	return items, nil
+}
+
+func (uc *usecase) UpdateItem(ctx context.Context, actor recipe.Actor, id string, dto pantry.UpdateItemDTO) (*pantry.PantryItemModel, error) {
+	if _, err := uc.ownItem(ctx, actor, id); err != nil {
+		return nil, err
+	}
+
+	return uc.pantryRepo.UpdateItem(ctx, id, dto)
+}
+
+func (uc *usecase) DeleteItem(ctx context.Context, actor recipe.Actor, id string) error {
+	if _, err := uc.ownItem(ctx, actor, id); err != nil {
+		return err
+	}
+
+	return uc.pantryRepo.DeleteItem(ctx, id)
+}
+
+func (uc *usecase) MatchRecipes(ctx context.Context, actor recipe.Actor, opts pantry.MatchOptions) ([]pantry.MatchModel, error) {
+	items, err := uc.pantryRepo.ListItems(ctx, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	catalogue := map[string]*ingredient.IngredientModel{}
+	for _, item := range items {
+		if _, ok := catalogue[item.IngredientID]; ok {
+			continue
+		}
+
+		known, err := uc.ingredientUC.GetIngredientByID(ctx, item.IngredientID)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, err
+		}
+		catalogue[item.IngredientID] = known
+	}
+
+	stock := pantry.NewStock(items, catalogue, time.Now())
+
+	var params recipe.SearchParams
+	if !opts.IgnoreProfile {
+		u, err := uc.userUC.GetUserByID(ctx, actor.UserID)
+		if err != nil {
+			return nil, err
+		}
+		params.Restrict(u.Dietary)
+	}
+
+	recipes, err := uc.recipeUC.ListRecipesUsing(ctx, stock.IngredientIDs(), params, pantry.MaxCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []pantry.MatchModel{}
+	for _, r := range recipes {
+		if match := stock.Match(r); match.Coverage >= opts.MinCoverage {
+			matches = append(matches, match)
+		}
+	}
+
+	pantry.Rank(matches)
+
+	if opts.Limit > 0 && len(matches) > opts.Limit {
+		matches = matches[:opts.Limit]
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,204 @@
+package pantry
+
+import (
+	"flove/job/internal/ingredient"
+	"flove/job/internal/recipe"
+	"flove/job/pkg/units"
+	"slices"
+	"time"
+)
+
+// ExpiryBoost is the score an ingredient expiring today adds to a recipe, the boost
+// shrinks as the expiry date gets further away.
+const ExpiryBoost = 0.15
+
+// Stock is what a pantry holds at a point in time, expired items left out.
+type Stock struct {
+	now       time.Time
+	items     map[string][]*PantryItemModel
+	catalogue map[string]*ingredient.IngredientModel
+}
+
+// NewStock gathers the usable items of a pantry by ingredient. The catalogue gives the
+// density and piece weight needed to compare amounts in different units.
+func NewStock(items []*PantryItemModel, catalogue map[string]*ingredient.IngredientModel, now time.Time) *Stock {
+	s := &Stock{
+		now:       now,
+		items:     map[string][]*PantryItemModel{},
+		catalogue: catalogue,
+	}
+
+	for _, item := range items {
+		if !item.IsExpired(now) {
+			s.items[item.IngredientID] = append(s.items[item.IngredientID], item)
+		}
+	}
+
+	return s
+}
+
+// IngredientIDs lists the ingredients the stock holds.
+func (s *Stock) IngredientIDs() []string {
+	ids := make([]string, 0, len(s.items))
+	for id := range s.items {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	return ids
+}
+
+// available returns the share of the needed amount the stock holds, between 0 and 1.
+// Amounts that are not known or cannot be compared are taken as enough.
+func (s *Stock) available(need recipe.IngredientModel) float64 {
+	items := s.items[need.IngredientID]
+	if len(items) == 0 {
+		return 0
+	}
+
+	if need.Quantity <= 0 {
+		return 1
+	}
+
+	total := 0.0
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return 1
+		}
+
+		converted, ok := convertAmount(item.Quantity, item.Unit, need.Unit, s.catalogue[need.IngredientID])
+		if !ok {
+			return 1
+		}
+		total += converted
+	}
+
+	return min(total/need.Quantity, 1)
+}
+
+// expiring returns the soonest to expire item of the ingredient when it expires within
+// the ExpiringWindow.
+func (s *Stock) expiring(ingredientID string) *PantryItemModel {
+	var soonest *PantryItemModel
+	for _, item := range s.items[ingredientID] {
+		if item.IsExpiring(s.now) && (soonest == nil || item.ExpiresOn.Before(*soonest.ExpiresOn)) {
+			soonest = item
+		}
+	}
+
+	return soonest
+}
+
+// Match ranks a recipe against the stock. Staples and ingredients outside the catalogue
+// are left out of the coverage, the latter are listed as missing since a pantry cannot
+// hold them.
+func (s *Stock) Match(r *recipe.RecipeModel) MatchModel {
+	match := MatchModel{
+		Recipe:   r,
+		Missing:  []MissingModel{},
+		Expiring: []ExpiringModel{},
+	}
+
+	counted, covered := 0, 0.0
+	for _, need := range r.Ingredients {
+		if slices.Contains(Staples, need.IngredientID) {
+			continue
+		}
+
+		share := 0.0
+		if need.IngredientID != "" {
+			counted++
+			share = s.available(need)
+			covered += share
+		}
+
+		if share < 1 {
+			missing := MissingModel{
+				IngredientID: need.IngredientID,
+				Name:         need.Name,
+				Quantity:     need.Quantity,
+				Unit:         need.Unit,
+				Partial:      share > 0,
+			}
+			if missing.Partial {
+				missing.Quantity = recipe.RoundQuantity(need.Quantity*(1-share), need.Unit)
+			}
+			match.Missing = append(match.Missing, missing)
+		}
+
+		if item := s.expiring(need.IngredientID); item != nil && share > 0 {
+			daysLeft := item.DaysLeft(s.now)
+			match.Expiring = append(match.Expiring, ExpiringModel{
+				IngredientID: need.IngredientID,
+				Name:         item.Name,
+				ExpiresOn:    *item.ExpiresOn,
+				DaysLeft:     daysLeft,
+			})
+			match.Score += ExpiryBoost * float64(ExpiringWindow+1-daysLeft) / float64(ExpiringWindow+1)
+		}
+	}
+
+	if counted > 0 {
+		match.Coverage = covered / float64(counted)
+	}
+	match.Score += match.Coverage
+
+	return match
+}
+
+// Rank orders matches by score, then by the fewest ingredients to buy and the most
+// popular recipe.
+func Rank(matches []MatchModel) {
+	slices.SortStableFunc(matches, func(a, b MatchModel) int {
+		switch {
+		case a.Score != b.Score:
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		case len(a.Missing) != len(b.Missing):
+			return len(a.Missing) - len(b.Missing)
+		case a.Recipe.Popularity() > b.Recipe.Popularity():
+			return -1
+		case a.Recipe.Popularity() < b.Recipe.Popularity():
+			return 1
+		default:
+			return 0
+		}
+	})
+}
+
+// convertAmount expresses a pantry amount in the unit a recipe asks for. Pieces are
+// weighed with the piece weight of the ingredient when one side is counted and the other
+// is not.
+func convertAmount(quantity float64, from, to string, known *ingredient.IngredientModel) (float64, bool) {
+	var density, pieceWeight float64
+	if known != nil {
+		density, pieceWeight = known.Density, known.PieceWeight
+	}
+
+	if converted, err := units.Convert(quantity, from, to, density); err == nil {
+		return converted, true
+	}
+
+	fromUnit, ok := units.Lookup(from)
+	if !ok || pieceWeight <= 0 {
+		return 0, false
+	}
+
+	toUnit, ok := units.Lookup(to)
+	if !ok {
+		return 0, false
+	}
+
+	switch {
+	case fromUnit.Symbol == "pc":
+		converted, err := units.Convert(quantity*pieceWeight, "g", to, density)
+		return converted, err == nil
+	case toUnit.Symbol == "pc":
+		grams, err := units.Convert(quantity, from, "g", density)
+		return grams / pieceWeight, err == nil
+	default:
+		return 0, false
+	}
+}
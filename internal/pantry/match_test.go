@@ -0,0 +1,155 @@
+package pantry
+
+import (
+	"flove/job/internal/ingredient"
+	"flove/job/internal/recipe"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestConvertAmount(t *testing.T) {
+	egg := &ingredient.IngredientModel{ID: "egg", PieceWeight: 50}
+	flour := &ingredient.IngredientModel{ID: "flour", Density: 0.5}
+
+	tests := []struct {
+		name     string
+		quantity float64
+		from, to string
+		known    *ingredient.IngredientModel
+		want     float64
+		ok       bool
+	}{
+		{name: "same dimension", quantity: 1, from: "kg", to: "g", want: 1000, ok: true},
+		{name: "volume to mass with density", quantity: 200, from: "ml", to: "g", known: flour, want: 100, ok: true},
+		{name: "volume to mass without density", quantity: 200, from: "ml", to: "g"},
+		{name: "pieces to grams", quantity: 3, from: "pc", to: "g", known: egg, want: 150, ok: true},
+		{name: "kilograms to pieces", quantity: 0.1, from: "kg", to: "pieces", known: egg, want: 2, ok: true},
+		{name: "pieces without a piece weight", quantity: 3, from: "pc", to: "g", known: flour},
+		{name: "pieces to cloves", quantity: 3, from: "pc", to: "clove", known: egg, want: 3, ok: true},
+		{name: "unknown unit", quantity: 1, from: "handful", to: "g", known: egg},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := convertAmount(tt.quantity, tt.from, tt.to, tt.known)
+			if ok != tt.ok || math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("convertAmount(%v %s, %s) = %v, %v, want %v, %v", tt.quantity, tt.from, tt.to, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestStockMatch(t *testing.T) {
+	now := time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC)
+	in := func(days int) *time.Time {
+		date := time.Date(2026, 10, 18+days, 0, 0, 0, 0, time.UTC)
+		return &date
+	}
+
+	catalogue := map[string]*ingredient.IngredientModel{
+		"egg": {ID: "egg", PieceWeight: 50},
+	}
+
+	items := []*PantryItemModel{
+		{IngredientID: "egg", Name: "Eggs", Quantity: 2, Unit: "pc"},
+		{IngredientID: "flour", Name: "Flour", Quantity: 250, Unit: "g"},
+		{IngredientID: "milk", Name: "Milk", Quantity: 1, Unit: "l", ExpiresOn: in(-1)},
+		{IngredientID: "butter", Name: "Butter", ExpiresOn: in(1)},
+		{IngredientID: "cheese", Name: "Cheese", Quantity: 100, Unit: "g", ExpiresOn: in(10)},
+	}
+
+	tests := []struct {
+		name         string
+		ingredients  []recipe.IngredientModel
+		wantCoverage float64
+		wantScore    float64
+		wantMissing  []MissingModel
+		wantExpiring int
+	}{
+		{
+			name: "everything at hand",
+			ingredients: []recipe.IngredientModel{
+				{IngredientID: "flour", Name: "flour", Quantity: 0.2, Unit: "kg"},
+				{IngredientID: "cheese", Name: "cheese", Quantity: 50, Unit: "g"},
+			},
+			wantCoverage: 1,
+			wantScore:    1,
+		},
+		{
+			name: "staples are left out",
+			ingredients: []recipe.IngredientModel{
+				{IngredientID: "flour", Name: "flour", Quantity: 100, Unit: "g"},
+				{IngredientID: "salt", Name: "salt", Quantity: 1, Unit: "pinch"},
+			},
+			wantCoverage: 1,
+			wantScore:    1,
+		},
+		{
+			name: "short amounts count for the part there is",
+			ingredients: []recipe.IngredientModel{
+				{IngredientID: "egg", Name: "eggs", Quantity: 200, Unit: "g"},
+				{IngredientID: "flour", Name: "flour", Quantity: 100, Unit: "g"},
+			},
+			wantCoverage: 0.75,
+			wantScore:    0.75,
+			wantMissing:  []MissingModel{{IngredientID: "egg", Name: "eggs", Quantity: 100, Unit: "g", Partial: true}},
+		},
+		{
+			name: "expired items are not used",
+			ingredients: []recipe.IngredientModel{
+				{IngredientID: "milk", Name: "milk", Quantity: 200, Unit: "ml"},
+				{IngredientID: "flour", Name: "flour", Quantity: 100, Unit: "g"},
+			},
+			wantCoverage: 0.5,
+			wantScore:    0.5,
+			wantMissing:  []MissingModel{{IngredientID: "milk", Name: "milk", Quantity: 200, Unit: "ml"}},
+		},
+		{
+			name: "ingredients outside the catalogue are missing but not counted",
+			ingredients: []recipe.IngredientModel{
+				{Name: "saffron", Quantity: 1, Unit: "pinch"},
+				{IngredientID: "flour", Name: "flour", Quantity: 100, Unit: "g"},
+			},
+			wantCoverage: 1,
+			wantScore:    1,
+			wantMissing:  []MissingModel{{Name: "saffron", Quantity: 1, Unit: "pinch"}},
+		},
+		{
+			name: "expiring items boost the score",
+			ingredients: []recipe.IngredientModel{
+				{IngredientID: "butter", Name: "butter", Quantity: 50, Unit: "g"},
+			},
+			wantCoverage: 1,
+			wantScore:    1 + ExpiryBoost*float64(ExpiringWindow)/float64(ExpiringWindow+1),
+			wantExpiring: 1,
+		},
+	}
+
+	stock := NewStock(items, catalogue, now)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := stock.Match(&recipe.RecipeModel{Ingredients: tt.ingredients})
+
+			if math.Abs(match.Coverage-tt.wantCoverage) > 1e-9 {
+				t.Errorf("Coverage = %v, want %v", match.Coverage, tt.wantCoverage)
+			}
+			if math.Abs(match.Score-tt.wantScore) > 1e-9 {
+				t.Errorf("Score = %v, want %v", match.Score, tt.wantScore)
+			}
+			if len(match.Expiring) != tt.wantExpiring {
+				t.Errorf("Expiring = %v, want %d items", match.Expiring, tt.wantExpiring)
+			}
+
+			if len(match.Missing) != len(tt.wantMissing) {
+				t.Fatalf("Missing = %v, want %v", match.Missing, tt.wantMissing)
+			}
+			for i, missing := range match.Missing {
+				if missing != tt.wantMissing[i] {
+					t.Errorf("Missing[%d] = %+v, want %+v", i, missing, tt.wantMissing[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package pantry
+
+import (
+	"flove/job/internal/recipe"
+	"time"
+)
+
+const (
+	// MaxItems bounds the items of a pantry.
+	MaxItems = 500
+	// ExpiringWindow is the number of days before its expiry date an item is expiring.
+	ExpiringWindow = 3
+	// MaxCandidates bounds the recipes ranked for a pantry, those using the most pantry
+	// ingredients are considered first.
+	MaxCandidates = 200
+)
+
+// Staples are assumed to be at hand in every kitchen, recipes are not held back for them.
+var Staples = []string{"water", "salt", "black-pepper"}
+
+// PantryItemModel is an ingredient a user has at home. A zero Quantity means the amount
+// is not known and is taken as enough, a nil ExpiresOn means the item keeps.
+type PantryItemModel struct {
+	ID           string
+	UserID       string
+	IngredientID string
+	Name         string
+	Quantity     float64
+	Unit         string
+	ExpiresOn    *time.Time
+	AddedAt      time.Time
+	UpdatedAt    time.Time
+}
+
+// DaysLeft returns the number of days until the item expires, zero on its expiry date
+// and less once it has expired.
+func (i *PantryItemModel) DaysLeft(now time.Time) int {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return int(i.ExpiresOn.Sub(today).Hours() / 24)
+}
+
+// IsExpired tells whether the item is past its expiry date, expired items are not used.
+func (i *PantryItemModel) IsExpired(now time.Time) bool {
+	return i.ExpiresOn != nil && i.DaysLeft(now) < 0
+}
+
+// IsExpiring tells whether the item expires within the ExpiringWindow.
+func (i *PantryItemModel) IsExpiring(now time.Time) bool {
+	return i.ExpiresOn != nil && !i.IsExpired(now) && i.DaysLeft(now) <= ExpiringWindow
+}
+
+// MatchModel is a recipe ranked against a pantry. Coverage is the share of the recipe
+// ingredients at hand, an ingredient short of the amount needed counts for the part
+// there is. Score adds a boost for every expiring ingredient the recipe uses up.
+type MatchModel struct {
+	Recipe   *recipe.RecipeModel
+	Coverage float64
+	Score    float64
+	Missing  []MissingModel
+	Expiring []ExpiringModel
+}
+
+// MissingModel is an ingredient to buy before cooking a recipe. Partial is set when some
+// of it is at hand and Quantity is the amount still lacking.
+type MissingModel struct {
+	IngredientID string
+	Name         string
+	Quantity     float64
+	Unit         string
+	Partial      bool
+}
+
+// ExpiringModel is a pantry ingredient a recipe uses that expires soon.
+type ExpiringModel struct {
+	IngredientID string
+	Name         string
+	ExpiresOn    time.Time
+	DaysLeft     int
+}
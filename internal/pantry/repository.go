@@ -0,0 +1,12 @@
+package pantry
+
+import "context"
+
+type PantryRepository interface {
+	CreateItem(ctx context.Context, item *PantryItemModel) error
+	GetItem(ctx context.Context, id string) (*PantryItemModel, error)
+	ListItems(ctx context.Context, userID string) ([]*PantryItemModel, error)
+	CountItems(ctx context.Context, userID string) (int64, error)
+	UpdateItem(ctx context.Context, id string, update UpdateItemDTO) (*PantryItemModel, error)
+	DeleteItem(ctx context.Context, id string) error
+}
@@ -0,0 +1,18 @@
+package pantry
+
+import (
+	"context"
+	"flove/job/internal/recipe"
+)
+
+type PantryUC interface {
+	// AddItem stocks a catalogue ingredient in the pantry of the actor.
+	AddItem(ctx context.Context, actor recipe.Actor, item *PantryItemModel) error
+	// ListItems lists the pantry of the actor, soonest to expire first.
+	ListItems(ctx context.Context, actor recipe.Actor) ([]*PantryItemModel, error)
+	UpdateItem(ctx context.Context, actor recipe.Actor, id string, dto UpdateItemDTO) (*PantryItemModel, error)
+	DeleteItem(ctx context.Context, actor recipe.Actor, id string) error
+
+	// MatchRecipes ranks the published recipes by how much of them the pantry covers.
+	MatchRecipes(ctx context.Context, actor recipe.Actor, opts MatchOptions) ([]MatchModel, error)
+}
@@ -1,11 +1,122 @@
 package recipe
 
+import (
+	"flove/job/internal/ingredient"
+	"flove/job/internal/user"
+	"flove/job/pkg/units"
+	"time"
+)
+
 type UpdateRecipeDTO struct {
 	Name        *string
 	Description *string
 	Category    *string
 	Tags        *[]string
-	Nutrition   *NutritionInfo
+	Ingredients *[]IngredientModel
+	Steps       *[]StepModel
+	Nutrition   *UpdateNutritionDTO
 	CookTime    *int
 	Servings    *int
+
+	NutritionSource *string
+	Allergens       *[]string
+	DietLabels      *[]string
+	// RecomputeNutrition drops a manual override and computes nutrition from the ingredients again.
+	RecomputeNutrition bool
+}
+
+// UpdateNutritionDTO patches nutrition values one by one, nil fields are left untouched.
+type UpdateNutritionDTO struct {
+	Calories      *float64
+	Protein       *float64
+	Fat           *float64
+	Carbohydrates *float64
+	Fiber         *float64
+	Sugar         *float64
+	Sodium        *float64
+}
+
+type UpdateStepDTO struct {
+	Text          *string
+	Duration      *time.Duration
+	Temperature   *Temperature
+	IngredientIDs *[]string
+}
+
+// Apply returns the nutrition with the set fields of the update written over it.
+func (dto *UpdateNutritionDTO) Apply(n NutritionInfo) NutritionInfo {
+	if dto == nil {
+		return n
+	}
+
+	if dto.Calories != nil {
+		n.Calories = *dto.Calories
+	}
+	if dto.Protein != nil {
+		n.Protein = *dto.Protein
+	}
+	if dto.Fat != nil {
+		n.Fat = *dto.Fat
+	}
+	if dto.Carbohydrates != nil {
+		n.Carbohydrates = *dto.Carbohydrates
+	}
+	if dto.Fiber != nil {
+		n.Fiber = *dto.Fiber
+	}
+	if dto.Sugar != nil {
+		n.Sugar = *dto.Sugar
+	}
+	if dto.Sodium != nil {
+		n.Sodium = *dto.Sodium
+	}
+
+	return n
+}
+
+// SearchParams select recipes for a search. ExcludeAllergens drops recipes containing
+// any of the allergens, DietLabels keeps only recipes that fit every listed diet.
+// ExcludeIngredients and MaxCalories (per serving, zero means no limit) come from
+// the dietary profile of the user. Nutrition ranges are keyed by nutrient and apply
+// per serving. Cursor is an opaque token from a previous result; without it Page
+// selects the page the old way, by skipping the recipes before it.
+type SearchParams struct {
+	Query              string
+	Categories         []string
+	Tags               []string
+	TagMatch           TagMatch
+	ExcludeAllergens   []string
+	DietLabels         []string
+	ExcludeIngredients []string
+	MaxCalories        float64
+	Nutrition          map[string]Range
+	Servings           Range
+	CookTime           Range
+	Sort               SortOrder
+	Cursor             string
+	Page               int64
+	Limit              int64
+}
+
+// PageSize is the requested limit with the default and the cap applied.
+func (p *SearchParams) PageSize() int64 {
+	if p.Limit <= 0 {
+		return DefaultSearchLimit
+	}
+
+	return min(p.Limit, MaxSearchLimit)
+}
+
+// Restrict narrows the search down to recipes that do not violate the dietary profile.
+func (p *SearchParams) Restrict(profile user.DietaryProfile) {
+	profile.Restrict(&p.ExcludeAllergens, &p.DietLabels, &p.ExcludeIngredients, &p.MaxCalories)
+}
+
+// ViewOptions describe how a recipe is presented to the reader. Zero values keep
+// the recipe as it is stored. Catalogue holds the entries of the ingredients, whose
+// densities convert between volumes and weights.
+type ViewOptions struct {
+	Servings   int
+	UnitSystem units.System
+	Catalogue  map[string]*ingredient.IngredientModel
 }
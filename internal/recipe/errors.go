@@ -0,0 +1,13 @@
+package recipe
+
+import "errors"
+
+var (
+	ErrUnknownIngredient = errors.New("unknown ingredient")
+	ErrInvalidCursor     = errors.New("invalid cursor")
+	ErrForbidden         = errors.New("recipe belongs to another user")
+	ErrInvalidTransition = errors.New("invalid status transition")
+	ErrOwnRecipe         = errors.New("authors cannot rate their own recipes")
+	ErrTooManyImages     = errors.New("too many images")
+	ErrNutritionOverride = errors.New("only admins can set the nutrition of a recipe")
+)
@@ -0,0 +1,169 @@
+package recipe
+
+import (
+	"encoding/json"
+	"errors"
+	"flove/job/internal/base/database"
+	"strconv"
+	"strings"
+)
+
+// Types of the events published by the recipe use cases.
+const (
+	EventCreated = "recipe:created"
+	EventUpdated = "recipe:updated"
+	EventDeleted = "recipe:deleted"
+	EventRated   = "recipe:rated"
+	EventUnrated = "recipe:unrated"
+)
+
+// errAmbiguousLegacy is returned for legacy recipe messages whose free text fields held
+// colons, the fields cannot be told apart.
+var errAmbiguousLegacy = errors.New("legacy recipe message is ambiguous")
+
+// RecipeSnapshot carries the recipe fields the recommendation graph needs. The status
+// lets consumers drop recipes that are no longer published.
+type RecipeSnapshot struct {
+	ID                 string   `json:"id"`
+	Name               string   `json:"name"`
+	Category           string   `json:"category"`
+	Tags               []string `json:"tags"`
+	Allergens          []string `json:"allergens"`
+	DietLabels         []string `json:"diet_labels"`
+	IngredientIDs      []string `json:"ingredient_ids"`
+	CaloriesPerServing float64  `json:"calories_per_serving"`
+	Status             Status   `json:"status"`
+}
+
+func NewRecipeSnapshot(r *RecipeModel) RecipeSnapshot {
+	ingredientIDs := make([]string, len(r.Ingredients))
+	for i, line := range r.Ingredients {
+		ingredientIDs[i] = line.IngredientID
+	}
+
+	return RecipeSnapshot{
+		ID:                 r.ID,
+		Name:               r.Name,
+		Category:           r.Category,
+		Tags:               r.Tags,
+		Allergens:          r.Allergens,
+		DietLabels:         r.DietLabels,
+		IngredientIDs:      ingredientIDs,
+		CaloriesPerServing: r.Nutrition.PerServing(r.Servings).Calories,
+		Status:             r.Status,
+	}
+}
+
+func (RecipeSnapshot) EventVersion() int { return 1 }
+
+// Upcasters reads the legacy id:name:category:tags:allergens:diets:ingredients:calories:status
+// messages, which only parse when the name, category and tags hold no colon.
+func (RecipeSnapshot) Upcasters() map[int]database.Upcaster {
+	return map[int]database.Upcaster{
+		database.LegacyVersion: func(payload json.RawMessage) (json.RawMessage, error) {
+			fields, err := database.LegacyFields(payload, 9)
+			if err != nil {
+				return nil, err
+			}
+			if strings.Contains(fields[8], ":") {
+				return nil, errAmbiguousLegacy
+			}
+
+			calories, err := strconv.ParseFloat(fields[7], 64)
+			if err != nil {
+				return nil, err
+			}
+
+			return json.Marshal(RecipeSnapshot{
+				ID:                 fields[0],
+				Name:               fields[1],
+				Category:           fields[2],
+				Tags:               database.LegacyList(fields[3]),
+				Allergens:          database.LegacyList(fields[4]),
+				DietLabels:         database.LegacyList(fields[5]),
+				IngredientIDs:      database.LegacyList(fields[6]),
+				CaloriesPerServing: calories,
+				Status:             Status(fields[8]),
+			})
+		},
+	}
+}
+
+type RecipeCreated struct{ RecipeSnapshot }
+
+func (RecipeCreated) EventType() string { return EventCreated }
+
+type RecipeUpdated struct{ RecipeSnapshot }
+
+func (RecipeUpdated) EventType() string { return EventUpdated }
+
+type RecipeDeleted struct {
+	ID string `json:"id"`
+}
+
+func (RecipeDeleted) EventType() string { return EventDeleted }
+func (RecipeDeleted) EventVersion() int { return 1 }
+
+// Upcasters reads the legacy messages holding the bare recipe ID.
+func (RecipeDeleted) Upcasters() map[int]database.Upcaster {
+	return map[int]database.Upcaster{
+		database.LegacyVersion: func(payload json.RawMessage) (json.RawMessage, error) {
+			fields, err := database.LegacyFields(payload, 1)
+			if err != nil {
+				return nil, err
+			}
+
+			return json.Marshal(RecipeDeleted{ID: fields[0]})
+		},
+	}
+}
+
+type RecipeRated struct {
+	RecipeID string `json:"recipe_id"`
+	UserID   string `json:"user_id"`
+	Stars    int    `json:"stars"`
+}
+
+func (RecipeRated) EventType() string { return EventRated }
+func (RecipeRated) EventVersion() int { return 1 }
+
+// Upcasters reads the legacy recipeID:userID:stars messages.
+func (RecipeRated) Upcasters() map[int]database.Upcaster {
+	return map[int]database.Upcaster{
+		database.LegacyVersion: func(payload json.RawMessage) (json.RawMessage, error) {
+			fields, err := database.LegacyFields(payload, 3)
+			if err != nil {
+				return nil, err
+			}
+
+			stars, err := strconv.Atoi(fields[2])
+			if err != nil {
+				return nil, err
+			}
+
+			return json.Marshal(RecipeRated{RecipeID: fields[0], UserID: fields[1], Stars: stars})
+		},
+	}
+}
+
+type RecipeUnrated struct {
+	RecipeID string `json:"recipe_id"`
+	UserID   string `json:"user_id"`
+}
+
+func (RecipeUnrated) EventType() string { return EventUnrated }
+func (RecipeUnrated) EventVersion() int { return 1 }
+
+// Upcasters reads the legacy recipeID:userID messages.
+func (RecipeUnrated) Upcasters() map[int]database.Upcaster {
+	return map[int]database.Upcaster{
+		database.LegacyVersion: func(payload json.RawMessage) (json.RawMessage, error) {
+			fields, err := database.LegacyFields(payload, 2)
+			if err != nil {
+				return nil, err
+			}
+
+			return json.Marshal(RecipeUnrated{RecipeID: fields[0], UserID: fields[1]})
+		},
+	}
+}
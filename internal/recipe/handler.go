@@ -1,9 +1,15 @@
 package recipe
 
 import (
+	"errors"
 	"flove/job/config"
 	"flove/job/internal/base/database"
 	"flove/job/internal/base/response"
+	"flove/job/internal/media"
+	"flove/job/internal/user"
+	"flove/job/pkg/fp"
+	"flove/job/pkg/units"
+	"io"
 	"net/http"
 	"time"
 
@@ -13,15 +19,54 @@ import (
 type RecipeHandler struct {
 	config   *config.Config
 	recipeUC RecipeUC
+	userUC   user.UserUC
 }
 
-func NewRecipeHandler(config *config.Config, uc RecipeUC) *RecipeHandler {
+func NewRecipeHandler(config *config.Config, uc RecipeUC, userUC user.UserUC) *RecipeHandler {
 	return &RecipeHandler{
 		config:   config,
 		recipeUC: uc,
+		userUC:   userUC,
 	}
 }
 
+// actor identifies the authenticated user making the request.
+func actor(ctx *gin.Context) Actor {
+	role, _ := ctx.Get("role")
+	r, _ := role.(user.Role)
+
+	return Actor{
+		UserID: ctx.GetString("userID"),
+		Role:   r,
+	}
+}
+
+type viewRequest struct {
+	Servings int    `form:"servings" binding:"omitempty,gte=1,lte=100"`
+	Units    string `form:"units" binding:"omitempty,oneof=metric imperial"`
+}
+
+// viewOptions falls back to the unit system stored in the user preferences
+// when the request does not ask for one. The recipes are the ones to be viewed, their
+// ingredients are looked up in the catalogue for the unit conversion.
+func (h *RecipeHandler) viewOptions(ctx *gin.Context, req viewRequest, recipes ...*RecipeModel) ViewOptions {
+	opts := ViewOptions{
+		Servings:   req.Servings,
+		UnitSystem: units.ParseSystem(req.Units),
+	}
+
+	if opts.UnitSystem == "" {
+		if u, err := h.userUC.GetUserByID(ctx, ctx.GetString("userID")); err == nil {
+			opts.UnitSystem = u.UnitSystem
+		}
+	}
+
+	// without the catalogue the common densities by name stand in
+	opts.Catalogue, _ = h.recipeUC.IngredientCatalogue(ctx, recipes...)
+
+	return opts
+}
+
 type nutrition struct {
 	Calories      float64 `json:"calories"`
 	Protein       float64 `json:"protein"`
@@ -32,17 +77,87 @@ type nutrition struct {
 	Sodium        float64 `json:"sodium"`
 }
 
+type recipeIngredient struct {
+	IngredientID string  `json:"ingredient_id" binding:"required"`
+	Name         string  `json:"name" binding:"required"`
+	Quantity     float64 `json:"quantity" binding:"gte=0"`
+	Unit         string  `json:"unit"`
+	Note         string  `json:"note"`
+}
+
+type temperature struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit" binding:"required,oneof=C F"`
+}
+
+type step struct {
+	Text            string       `json:"text" binding:"required"`
+	DurationSeconds int          `json:"duration_seconds" binding:"gte=0"`
+	Temperature     *temperature `json:"temperature"`
+	IngredientIDs   []string     `json:"ingredient_ids"`
+}
+
+func (s step) toStepModel() StepModel {
+	model := StepModel{
+		Text:          s.Text,
+		Duration:      time.Duration(s.DurationSeconds) * time.Second,
+		IngredientIDs: s.IngredientIDs,
+	}
+
+	if s.Temperature != nil {
+		model.Temperature = &Temperature{
+			Value: s.Temperature.Value,
+			Unit:  s.Temperature.Unit,
+		}
+	}
+
+	return model
+}
+
+type stepResponse struct {
+	ID              string          `json:"id"`
+	Text            string          `json:"text"`
+	DurationSeconds int             `json:"duration_seconds,omitempty"`
+	Temperature     *temperature    `json:"temperature,omitempty"`
+	IngredientIDs   []string        `json:"ingredient_ids"`
+	Images          []imageResponse `json:"images"`
+}
+
+func toStepResponse(s StepModel) stepResponse {
+	resp := stepResponse{
+		ID:              s.ID,
+		Text:            s.Text,
+		DurationSeconds: int(s.Duration.Seconds()),
+		IngredientIDs:   s.IngredientIDs,
+		Images:          fp.Map(s.Images, toImageResponse),
+	}
+
+	if s.Temperature != nil {
+		resp.Temperature = &temperature{
+			Value: s.Temperature.Value,
+			Unit:  s.Temperature.Unit,
+		}
+	}
+
+	return resp
+}
+
 type createRecipeRequest struct {
-	Name        string    `json:"name" binding:"required"`
-	Description string    `json:"description" binding:"required"`
-	Category    string    `json:"category" binding:"required"`
-	Tags        []string  `json:"tags"`
-	Nutrition   nutrition `json:"nutrition"`
-	Servings    int       `json:"servings" binding:"required"`
+	Name        string             `json:"name" binding:"required"`
+	Description string             `json:"description" binding:"required"`
+	Category    string             `json:"category" binding:"required"`
+	Tags        []string           `json:"tags"`
+	Ingredients []recipeIngredient `json:"ingredients" binding:"dive"`
+	Steps       []step             `json:"steps" binding:"dive"`
+	Nutrition   *nutrition         `json:"nutrition"`
+	CookTime    int                `json:"cook_time" binding:"gte=0"`
+	Servings    int                `json:"servings" binding:"required"`
 }
 
 // @Summary Create a new recipe
-// @Description Create a new recipe with the given details
+// @Description Create a new recipe with the given details. Recipes written by users start
+// @Description as drafts, recipes written by admins are published right away. The nutrition
+// @Description is computed from the ingredients, only admins may send it instead.
 // @Security BasicAuth
 // @Tags Recipe
 // @Accept json
@@ -50,6 +165,7 @@ type createRecipeRequest struct {
 // @Param recipe body createRecipeRequest true "Recipe details"
 // @Success 201 {object} response.Response
 // @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /recipes [post]
 func (h *RecipeHandler) CreateRecipe(ctx *gin.Context) {
@@ -65,18 +181,36 @@ func (h *RecipeHandler) CreateRecipe(ctx *gin.Context) {
 		Description: req.Description,
 		Category:    req.Category,
 		Tags:        req.Tags,
-		Nutrition:   NutritionInfo(req.Nutrition),
+		Ingredients: fp.Map(req.Ingredients, func(i recipeIngredient) IngredientModel { return IngredientModel(i) }),
+		Steps:       fp.Map(req.Steps, step.toStepModel),
+		CookTime:    req.CookTime,
 		Servings:    req.Servings,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 
-	if err := h.recipeUC.CreateRecipe(ctx, model); err != nil {
-		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+	// nutrition sent by the client overrides the values computed from the ingredients
+	if req.Nutrition != nil {
+		model.Nutrition = NutritionInfo(*req.Nutrition)
+		model.NutritionSource = NutritionOverridden
+	}
+
+	if err := h.recipeUC.CreateRecipe(ctx, actor(ctx), model); err != nil {
+		switch {
+		case errors.Is(err, ErrUnknownIngredient):
+			response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		case errors.Is(err, ErrNutritionOverride):
+			response.WriteResponse(ctx, http.StatusForbidden, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
 		return
 	}
 
-	response.WriteResponse(ctx, http.StatusCreated, "recipe succesfully created")
+	response.WriteResponseWithBody(ctx, http.StatusCreated, "recipe succesfully created", recipeStatusResponse{
+		ID:     model.ID,
+		Status: model.Status,
+	})
 }
 
 // @Summary Delete a recipe
@@ -88,6 +222,8 @@ func (h *RecipeHandler) CreateRecipe(ctx *gin.Context) {
 // @Param id path string true "Recipe ID"
 // @Success 200 {object} response.Response
 // @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /recipes/{id} [delete]
 func (h *RecipeHandler) DeleteRecipe(ctx *gin.Context) {
@@ -100,8 +236,15 @@ func (h *RecipeHandler) DeleteRecipe(ctx *gin.Context) {
 		return
 	}
 
-	if err := h.recipeUC.DeleteRecipe(ctx, req.ID); err != nil {
-		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+	if err := h.recipeUC.DeleteRecipe(ctx, actor(ctx), req.ID); err != nil {
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrForbidden):
+			response.WriteResponse(ctx, http.StatusForbidden, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
 		return
 	}
 
@@ -115,25 +258,33 @@ func (h *RecipeHandler) DeleteRecipe(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Recipe ID"
+// @Param servings query int false "Scale the recipe to this number of servings"
+// @Param units query string false "Unit system, metric or imperial, defaults to the user preference"
 // @Success 200 {object} response.Response
 // @Failure 400 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /recipes/{id} [get]
 func (h *RecipeHandler) GetRecipeByID(ctx *gin.Context) {
-	var req struct {
+	var uri struct {
 		ID string `uri:"id" binding:"required"`
 	}
 
-	if err := ctx.ShouldBindUri(&req); err != nil {
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req viewRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
 		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	recipe, err := h.recipeUC.GetRecipeByID(ctx, req.ID)
+	recipe, err := h.recipeUC.GetRecipeByID(ctx, actor(ctx), uri.ID)
 	if err != nil {
-		switch err {
-		case database.ErrNotFound:
+		switch {
+		case errors.Is(err, database.ErrNotFound):
 			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
 		default:
 			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
@@ -143,22 +294,62 @@ func (h *RecipeHandler) GetRecipeByID(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, response.Response{
 		Code: http.StatusOK,
-		Body: recipe,
+		Body: recipe.View(h.viewOptions(ctx, req, recipe)),
 	})
 }
 
+type updateNutritionRequest struct {
+	Calories      *float64 `json:"calories" binding:"omitempty,gte=0"`
+	Protein       *float64 `json:"protein" binding:"omitempty,gte=0"`
+	Fat           *float64 `json:"fat" binding:"omitempty,gte=0"`
+	Carbohydrates *float64 `json:"carbohydrates" binding:"omitempty,gte=0"`
+	Fiber         *float64 `json:"fiber" binding:"omitempty,gte=0"`
+	Sugar         *float64 `json:"sugar" binding:"omitempty,gte=0"`
+	Sodium        *float64 `json:"sodium" binding:"omitempty,gte=0"`
+}
+
 type updateRecipeRequest struct {
-	ID          string   `uri:"id" binding:"required"`
-	Name        string   `json:"name" binding:"omitempty"`
-	Description string   `json:"description" binding:"omitempty"`
-	Category    string   `json:"category" binding:"omitempty"`
-	Tags        []string `json:"tags" binding:"omitempty"`
-	CookTime    int      `json:"cook_time" binding:"omitempty"`
-	Servings    int      `json:"servings" binding:"omitempty"`
+	ID          string                  `uri:"id" binding:"required"`
+	Name        *string                 `json:"name" binding:"omitempty,min=1"`
+	Description *string                 `json:"description" binding:"omitempty,min=1"`
+	Category    *string                 `json:"category" binding:"omitempty,min=1"`
+	Tags        *[]string               `json:"tags" binding:"omitempty"`
+	Ingredients *[]recipeIngredient     `json:"ingredients" binding:"omitempty,dive"`
+	Nutrition   *updateNutritionRequest `json:"nutrition" binding:"omitempty"`
+	CookTime    *int                    `json:"cook_time" binding:"omitempty,gte=0"`
+	Servings    *int                    `json:"servings" binding:"omitempty,gte=1"`
+
+	RecomputeNutrition bool `json:"recompute_nutrition"`
+}
+
+func (r *updateRecipeRequest) toDTO() UpdateRecipeDTO {
+	dto := UpdateRecipeDTO{
+		Name:        r.Name,
+		Description: r.Description,
+		Category:    r.Category,
+		Tags:        r.Tags,
+		CookTime:    r.CookTime,
+		Servings:    r.Servings,
+
+		RecomputeNutrition: r.RecomputeNutrition,
+	}
+
+	if r.Ingredients != nil {
+		ingredients := fp.Map(*r.Ingredients, func(i recipeIngredient) IngredientModel { return IngredientModel(i) })
+		dto.Ingredients = &ingredients
+	}
+
+	if r.Nutrition != nil {
+		nutrition := UpdateNutritionDTO(*r.Nutrition)
+		dto.Nutrition = &nutrition
+	}
+
+	return dto
 }
 
 // @Summary Update a recipe
-// @Description Update a recipe with the given ID
+// @Description Update a recipe with the given ID. Only admins may set the nutrition or recompute it,
+// @Description a published recipe changed by its author goes back to pending review.
 // @Security BasicAuth
 // @Tags Recipe
 // @Accept json
@@ -167,6 +358,7 @@ type updateRecipeRequest struct {
 // @Param recipe body updateRecipeRequest true "Recipe details"
 // @Success 200 {object} response.Response
 // @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /recipes/{id} [patch]
@@ -183,12 +375,15 @@ func (h *RecipeHandler) UpdateRecipe(ctx *gin.Context) {
 		return
 	}
 
-	userID := ctx.MustGet("userID").(string)
-	err := h.recipeUC.UpdateRecipe(ctx, userID, UpdateRecipeDTO{})
+	_, err := h.recipeUC.UpdateRecipe(ctx, actor(ctx), req.ID, req.toDTO())
 	if err != nil {
-		switch err {
-		case database.ErrNotFound:
+		switch {
+		case errors.Is(err, database.ErrNotFound):
 			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrForbidden), errors.Is(err, ErrNutritionOverride):
+			response.WriteResponse(ctx, http.StatusForbidden, err.Error())
+		case errors.Is(err, ErrUnknownIngredient):
+			response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
 		default:
 			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
 		}
@@ -197,56 +392,1223 @@ func (h *RecipeHandler) UpdateRecipe(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, &response.Response{
 		Code:    http.StatusOK,
-		Message: "user succesfully updated",
+		Message: "recipe succesfully updated",
+	})
+}
+
+type recipeStatusResponse struct {
+	ID     string `json:"id"`
+	Status Status `json:"status" example:"draft"`
+}
+
+type changeStatusRequest struct {
+	ID     string `uri:"id" binding:"required"`
+	Status Status `json:"status" binding:"required,oneof=draft pending_review published archived" example:"pending_review"`
+}
+
+// @Summary Change the status of a recipe
+// @Description Move a recipe through the publishing workflow: draft, pending_review, published
+// @Description and archived. Authors submit their recipes for review, only admins publish them.
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param status body changeStatusRequest true "New status"
+// @Success 200 {object} response.Response{body=recipeStatusResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/status [post]
+func (h *RecipeHandler) ChangeStatus(ctx *gin.Context) {
+	var req changeStatusRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	recipe, err := h.recipeUC.ChangeStatus(ctx, actor(ctx), req.ID, req.Status)
+	if err != nil {
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrForbidden):
+			response.WriteResponse(ctx, http.StatusForbidden, err.Error())
+		case errors.Is(err, ErrInvalidTransition):
+			response.WriteResponse(ctx, http.StatusConflict, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "recipe status succesfully changed", recipeStatusResponse{
+		ID:     recipe.ID,
+		Status: recipe.Status,
 	})
 }
 
+type listMyRecipesRequest struct {
+	Status []Status `form:"status" binding:"omitempty,dive,oneof=draft pending_review published archived"`
+}
+
+// @Summary List my recipes
+// @Description List the recipes written by the user in any status, most recently updated first
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept json
+// @Produce json
+// @Param status query []string false "Only list recipes in these statuses" collectionFormat(multi)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/mine [get]
+func (h *RecipeHandler) ListMyRecipes(ctx *gin.Context) {
+	var req listMyRecipesRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	recipes, err := h.recipeUC.ListAuthorRecipes(ctx, ctx.GetString("userID"), req.Status)
+	if err != nil {
+		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", recipes)
+}
+
 type searchParametersRequest struct {
-	Query string   `json:"query" binding:"omitempty"`
-	Tags  []string `json:"tags" binding:"omitempty"`
-	Page  int64    `json:"page" binding:"omitempty"`
-	Limit int64    `json:"limit" binding:"omitempty"`
+	Query      string   `form:"query" binding:"omitempty"`
+	Categories []string `form:"category" binding:"omitempty"`
+	Tags       []string `form:"tags" binding:"omitempty"`
+	TagMatch   string   `form:"tags_match" binding:"omitempty,oneof=any all" example:"any"`
+	Sort       string   `form:"sort" binding:"omitempty,oneof=newest most_viewed most_liked highest_rated fewest_calories relevance" example:"newest"`
+	// Cursor continues a previous search, Page is kept for clients paging by number.
+	Cursor string `form:"cursor" binding:"omitempty"`
+	Page   int64  `form:"page" binding:"omitempty,gte=0"`
+	Limit  int64  `form:"limit" binding:"omitempty,gte=0"`
+	Units  string `form:"units" binding:"omitempty,oneof=metric imperial"`
+
+	ExcludeAllergens []string `form:"exclude_allergens" binding:"omitempty"`
+	Diets            []string `form:"diets" binding:"omitempty"`
+	// IgnoreProfile skips the dietary profile of the user, which is applied by default.
+	IgnoreProfile bool `form:"ignore_profile" binding:"omitempty"`
+
+	// Nutrition bounds are per serving.
+	CaloriesMin      *float64 `form:"calories_min" binding:"omitempty,gte=0"`
+	CaloriesMax      *float64 `form:"calories_max" binding:"omitempty,gte=0"`
+	ProteinMin       *float64 `form:"protein_min" binding:"omitempty,gte=0"`
+	ProteinMax       *float64 `form:"protein_max" binding:"omitempty,gte=0"`
+	FatMin           *float64 `form:"fat_min" binding:"omitempty,gte=0"`
+	FatMax           *float64 `form:"fat_max" binding:"omitempty,gte=0"`
+	CarbohydratesMin *float64 `form:"carbohydrates_min" binding:"omitempty,gte=0"`
+	CarbohydratesMax *float64 `form:"carbohydrates_max" binding:"omitempty,gte=0"`
+	FiberMin         *float64 `form:"fiber_min" binding:"omitempty,gte=0"`
+	FiberMax         *float64 `form:"fiber_max" binding:"omitempty,gte=0"`
+	SugarMin         *float64 `form:"sugar_min" binding:"omitempty,gte=0"`
+	SugarMax         *float64 `form:"sugar_max" binding:"omitempty,gte=0"`
+	SodiumMin        *float64 `form:"sodium_min" binding:"omitempty,gte=0"`
+	SodiumMax        *float64 `form:"sodium_max" binding:"omitempty,gte=0"`
+
+	ServingsMin *float64 `form:"servings_min" binding:"omitempty,gte=0"`
+	ServingsMax *float64 `form:"servings_max" binding:"omitempty,gte=0"`
+	CookTimeMin *float64 `form:"cook_time_min" binding:"omitempty,gte=0"`
+	CookTimeMax *float64 `form:"cook_time_max" binding:"omitempty,gte=0"`
+}
+
+func (r *searchParametersRequest) toParams() SearchParams {
+	ranges := map[string]Range{
+		NutrientCalories:      {Min: r.CaloriesMin, Max: r.CaloriesMax},
+		NutrientProtein:       {Min: r.ProteinMin, Max: r.ProteinMax},
+		NutrientFat:           {Min: r.FatMin, Max: r.FatMax},
+		NutrientCarbohydrates: {Min: r.CarbohydratesMin, Max: r.CarbohydratesMax},
+		NutrientFiber:         {Min: r.FiberMin, Max: r.FiberMax},
+		NutrientSugar:         {Min: r.SugarMin, Max: r.SugarMax},
+		NutrientSodium:        {Min: r.SodiumMin, Max: r.SodiumMax},
+	}
+
+	nutrition := map[string]Range{}
+	for nutrient, bounds := range ranges {
+		if !bounds.IsZero() {
+			nutrition[nutrient] = bounds
+		}
+	}
+
+	return SearchParams{
+		Query:            r.Query,
+		Categories:       r.Categories,
+		Tags:             r.Tags,
+		TagMatch:         TagMatch(r.TagMatch),
+		ExcludeAllergens: r.ExcludeAllergens,
+		DietLabels:       r.Diets,
+		Nutrition:        nutrition,
+		Servings:         Range{Min: r.ServingsMin, Max: r.ServingsMax},
+		CookTime:         Range{Min: r.CookTimeMin, Max: r.CookTimeMax},
+		Sort:             SortOrder(r.Sort),
+		Cursor:           r.Cursor,
+		Page:             r.Page,
+		Limit:            r.Limit,
+	}
+}
+
+type facetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+type facetBucket struct {
+	Min   float64  `json:"min"`
+	Max   *float64 `json:"max,omitempty"`
+	Count int      `json:"count"`
+}
+
+type facetsResponse struct {
+	Categories []facetCount  `json:"categories"`
+	Tags       []facetCount  `json:"tags"`
+	DietLabels []facetCount  `json:"diet_labels"`
+	Servings   []facetBucket `json:"servings"`
+	CookTime   []facetBucket `json:"cook_time"`
+	Calories   []facetBucket `json:"calories"`
+}
+
+func toFacetCounts(counts []FacetCount) []facetCount {
+	return fp.Map(counts, func(c FacetCount) facetCount {
+		return facetCount{Value: c.Value, Count: c.Count}
+	})
+}
+
+func toFacetBuckets(buckets []FacetBucket) []facetBucket {
+	return fp.Map(buckets, func(b FacetBucket) facetBucket {
+		return facetBucket{Min: b.Min, Max: b.Max, Count: b.Count}
+	})
+}
+
+func toFacetsResponse(f Facets) facetsResponse {
+	return facetsResponse{
+		Categories: toFacetCounts(f.Categories),
+		Tags:       toFacetCounts(f.Tags),
+		DietLabels: toFacetCounts(f.DietLabels),
+		Servings:   toFacetBuckets(f.Servings),
+		CookTime:   toFacetBuckets(f.CookTime),
+		Calories:   toFacetBuckets(f.Calories),
+	}
 }
 
 // @Summary Search recipes
-// @Description Search recipes based on query, tags, page, and limit
+// @Description Search recipes by query and facets: category, tags (any or all), diets, allergens,
+// @Description nutrition per serving, servings and cook time. The response carries the count of
+// @Description matching recipes per facet value. Recipes violating the dietary profile of the user
+// @Description are left out unless ignore_profile is set. Text queries are ranked by the search index
+// @Description over name, ingredients, description and steps, highlights hold the matched fragments per recipe ID
+// @Description as HTML-escaped text with the matches wrapped in <mark> tags. A text query considers its 1000
+// @Description best published matches only, truncated is set when it matched more.
+// @Description Pass next_cursor back as cursor to get the following page, page and limit still work for older clients.
+// @Description The limit defaults to 20 and is capped at 100.
 // @Security BasicAuth
 // @Tags Recipe
 // @Accept json
 // @Produce json
-//
-//	@Param searchRequest body searchParametersRequest true "Search parameters"
-//
+// @Param request query searchParametersRequest false "Search parameters"
 // @Success 200 {object} response.Response
 // @Failure 400 {object} response.Response
 // @Failure 500 {object} response.Response
-// @Router /recipes/search [get]
+// @Router /recipes [get]
 func (h *RecipeHandler) SearchRecipe(ctx *gin.Context) {
 	var req searchParametersRequest
 
-	if err := ctx.ShouldBindJSON(&req); err != nil {
+	if err := ctx.ShouldBindQuery(&req); err != nil {
 		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	recipes, totalDocuments, err := h.recipeUC.SearchRecipe(ctx, req.Query, req.Tags, req.Page, req.Limit)
+	params := req.toParams()
+
+	if !req.IgnoreProfile {
+		u, err := h.userUC.GetUserByID(ctx, ctx.GetString("userID"))
+		if err != nil {
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		params.Restrict(u.Dietary)
+	}
+
+	result, err := h.recipeUC.SearchRecipe(ctx, params)
 	if err != nil {
-		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		switch {
+		case errors.Is(err, ErrInvalidCursor):
+			response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
 		return
 	}
 
+	// the page number only makes sense to clients paging the old way
+	var page int64
+	if req.Cursor == "" {
+		page = max(1, req.Page)
+	}
+
+	opts := h.viewOptions(ctx, viewRequest{Units: req.Units}, result.Recipes...)
+	recipes := fp.Map(result.Recipes, func(r *RecipeModel) *RecipeModel { return r.View(opts) })
+
 	ctx.JSON(http.StatusOK, response.Response{
 		Code: http.StatusOK,
 		Body: struct {
-			Recipes []*RecipeModel `json:"recipes"`
-			Total   int            `json:"total"`
-			Limit   int64          `json:"limit"`
-			Page    int64          `json:"page"`
+			Recipes    []*RecipeModel                 `json:"recipes"`
+			Highlights map[string]map[string][]string `json:"highlights"`
+			Facets     facetsResponse                 `json:"facets"`
+			NextCursor string                         `json:"next_cursor,omitempty"`
+			Total      int                            `json:"total"`
+			Truncated  bool                           `json:"truncated,omitempty"`
+			Limit      int64                          `json:"limit"`
+			Page       int64                          `json:"page,omitempty"`
 		}{
-			Recipes: recipes,
-			Total:   totalDocuments,
-			Limit:   req.Limit,
-			Page:    max(1, req.Page),
+			Recipes:    recipes,
+			Highlights: result.Highlights,
+			Facets:     toFacetsResponse(result.Facets),
+			NextCursor: result.NextCursor,
+			Total:      result.Total,
+			Truncated:  result.Truncated,
+			Limit:      params.PageSize(),
+			Page:       page,
 		},
 	})
 }
+
+type suggestRequest struct {
+	Query string `form:"q" binding:"required" example:"chiken"`
+	Limit int    `form:"limit" binding:"omitempty,gte=1,lte=50" example:"10"`
+}
+
+type suggestionResponse struct {
+	Text      string   `json:"text"`
+	Kind      string   `json:"kind"`
+	RecipeIDs []string `json:"recipe_ids"`
+	Score     float64  `json:"score"`
+}
+
+// @Summary Suggest search terms
+// @Description Autocomplete recipe names, tags and ingredients while the user types.
+// @Description Small typos are tolerated, suggestions are ranked by recipe popularity.
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept json
+// @Produce json
+// @Param q query string true "What the user has typed so far"
+// @Param limit query int false "Maximum number of suggestions, 10 by default"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/suggest [get]
+func (h *RecipeHandler) Suggest(ctx *gin.Context) {
+	var req suggestRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+
+	suggestions, err := h.recipeUC.Suggest(ctx, req.Query, req.Limit)
+	if err != nil {
+		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", fp.Map(suggestions, func(s SuggestionModel) suggestionResponse {
+		return suggestionResponse{
+			Text:      s.Text,
+			Kind:      s.Kind,
+			RecipeIDs: s.RecipeIDs,
+			Score:     s.Score,
+		}
+	}))
+}
+
+// @Summary Get recipe steps
+// @Description Get the ordered preparation steps of a recipe
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param units query string false "Unit system, metric or imperial, defaults to the user preference"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/steps [get]
+func (h *RecipeHandler) GetSteps(ctx *gin.Context) {
+	var uri struct {
+		ID string `uri:"id" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req viewRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	recipe, err := h.recipeUC.GetRecipeByID(ctx, actor(ctx), uri.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	recipe = recipe.View(h.viewOptions(ctx, req, recipe))
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", fp.Map(recipe.Steps, toStepResponse))
+}
+
+type addStepRequest struct {
+	step
+	Position *int `json:"position" binding:"omitempty,gte=0"`
+}
+
+// @Summary Add a recipe step
+// @Description Add a preparation step to a recipe, appended to the end unless position is given
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param step body addStepRequest true "Step details"
+// @Success 201 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/steps [post]
+func (h *RecipeHandler) AddStep(ctx *gin.Context) {
+	var uri struct {
+		ID string `uri:"id" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req addStepRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	model := req.toStepModel()
+	if err := h.recipeUC.AddStep(ctx, actor(ctx), uri.ID, &model, req.Position); err != nil {
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrForbidden):
+			response.WriteResponse(ctx, http.StatusForbidden, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusCreated, "step succesfully created", toStepResponse(model))
+}
+
+type updateStepRequest struct {
+	Text            *string      `json:"text" binding:"omitempty,min=1"`
+	DurationSeconds *int         `json:"duration_seconds" binding:"omitempty,gte=0"`
+	Temperature     *temperature `json:"temperature"`
+	IngredientIDs   *[]string    `json:"ingredient_ids"`
+}
+
+// @Summary Update a recipe step
+// @Description Update the fields of a recipe step that are present in the request
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param stepID path string true "Step ID"
+// @Param step body updateStepRequest true "Step details"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/steps/{stepID} [patch]
+func (h *RecipeHandler) UpdateStep(ctx *gin.Context) {
+	var uri struct {
+		ID     string `uri:"id" binding:"required"`
+		StepID string `uri:"stepID" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req updateStepRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dto := UpdateStepDTO{
+		Text:          req.Text,
+		IngredientIDs: req.IngredientIDs,
+	}
+
+	if req.DurationSeconds != nil {
+		duration := time.Duration(*req.DurationSeconds) * time.Second
+		dto.Duration = &duration
+	}
+
+	if req.Temperature != nil {
+		dto.Temperature = &Temperature{
+			Value: req.Temperature.Value,
+			Unit:  req.Temperature.Unit,
+		}
+	}
+
+	if err := h.recipeUC.UpdateStep(ctx, actor(ctx), uri.ID, uri.StepID, dto); err != nil {
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrForbidden):
+			response.WriteResponse(ctx, http.StatusForbidden, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.WriteResponse(ctx, http.StatusOK, "step succesfully updated")
+}
+
+// @Summary Delete a recipe step
+// @Description Delete a preparation step from a recipe
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param stepID path string true "Step ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/steps/{stepID} [delete]
+func (h *RecipeHandler) DeleteStep(ctx *gin.Context) {
+	var req struct {
+		ID     string `uri:"id" binding:"required"`
+		StepID string `uri:"stepID" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.recipeUC.DeleteStep(ctx, actor(ctx), req.ID, req.StepID); err != nil {
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrForbidden):
+			response.WriteResponse(ctx, http.StatusForbidden, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.WriteResponse(ctx, http.StatusOK, "step succesfully deleted")
+}
+
+type cookingStepResponse struct {
+	Number          int          `json:"number"`
+	Step            stepResponse `json:"step"`
+	StartsAtSeconds int          `json:"starts_at_seconds"`
+	EndsAtSeconds   int          `json:"ends_at_seconds"`
+	TimerSeconds    int          `json:"timer_seconds"`
+}
+
+// @Summary Get recipe cooking mode
+// @Description Get the recipe steps with their timers laid out on a timeline
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param servings query int false "Scale the recipe to this number of servings"
+// @Param units query string false "Unit system, metric or imperial, defaults to the user preference"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/cooking-mode [get]
+func (h *RecipeHandler) GetCookingMode(ctx *gin.Context) {
+	var uri struct {
+		ID string `uri:"id" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req viewRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	mode, err := h.recipeUC.GetCookingMode(ctx, actor(ctx), uri.ID, h.viewOptions(ctx, req))
+	if err != nil {
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", struct {
+		RecipeID         string                `json:"recipe_id"`
+		Steps            []cookingStepResponse `json:"steps"`
+		TotalTimeSeconds int                   `json:"total_time_seconds"`
+	}{
+		RecipeID: mode.RecipeID,
+		Steps: fp.Map(mode.Steps, func(s CookingStepModel) cookingStepResponse {
+			return cookingStepResponse{
+				Number:          s.Number,
+				Step:            toStepResponse(s.Step),
+				StartsAtSeconds: int(s.StartsAt.Seconds()),
+				EndsAtSeconds:   int(s.EndsAt.Seconds()),
+				TimerSeconds:    int(s.Step.Duration.Seconds()),
+			}
+		}),
+		TotalTimeSeconds: int(mode.TotalTime.Seconds()),
+	})
+}
+
+// @Summary List recipe revisions
+// @Description List every stored revision of a recipe, newest first
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/revisions [get]
+func (h *RecipeHandler) ListRevisions(ctx *gin.Context) {
+	var req struct {
+		ID string `uri:"id" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	revisions, err := h.recipeUC.ListRevisions(ctx, req.ID)
+	if err != nil {
+		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", revisions)
+}
+
+type revisionRequest struct {
+	ID     string `uri:"id" binding:"required"`
+	Number int    `uri:"number" binding:"required,gte=1"`
+}
+
+// @Summary Get a recipe revision
+// @Description Get a single revision of a recipe with its snapshot and diff
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param number path int true "Revision number"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/revisions/{number} [get]
+func (h *RecipeHandler) GetRevision(ctx *gin.Context) {
+	var req revisionRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	revision, err := h.recipeUC.GetRevision(ctx, req.ID, req.Number)
+	if err != nil {
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", revision)
+}
+
+type diffRevisionsRequest struct {
+	From int `form:"from" binding:"required,gte=1"`
+	To   int `form:"to" binding:"required,gte=1"`
+}
+
+// @Summary Diff two recipe revisions
+// @Description Get the field-level difference between any two revisions of a recipe
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param from query int true "Revision to diff from"
+// @Param to query int true "Revision to diff to"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/revisions/diff [get]
+func (h *RecipeHandler) DiffRevisions(ctx *gin.Context) {
+	var uri struct {
+		ID string `uri:"id" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req diffRevisionsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	changes, err := h.recipeUC.DiffRevisions(ctx, uri.ID, req.From, req.To)
+	if err != nil {
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", changes)
+}
+
+// @Summary Roll back a recipe
+// @Description Restore a recipe to the state of an earlier revision, recorded as a new revision
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param number path int true "Revision number"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/revisions/{number}/rollback [post]
+func (h *RecipeHandler) RollbackRecipe(ctx *gin.Context) {
+	var req revisionRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	recipe, err := h.recipeUC.RollbackRecipe(ctx, actor(ctx), req.ID, req.Number)
+	if err != nil {
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrForbidden):
+			response.WriteResponse(ctx, http.StatusForbidden, err.Error())
+		case errors.Is(err, ErrUnknownIngredient):
+			response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "recipe succesfully rolled back", recipe)
+}
+
+type ratingResponse struct {
+	RecipeID  string    `json:"recipe_id"`
+	UserID    string    `json:"user_id"`
+	Stars     int       `json:"stars" example:"4"`
+	Review    string    `json:"review,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func toRatingResponse(r *RatingModel) ratingResponse {
+	return ratingResponse{
+		RecipeID:  r.RecipeID,
+		UserID:    r.UserID,
+		Stars:     r.Stars,
+		Review:    r.Review,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+type rateRecipeRequest struct {
+	ID     string `uri:"id" binding:"required"`
+	Stars  int    `json:"stars" binding:"required,gte=1,lte=5" example:"4"`
+	Review string `json:"review" binding:"omitempty,max=5000"`
+}
+
+// @Summary Rate a recipe
+// @Description Rate a recipe with 1 to 5 stars and an optional review. Rating the recipe
+// @Description again replaces the previous rating.
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param rating body rateRecipeRequest true "Rating"
+// @Success 200 {object} response.Response{body=ratingResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/rating [put]
+func (h *RecipeHandler) RateRecipe(ctx *gin.Context) {
+	var req rateRecipeRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rating, err := h.recipeUC.RateRecipe(ctx, actor(ctx), req.ID, req.Stars, req.Review)
+	if err != nil {
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrOwnRecipe):
+			response.WriteResponse(ctx, http.StatusForbidden, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "recipe succesfully rated", toRatingResponse(rating))
+}
+
+// @Summary Get my rating of a recipe
+// @Description Get the rating the user gave the recipe
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Success 200 {object} response.Response{body=ratingResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/rating [get]
+func (h *RecipeHandler) GetRating(ctx *gin.Context) {
+	var req struct {
+		ID string `uri:"id" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rating, err := h.recipeUC.GetRating(ctx, actor(ctx), req.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", toRatingResponse(rating))
+}
+
+// @Summary Delete my rating of a recipe
+// @Description Delete the rating the user gave the recipe
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/rating [delete]
+func (h *RecipeHandler) DeleteRating(ctx *gin.Context) {
+	var req struct {
+		ID string `uri:"id" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.recipeUC.DeleteRating(ctx, actor(ctx), req.ID); err != nil {
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.WriteResponse(ctx, http.StatusOK, "rating succesfully deleted")
+}
+
+type listRatingsRequest struct {
+	ID    string `uri:"id" binding:"required"`
+	Page  int64  `form:"page" binding:"omitempty,gte=0"`
+	Limit int64  `form:"limit" binding:"omitempty,gte=1,lte=100"`
+}
+
+type ratingSummaryResponse struct {
+	Average float64 `json:"average" example:"4.2"`
+	Count   int     `json:"count"`
+	// Histogram counts the ratings per number of stars, from 1 to 5.
+	Histogram []int `json:"histogram"`
+}
+
+// @Summary List the ratings of a recipe
+// @Description List the ratings and reviews of a recipe, most recent first, with the
+// @Description average, the number of ratings and the number of ratings per star.
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param page query int false "Page number, starting at 0"
+// @Param limit query int false "Page size, 20 by default"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/ratings [get]
+func (h *RecipeHandler) ListRatings(ctx *gin.Context) {
+	var req listRatingsRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if req.Limit == 0 {
+		req.Limit = DefaultSearchLimit
+	}
+
+	recipe, err := h.recipeUC.GetRecipeByID(ctx, actor(ctx), req.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	ratings, total, err := h.recipeUC.ListRatings(ctx, actor(ctx), req.ID, req.Page, req.Limit)
+	if err != nil {
+		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", struct {
+		Summary ratingSummaryResponse `json:"summary"`
+		Ratings []ratingResponse      `json:"ratings"`
+		Total   int64                 `json:"total"`
+		Page    int64                 `json:"page"`
+		Limit   int64                 `json:"limit"`
+	}{
+		Summary: ratingSummaryResponse{
+			Average:   recipe.Rating.Average,
+			Count:     recipe.Rating.Count,
+			Histogram: recipe.Rating.Histogram[:],
+		},
+		Ratings: fp.Map(ratings, toRatingResponse),
+		Total:   total,
+		Page:    req.Page,
+		Limit:   req.Limit,
+	})
+}
+
+type imageVariantResponse struct {
+	Name   string `json:"name" example:"small"`
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+type imageResponse struct {
+	ID       string                 `json:"id"`
+	URL      string                 `json:"url"`
+	Width    int                    `json:"width"`
+	Height   int                    `json:"height"`
+	Variants []imageVariantResponse `json:"variants"`
+}
+
+func toImageResponse(i media.ImageModel) imageResponse {
+	return imageResponse{
+		ID:     i.ID,
+		URL:    i.URL,
+		Width:  i.Width,
+		Height: i.Height,
+		Variants: fp.Map(i.Variants, func(v media.VariantModel) imageVariantResponse {
+			return imageVariantResponse{
+				Name:   v.Name,
+				URL:    v.URL,
+				Width:  v.Width,
+				Height: v.Height,
+			}
+		}),
+	}
+}
+
+// multipartOverhead leaves room for the headers and boundaries around the uploaded file.
+const multipartOverhead = 1 << 20
+
+// readImage reads the image field of a multipart form, refusing files above the upload limit.
+func (h *RecipeHandler) readImage(ctx *gin.Context) ([]byte, error) {
+	limit := h.config.Media.MaxUploadSize
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, limit+multipartOverhead)
+
+	header, err := ctx.FormFile("image")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, media.ErrTooLarge
+		}
+		return nil, err
+	}
+
+	if header.Size > limit {
+		return nil, media.ErrTooLarge
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return io.ReadAll(io.LimitReader(file, limit+1))
+}
+
+type imageRequest struct {
+	ID      string `uri:"id" binding:"required"`
+	StepID  string `uri:"stepID"`
+	ImageID string `uri:"imageID"`
+}
+
+// uploadImage serves the upload of a recipe picture and of a step picture alike.
+func (h *RecipeHandler) uploadImage(ctx *gin.Context) {
+	var req imageRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, err := h.readImage(ctx)
+	if err != nil {
+		if errors.Is(err, media.ErrTooLarge) {
+			response.WriteResponse(ctx, http.StatusRequestEntityTooLarge, err.Error())
+			return
+		}
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	image, err := h.recipeUC.AddImage(ctx, actor(ctx), req.ID, req.StepID, data)
+	if err != nil {
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrForbidden):
+			response.WriteResponse(ctx, http.StatusForbidden, err.Error())
+		case errors.Is(err, ErrTooManyImages):
+			response.WriteResponse(ctx, http.StatusConflict, err.Error())
+		case errors.Is(err, media.ErrTooLarge):
+			response.WriteResponse(ctx, http.StatusRequestEntityTooLarge, err.Error())
+		case errors.Is(err, media.ErrUnsupportedType):
+			response.WriteResponse(ctx, http.StatusUnsupportedMediaType, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusCreated, "image succesfully uploaded", toImageResponse(*image))
+}
+
+// deleteImage serves the removal of a recipe picture and of a step picture alike.
+func (h *RecipeHandler) deleteImage(ctx *gin.Context) {
+	var req imageRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.recipeUC.DeleteImage(ctx, actor(ctx), req.ID, req.StepID, req.ImageID); err != nil {
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrForbidden):
+			response.WriteResponse(ctx, http.StatusForbidden, err.Error())
+		default:
+			response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.WriteResponse(ctx, http.StatusOK, "image succesfully deleted")
+}
+
+// @Summary Upload a recipe image
+// @Description Upload a JPEG, PNG or GIF picture of a recipe as the image field of a multipart
+// @Description form. The picture is stored without its metadata, along with small, medium and
+// @Description large thumbnails.
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param image formData file true "Image file"
+// @Success 201 {object} response.Response{body=imageResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 413 {object} response.Response
+// @Failure 415 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/images [post]
+func (h *RecipeHandler) UploadRecipeImage(ctx *gin.Context) {
+	h.uploadImage(ctx)
+}
+
+// @Summary Delete a recipe image
+// @Description Delete a picture of a recipe along with its thumbnails
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param imageID path string true "Image ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/images/{imageID} [delete]
+func (h *RecipeHandler) DeleteRecipeImage(ctx *gin.Context) {
+	h.deleteImage(ctx)
+}
+
+// @Summary Upload a step image
+// @Description Upload a JPEG, PNG or GIF picture of a recipe step as the image field of a
+// @Description multipart form. The picture is stored without its metadata, along with small,
+// @Description medium and large thumbnails.
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param stepID path string true "Step ID"
+// @Param image formData file true "Image file"
+// @Success 201 {object} response.Response{body=imageResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 413 {object} response.Response
+// @Failure 415 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/steps/{stepID}/images [post]
+func (h *RecipeHandler) UploadStepImage(ctx *gin.Context) {
+	h.uploadImage(ctx)
+}
+
+// @Summary Delete a step image
+// @Description Delete a picture of a recipe step along with its thumbnails
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param stepID path string true "Step ID"
+// @Param imageID path string true "Image ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/steps/{stepID}/images/{imageID} [delete]
+func (h *RecipeHandler) DeleteStepImage(ctx *gin.Context) {
+	h.deleteImage(ctx)
+}
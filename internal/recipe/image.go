@@ -0,0 +1,45 @@
+package recipe
+
+import (
+	"flove/job/internal/media"
+	"fmt"
+)
+
+// MaxImages bounds the pictures of a recipe and of each of its steps.
+const MaxImages = 20
+
+// ImagePrefix is the storage prefix of the pictures of a recipe, or of one of its steps
+// when stepID is set.
+func ImagePrefix(recipeID, stepID string) string {
+	if stepID == "" {
+		return fmt.Sprintf("recipes/%s", recipeID)
+	}
+
+	return fmt.Sprintf("recipes/%s/steps/%s", recipeID, stepID)
+}
+
+// StepImages returns the pictures of the recipe, or of one of its steps when stepID is
+// set. ok is false when the step does not exist.
+func (r *RecipeModel) StepImages(stepID string) (images []media.ImageModel, ok bool) {
+	if stepID == "" {
+		return r.Images, true
+	}
+
+	for _, step := range r.Steps {
+		if step.ID == stepID {
+			return step.Images, true
+		}
+	}
+
+	return nil, false
+}
+
+// AllImages returns the pictures of the recipe and of all its steps.
+func (r *RecipeModel) AllImages() []media.ImageModel {
+	images := append([]media.ImageModel{}, r.Images...)
+	for _, step := range r.Steps {
+		images = append(images, step.Images...)
+	}
+
+	return images
+}
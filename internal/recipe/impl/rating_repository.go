@@ -0,0 +1,146 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/recipe"
+	"flove/job/pkg/fp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	ratingsCollection = "recipe_ratings"
+)
+
+type ratingEntity struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	RecipeID  string             `bson:"recipe_id"`
+	UserID    string             `bson:"user_id"`
+	Stars     int                `bson:"stars"`
+	Review    string             `bson:"review"`
+	CreatedAt time.Time          `bson:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at"`
+}
+
+func (e *ratingEntity) toRatingModel() *recipe.RatingModel {
+	return &recipe.RatingModel{
+		ID:        e.ID.Hex(),
+		RecipeID:  e.RecipeID,
+		UserID:    e.UserID,
+		Stars:     e.Stars,
+		Review:    e.Review,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+type ratingRepository struct {
+	config *config.Config
+	db     *mongo.Database
+}
+
+func NewRatingRepository(config *config.Config, db *mongo.Database) recipe.RatingRepository {
+	ctx := context.Background()
+
+	db.Collection(ratingsCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "recipe_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "recipe_id", Value: 1}, {Key: "updated_at", Value: -1}},
+		},
+	})
+
+	return &ratingRepository{
+		config: config,
+		db:     db,
+	}
+}
+
+func (repo *ratingRepository) SaveRating(ctx context.Context, r *recipe.RatingModel) error {
+	filter := bson.M{"recipe_id": r.RecipeID, "user_id": r.UserID}
+	update := bson.M{
+		"$set": bson.M{
+			"stars":      r.Stars,
+			"review":     r.Review,
+			"updated_at": r.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{"created_at": r.CreatedAt},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	saved := &ratingEntity{}
+	if err := repo.db.Collection(ratingsCollection).FindOneAndUpdate(ctx, filter, update, opts).Decode(saved); err != nil {
+		return err
+	}
+	*r = *saved.toRatingModel()
+
+	return nil
+}
+
+func (repo *ratingRepository) GetRating(ctx context.Context, recipeID, userID string) (*recipe.RatingModel, error) {
+	entity := &ratingEntity{}
+
+	filter := bson.M{"recipe_id": recipeID, "user_id": userID}
+	if err := repo.db.Collection(ratingsCollection).FindOne(ctx, filter).Decode(entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, database.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return entity.toRatingModel(), nil
+}
+
+func (repo *ratingRepository) ListRatings(ctx context.Context, recipeID string, page, limit int64) ([]*recipe.RatingModel, int64, error) {
+	filter := bson.M{"recipe_id": recipeID}
+
+	total, err := repo.db.Collection(ratingsCollection).CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "updated_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(page * limit).
+		SetLimit(limit)
+
+	cursor, err := repo.db.Collection(ratingsCollection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var results []*ratingEntity
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, 0, err
+	}
+
+	return fp.Map(results, (*ratingEntity).toRatingModel), total, nil
+}
+
+func (repo *ratingRepository) DeleteRating(ctx context.Context, recipeID, userID string) (*recipe.RatingModel, error) {
+	entity := &ratingEntity{}
+
+	filter := bson.M{"recipe_id": recipeID, "user_id": userID}
+	if err := repo.db.Collection(ratingsCollection).FindOneAndDelete(ctx, filter).Decode(entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, database.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return entity.toRatingModel(), nil
+}
+
+func (repo *ratingRepository) DeleteRecipeRatings(ctx context.Context, recipeID string) error {
+	_, err := repo.db.Collection(ratingsCollection).DeleteMany(ctx, bson.M{"recipe_id": recipeID})
+	return err
+}
@@ -5,7 +5,12 @@ import (
 	"errors"
 	"flove/job/config"
 	"flove/job/internal/base/database"
+	"flove/job/internal/media"
 	"flove/job/internal/recipe"
+	"flove/job/pkg/fp"
+	"math"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,15 +25,157 @@ var (
 )
 
 type recipeEntity struct {
-	ID          primitive.ObjectID  `bson:"_id,omitempty"`
-	Name        string              `bson:"name"`
-	Description string              `bson:"description"`
-	Category    string              `bson:"category"`
-	Tags        []string            `bson:"tags"`
-	Nutrition   nutritionInfoEntity `bson:"nutrition_info"`
-	Servings    int                 `bson:"servings"`
-	CreatedAt   time.Time           `bson:"created_at"`
-	UpdatedAt   time.Time           `bson:"updated_at"`
+	ID              primitive.ObjectID   `bson:"_id,omitempty"`
+	AuthorID        string               `bson:"author_id"`
+	Status          recipe.Status        `bson:"status"`
+	Name            string               `bson:"name"`
+	Description     string               `bson:"description"`
+	Category        string               `bson:"category"`
+	Tags            []string             `bson:"tags"`
+	Ingredients     []ingredientEntity   `bson:"ingredients"`
+	Steps           []stepEntity         `bson:"steps"`
+	Images          []imageEntity        `bson:"images,omitempty"`
+	Nutrition       nutritionInfoEntity  `bson:"nutrition_info"`
+	NutritionSource string               `bson:"nutrition_source"`
+	CookTime        int                  `bson:"cook_time"`
+	Servings        int                  `bson:"servings"`
+	Allergens       []string             `bson:"allergens"`
+	DietLabels      []string             `bson:"diet_labels"`
+	Likes           int                  `bson:"likes"`
+	Views           int                  `bson:"views"`
+	Rating          *ratingSummaryEntity `bson:"rating,omitempty"`
+	CreatedAt       time.Time            `bson:"created_at"`
+	UpdatedAt       time.Time            `bson:"updated_at"`
+}
+
+// ratingSummaryEntity is computed from the ratings of the recipe on every change of them.
+// Histogram is keyed by the stars.
+type ratingSummaryEntity struct {
+	Average   float64        `bson:"average"`
+	Count     int            `bson:"count"`
+	Histogram map[string]int `bson:"histogram"`
+}
+
+func (e *ratingSummaryEntity) toRatingSummary() recipe.RatingSummary {
+	summary := recipe.RatingSummary{}
+	if e == nil {
+		return summary
+	}
+
+	summary.Average = e.Average
+	summary.Count = e.Count
+	for stars := recipe.MinStars; stars <= recipe.MaxStars; stars++ {
+		summary.Histogram[stars-1] = e.Histogram[strconv.Itoa(stars)]
+	}
+
+	return summary
+}
+
+type ingredientEntity struct {
+	IngredientID string  `bson:"ingredient_id"`
+	Name         string  `bson:"name"`
+	Quantity     float64 `bson:"quantity"`
+	Unit         string  `bson:"unit"`
+	Note         string  `bson:"note"`
+}
+
+type stepEntity struct {
+	ID            string             `bson:"id"`
+	Text          string             `bson:"text"`
+	Duration      time.Duration      `bson:"duration"`
+	Temperature   *temperatureEntity `bson:"temperature,omitempty"`
+	IngredientIDs []string           `bson:"ingredient_ids"`
+	Images        []imageEntity      `bson:"images,omitempty"`
+}
+
+type imageEntity struct {
+	ID       string          `bson:"id"`
+	Key      string          `bson:"key"`
+	URL      string          `bson:"url"`
+	Width    int             `bson:"width"`
+	Height   int             `bson:"height"`
+	Variants []variantEntity `bson:"variants"`
+}
+
+type variantEntity struct {
+	Name   string `bson:"name"`
+	Key    string `bson:"key"`
+	URL    string `bson:"url"`
+	Width  int    `bson:"width"`
+	Height int    `bson:"height"`
+}
+
+func (e imageEntity) toImageModel() media.ImageModel {
+	return media.ImageModel{
+		ID:     e.ID,
+		Key:    e.Key,
+		URL:    e.URL,
+		Width:  e.Width,
+		Height: e.Height,
+		Variants: fp.Map(e.Variants, func(v variantEntity) media.VariantModel {
+			return media.VariantModel(v)
+		}),
+	}
+}
+
+func toImageEntity(i media.ImageModel) imageEntity {
+	return imageEntity{
+		ID:     i.ID,
+		Key:    i.Key,
+		URL:    i.URL,
+		Width:  i.Width,
+		Height: i.Height,
+		Variants: fp.Map(i.Variants, func(v media.VariantModel) variantEntity {
+			return variantEntity(v)
+		}),
+	}
+}
+
+type temperatureEntity struct {
+	Value float64 `bson:"value"`
+	Unit  string  `bson:"unit"`
+}
+
+func (e stepEntity) toStepModel() recipe.StepModel {
+	step := recipe.StepModel{
+		ID:            e.ID,
+		Text:          e.Text,
+		Duration:      e.Duration,
+		IngredientIDs: e.IngredientIDs,
+		Images:        fp.Map(e.Images, imageEntity.toImageModel),
+	}
+
+	if e.Temperature != nil {
+		step.Temperature = &recipe.Temperature{
+			Value: e.Temperature.Value,
+			Unit:  e.Temperature.Unit,
+		}
+	}
+
+	return step
+}
+
+func toStepEntity(s recipe.StepModel) stepEntity {
+	if s.ID == "" {
+		s.ID = primitive.NewObjectID().Hex()
+	}
+
+	step := stepEntity{
+		ID:            s.ID,
+		Text:          s.Text,
+		Duration:      s.Duration,
+		IngredientIDs: s.IngredientIDs,
+		Images:        fp.Map(s.Images, toImageEntity),
+	}
+
+	if s.Temperature != nil {
+		step.Temperature = &temperatureEntity{
+			Value: s.Temperature.Value,
+			Unit:  s.Temperature.Unit,
+		}
+	}
+
+	return step
 }
 
 type nutritionInfoEntity struct {
@@ -42,12 +189,21 @@ type nutritionInfoEntity struct {
 }
 
 func (e *recipeEntity) toRecipeModel() *recipe.RecipeModel {
-	return &recipe.RecipeModel{
+	model := &recipe.RecipeModel{
 		ID:          e.ID.Hex(),
+		AuthorID:    e.AuthorID,
+		Status:      e.Status,
 		Name:        e.Name,
 		Description: e.Description,
 		Category:    e.Category,
 		Tags:        e.Tags,
+		Ingredients: fp.Map(e.Ingredients, func(i ingredientEntity) recipe.IngredientModel {
+			return recipe.IngredientModel(i)
+		}),
+		Steps: fp.Map(e.Steps, func(s stepEntity) recipe.StepModel {
+			return s.toStepModel()
+		}),
+		Images: fp.Map(e.Images, imageEntity.toImageModel),
 		Nutrition: recipe.NutritionInfo{
 			Calories:      e.Nutrition.Calories,
 			Protein:       e.Nutrition.Protein,
@@ -57,18 +213,45 @@ func (e *recipeEntity) toRecipeModel() *recipe.RecipeModel {
 			Sugar:         e.Nutrition.Sugar,
 			Sodium:        e.Nutrition.Sodium,
 		},
-		Servings:  e.Servings,
-		CreatedAt: e.CreatedAt,
-		UpdatedAt: e.UpdatedAt,
+		NutritionSource: e.NutritionSource,
+		CookTime:        e.CookTime,
+		Servings:        e.Servings,
+		Allergens:       e.Allergens,
+		DietLabels:      e.DietLabels,
+		Likes:           e.Likes,
+		Views:           e.Views,
+		Rating:          e.Rating.toRatingSummary(),
+		CreatedAt:       e.CreatedAt,
+		UpdatedAt:       e.UpdatedAt,
+	}
+
+	// recipes stored before the publishing workflow were all public
+	if model.Status == "" {
+		model.Status = recipe.StatusPublished
+	}
+
+	// recipes stored before nutrition was computed carry hand-typed values
+	if model.NutritionSource == "" {
+		model.NutritionSource = recipe.NutritionOverridden
 	}
+
+	model.NutritionPerServing = model.Nutrition.PerServing(model.Servings)
+	return model
 }
 
 func toEntity(r *recipe.RecipeModel) *recipeEntity {
 	return &recipeEntity{
+		AuthorID:    r.AuthorID,
+		Status:      r.Status,
 		Name:        r.Name,
 		Description: r.Description,
 		Category:    r.Category,
 		Tags:        r.Tags,
+		Ingredients: fp.Map(r.Ingredients, func(i recipe.IngredientModel) ingredientEntity {
+			return ingredientEntity(i)
+		}),
+		Steps:  fp.Map(r.Steps, toStepEntity),
+		Images: fp.Map(r.Images, toImageEntity),
 		Nutrition: nutritionInfoEntity{
 			Calories:      r.Nutrition.Calories,
 			Protein:       r.Nutrition.Protein,
@@ -78,28 +261,32 @@ func toEntity(r *recipe.RecipeModel) *recipeEntity {
 			Sugar:         r.Nutrition.Sugar,
 			Sodium:        r.Nutrition.Sodium,
 		},
-		Servings:  r.Servings,
-		CreatedAt: r.CreatedAt,
-		UpdatedAt: r.UpdatedAt,
+		NutritionSource: r.NutritionSource,
+		CookTime:        r.CookTime,
+		Servings:        r.Servings,
+		Allergens:       r.Allergens,
+		DietLabels:      r.DietLabels,
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt,
 	}
 }
 
 type repository struct {
-	config *config.Config
-	db     *mongo.Database
+	config      *config.Config
+	db          *mongo.Database
+	searchIndex recipe.SearchIndex
 }
 
-func NewRecipeRepository(config *config.Config, db *mongo.Database) recipe.RecipeRepository {
+func NewRecipeRepository(config *config.Config, db *mongo.Database, searchIndex recipe.SearchIndex) recipe.RecipeRepository {
 	ctx := context.Background()
 
 	db.Collection(recipesCollection).Indexes().DropAll(ctx)
-	db.Collection(recipesCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
-		Keys: bson.M{"name": "text"},
-	})
+	db.Collection(recipesCollection).Indexes().CreateOne(ctx, textIndexModel())
 
 	return &repository{
-		config: config,
-		db:     db,
+		config:      config,
+		db:          db,
+		searchIndex: searchIndex,
 	}
 }
 
@@ -114,7 +301,12 @@ func (repo *repository) CreateRecipe(ctx context.Context, r *recipe.RecipeModel)
 }
 
 func (repo *repository) DeleteRecipe(ctx context.Context, id string) error {
-	filter := bson.M{"_id": id}
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return database.ErrNotFound
+	}
+
+	filter := bson.M{"_id": objectID}
 	result, err := repo.db.Collection(recipesCollection).DeleteOne(ctx, filter)
 
 	if err != nil {
@@ -135,7 +327,7 @@ func (repo *repository) GetRecipeByID(ctx context.Context, id string) (*recipe.R
 	}
 
 	filter := bson.M{"_id": objectID}
-	var entity *recipeEntity
+	entity := &recipeEntity{}
 
 	if err := repo.db.Collection(recipesCollection).FindOne(ctx, filter).Decode(entity); err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
@@ -147,6 +339,107 @@ func (repo *repository) GetRecipeByID(ctx context.Context, id string) (*recipe.R
 	return entity.toRecipeModel(), nil
 }
 
+func (repo *repository) ListRecipes(ctx context.Context) ([]*recipe.RecipeModel, error) {
+	cursor, err := repo.db.Collection(recipesCollection).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*recipeEntity
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return fp.Map(results, (*recipeEntity).toRecipeModel), nil
+}
+
+func (repo *repository) ListRecipesByAuthor(ctx context.Context, authorID string, statuses []recipe.Status) ([]*recipe.RecipeModel, error) {
+	filter := bson.M{"author_id": authorID}
+	if len(statuses) > 0 {
+		filter["status"] = bson.M{"$in": statuses}
+	}
+
+	opts := options.Find().SetSort(bson.M{"updated_at": -1})
+
+	cursor, err := repo.db.Collection(recipesCollection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*recipeEntity
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return fp.Map(results, (*recipeEntity).toRecipeModel), nil
+}
+
+func (repo *repository) SetStatus(ctx context.Context, id string, status recipe.Status) (*recipe.RecipeModel, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, database.ErrNotFound
+	}
+
+	filter := bson.M{"_id": objectID}
+	update := bson.M{"$set": bson.M{"status": status, "updated_at": time.Now()}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	entity := &recipeEntity{}
+	if err := repo.db.Collection(recipesCollection).FindOneAndUpdate(ctx, filter, update, opts).Decode(entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, database.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return entity.toRecipeModel(), nil
+}
+
+func (repo *repository) RefreshRating(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return database.ErrNotFound
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"recipe_id": id}}},
+		{{Key: "$group", Value: bson.M{"_id": "$stars", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := repo.db.Collection(ratingsCollection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+
+	var groups []struct {
+		Stars int `bson:"_id"`
+		Count int `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return err
+	}
+
+	summary := ratingSummaryEntity{Histogram: map[string]int{}}
+	for s := recipe.MinStars; s <= recipe.MaxStars; s++ {
+		summary.Histogram[strconv.Itoa(s)] = 0
+	}
+
+	total := 0
+	for _, g := range groups {
+		summary.Histogram[strconv.Itoa(g.Stars)] = g.Count
+		summary.Count += g.Count
+		total += g.Stars * g.Count
+	}
+	if summary.Count > 0 {
+		summary.Average = float64(total) / float64(summary.Count)
+	}
+
+	// run in the transaction of the rating change, concurrent changes conflict on the
+	// recipe and are retried with the ratings they missed
+	_, err = repo.db.Collection(recipesCollection).UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$set": bson.M{"rating": summary}})
+	return err
+}
+
 func (repo *repository) IncrementLikes(ctx context.Context, id string) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -191,53 +484,544 @@ func (repo *repository) IncrementViews(ctx context.Context, id string) error {
 	return nil
 }
 
-func (repo *repository) SearchRecipe(ctx context.Context, query string, tags []string, page, limit int64) ([]*recipe.RecipeModel, int, error) {
-	skip := (max(1, page) - 1) * limit
-
-	opts := options.
-		Find().
-		SetLimit(limit).
-		SetSkip(skip)
+// perServing divides a recipe total by the number of servings, recipes without
+// servings count as a single serving.
+func perServing(field string) bson.M {
+	return bson.M{"$divide": bson.A{field, bson.M{"$max": bson.A{"$servings", 1}}}}
+}
 
-	query = strings.TrimSpace(query)
+func rangeFilter(r recipe.Range) bson.M {
 	filter := bson.M{}
+	if r.Min != nil {
+		filter["$gte"] = *r.Min
+	}
+	if r.Max != nil {
+		filter["$lte"] = *r.Max
+	}
+
+	return filter
+}
 
-	if query != "" {
-		filter["$text"] = bson.M{"$search": query}
-		opts = opts.
-			SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}}).
-			SetSort(bson.M{"score": bson.M{"$meta": "textScore"}})
+func rangeExpr(value any, r recipe.Range) bson.A {
+	var exprs bson.A
+	if r.Min != nil {
+		exprs = append(exprs, bson.M{"$gte": bson.A{value, *r.Min}})
+	}
+	if r.Max != nil {
+		exprs = append(exprs, bson.M{"$lte": bson.A{value, *r.Max}})
 	}
 
-	if len(tags) > 0 {
-		filter["tags"] = bson.M{"$in": tags}
+	return exprs
+}
+
+func searchFilter(params recipe.SearchParams) bson.M {
+	// recipes stored before the publishing workflow have no status and are published
+	filter := bson.M{"status": bson.M{"$in": bson.A{recipe.StatusPublished, nil}}}
+
+	if len(params.Categories) > 0 {
+		filter["category"] = bson.M{"$in": params.Categories}
 	}
 
-	cursor, err := repo.db.Collection(recipesCollection).Find(ctx, filter, opts)
+	if len(params.Tags) > 0 {
+		if params.TagMatch == recipe.TagMatchAll {
+			filter["tags"] = bson.M{"$all": params.Tags}
+		} else {
+			filter["tags"] = bson.M{"$in": params.Tags}
+		}
+	}
+
+	// recipes stored before allergens were inferred have none, they may hold any of them
+	if len(params.ExcludeAllergens) > 0 {
+		filter["allergens"] = bson.M{"$type": "array", "$nin": params.ExcludeAllergens}
+	}
+
+	if len(params.DietLabels) > 0 {
+		filter["diet_labels"] = bson.M{"$all": params.DietLabels}
+	}
+
+	if len(params.ExcludeIngredients) > 0 {
+		filter["ingredients.ingredient_id"] = bson.M{"$nin": params.ExcludeIngredients}
+	}
+
+	if !params.Servings.IsZero() {
+		filter["servings"] = rangeFilter(params.Servings)
+	}
+
+	if !params.CookTime.IsZero() {
+		filter["cook_time"] = rangeFilter(params.CookTime)
+	}
+
+	var exprs bson.A
+
+	if params.MaxCalories > 0 {
+		exprs = append(exprs, bson.M{"$lte": bson.A{perServing("$nutrition_info.calories"), params.MaxCalories}})
+	}
+
+	for nutrient, r := range params.Nutrition {
+		exprs = append(exprs, rangeExpr(perServing("$nutrition_info."+nutrient), r)...)
+	}
+
+	if len(exprs) > 0 {
+		filter["$expr"] = bson.M{"$and": exprs}
+	}
+
+	return filter
+}
+
+func (repo *repository) ListRecipesUsing(ctx context.Context, ingredientIDs []string, params recipe.SearchParams, limit int) ([]*recipe.RecipeModel, error) {
+	filter := searchFilter(params)
+
+	// excluded ingredients share the field, both conditions have to hold
+	used := bson.M{"$in": ingredientIDs}
+	if excluded, ok := filter["ingredients.ingredient_id"].(bson.M); ok {
+		used["$nin"] = excluded["$nin"]
+	}
+	filter["ingredients.ingredient_id"] = used
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: bson.M{
+			"used": bson.M{"$size": bson.M{"$setIntersection": bson.A{"$ingredients.ingredient_id", ingredientIDs}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "used", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := repo.db.Collection(recipesCollection).Aggregate(ctx, pipeline)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
 	var results []*recipeEntity
 	if err := cursor.All(ctx, &results); err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+
+	return fp.Map(results, (*recipeEntity).toRecipeModel), nil
+}
+
+// bucketFacet groups the matching recipes into the ranges starting at the given boundaries,
+// the last range is open ended.
+func bucketFacet(groupBy any, boundaries []float64) bson.A {
+	return bson.A{bson.M{"$bucket": bson.M{
+		"groupBy":    groupBy,
+		"boundaries": append(slices.Clone(boundaries), math.Inf(1)),
+		"default":    "other",
+		"output":     bson.M{"count": bson.M{"$sum": 1}},
+	}}}
+}
+
+type countEntity struct {
+	Value string `bson:"_id"`
+	Count int    `bson:"count"`
+}
+
+type bucketEntity struct {
+	Min   any `bson:"_id"`
+	Count int `bson:"count"`
+}
+
+// rankedRecipeEntity is a recipe along with the key the search sorted it by.
+type rankedRecipeEntity struct {
+	recipeEntity `bson:",inline"`
+	SortKey      float64 `bson:"sort_key"`
+}
+
+type searchEntity struct {
+	Recipes    []*rankedRecipeEntity `bson:"recipes"`
+	Total      []countEntity         `bson:"total"`
+	Categories []countEntity         `bson:"categories"`
+	Tags       []countEntity         `bson:"tags"`
+	DietLabels []countEntity         `bson:"diet_labels"`
+	Servings   []bucketEntity        `bson:"servings"`
+	CookTime   []bucketEntity        `bson:"cook_time"`
+	Calories   []bucketEntity        `bson:"calories"`
+}
+
+func toFacetCounts(entities []countEntity) []recipe.FacetCount {
+	counts := make([]recipe.FacetCount, 0, len(entities))
+	for _, e := range entities {
+		if e.Value == "" {
+			continue
+		}
+		counts = append(counts, recipe.FacetCount{Value: e.Value, Count: e.Count})
+	}
+
+	return counts
+}
+
+// toFacetBuckets lists every bucket, including the empty ones $bucket leaves out.
+func toFacetBuckets(entities []bucketEntity, boundaries []float64) []recipe.FacetBucket {
+	counts := map[float64]int{}
+	for _, e := range entities {
+		if min, ok := e.Min.(float64); ok {
+			counts[min] = e.Count
+		}
+	}
+
+	buckets := make([]recipe.FacetBucket, len(boundaries))
+	for i, min := range boundaries {
+		buckets[i] = recipe.FacetBucket{Min: min, Count: counts[min]}
+		if i+1 < len(boundaries) {
+			buckets[i].Max = &boundaries[i+1]
+		}
+	}
+
+	return buckets
+}
+
+// SearchRecipe ranks a text query with the search index, then runs the facet filters as
+// a single aggregation over the hits. The $facet stage returns the requested page in the
+// order of the index along with the total and the facet counts of all matching recipes.
+func (repo *repository) SearchRecipe(ctx context.Context, params recipe.SearchParams) (*recipe.SearchResult, error) {
+	filter := searchFilter(params)
+	page := bson.A{}
+
+	var hits []recipe.SearchHit
+	if query := strings.TrimSpace(params.Query); query != "" {
+		var err error
+		hits, err = repo.searchIndex.Search(ctx, query, recipe.MaxSearchHits)
+		if err != nil {
+			return nil, err
+		}
+
+		ids := bson.A{}
+		for _, hit := range hits {
+			if id, err := primitive.ObjectIDFromHex(hit.RecipeID); err == nil {
+				ids = append(ids, id)
+			}
+		}
+
+		filter["_id"] = bson.M{"$in": ids}
+		page = append(page, bson.M{"$addFields": bson.M{"rank": bson.M{"$indexOfArray": bson.A{ids, "$_id"}}}})
+	}
+
+	key, ascending := sortKey(params.Sort)
+	direction := -1
+	if ascending {
+		direction = 1
+	}
+
+	page = append(page, bson.M{"$addFields": bson.M{"sort_key": key}})
+
+	if params.Cursor != "" {
+		c, err := decodeSearchCursor(params.Cursor, params.Sort)
+		if err != nil {
+			return nil, err
+		}
+
+		page = append(page, bson.M{"$match": afterCursor(c, ascending)})
+	}
+
+	page = append(page, bson.M{"$sort": bson.D{{Key: "sort_key", Value: direction}, {Key: "_id", Value: direction}}})
+
+	if params.Cursor == "" && params.Page > 1 {
+		page = append(page, bson.M{"$skip": (params.Page - 1) * params.Limit})
+	}
+
+	// one extra recipe tells whether there is a next page
+	page = append(page, bson.M{"$limit": params.Limit + 1})
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$facet", Value: bson.M{
+			"recipes":     page,
+			"total":       bson.A{bson.M{"$count": "count"}},
+			"categories":  bson.A{bson.M{"$sortByCount": "$category"}},
+			"tags":        bson.A{bson.M{"$unwind": "$tags"}, bson.M{"$sortByCount": "$tags"}},
+			"diet_labels": bson.A{bson.M{"$unwind": "$diet_labels"}, bson.M{"$sortByCount": "$diet_labels"}},
+			"servings":    bucketFacet("$servings", recipe.ServingsBuckets),
+			"cook_time":   bucketFacet("$cook_time", recipe.CookTimeBuckets),
+			"calories":    bucketFacet(perServing("$nutrition_info.calories"), recipe.CaloriesBuckets),
+		}}},
+	}
+
+	cursor, err := repo.db.Collection(recipesCollection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []searchEntity
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	result := &recipe.SearchResult{
+		Recipes:    []*recipe.RecipeModel{},
+		Highlights: map[string]map[string][]string{},
+		Truncated:  len(hits) >= recipe.MaxSearchHits,
+	}
+	if len(results) == 0 {
+		return result, nil
+	}
+
+	highlights := map[string]map[string][]string{}
+	for _, hit := range hits {
+		highlights[hit.RecipeID] = hit.Highlights
+	}
+
+	entity := results[0]
+
+	if int64(len(entity.Recipes)) > params.Limit {
+		entity.Recipes = entity.Recipes[:params.Limit]
+
+		last := entity.Recipes[len(entity.Recipes)-1]
+		result.NextCursor = searchCursor{Sort: params.Sort, Value: last.SortKey, ID: last.ID.Hex()}.encode()
+	}
+
+	for _, r := range entity.Recipes {
+		model := r.toRecipeModel()
+		result.Recipes = append(result.Recipes, model)
+
+		if len(highlights[model.ID]) > 0 {
+			result.Highlights[model.ID] = highlights[model.ID]
+		}
 	}
 
-	recipes := make([]*recipe.RecipeModel, len(results))
-	for i, r := range results {
-		recipes[i] = r.toRecipeModel()
+	if len(entity.Total) > 0 {
+		result.Total = entity.Total[0].Count
 	}
 
-	count, err := repo.db.Collection(recipesCollection).CountDocuments(ctx, filter)
+	result.Facets = recipe.Facets{
+		Categories: toFacetCounts(entity.Categories),
+		Tags:       toFacetCounts(entity.Tags),
+		DietLabels: toFacetCounts(entity.DietLabels),
+		Servings:   toFacetBuckets(entity.Servings, recipe.ServingsBuckets),
+		CookTime:   toFacetBuckets(entity.CookTime, recipe.CookTimeBuckets),
+		Calories:   toFacetBuckets(entity.Calories, recipe.CaloriesBuckets),
+	}
+
+	return result, nil
+}
+
+func (repo *repository) UpdateRecipe(ctx context.Context, id string, update recipe.UpdateRecipeDTO) (*recipe.RecipeModel, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, 0, err
+		return nil, database.ErrNotFound
+	}
+
+	set := bson.M{"updated_at": time.Now()}
+
+	if update.Name != nil {
+		set["name"] = *update.Name
+	}
+	if update.Description != nil {
+		set["description"] = *update.Description
+	}
+	if update.Category != nil {
+		set["category"] = *update.Category
+	}
+	if update.Tags != nil {
+		set["tags"] = *update.Tags
+	}
+	if update.Ingredients != nil {
+		set["ingredients"] = fp.Map(*update.Ingredients, func(i recipe.IngredientModel) ingredientEntity {
+			return ingredientEntity(i)
+		})
+	}
+	if update.Steps != nil {
+		set["steps"] = fp.Map(*update.Steps, toStepEntity)
+	}
+	if update.NutritionSource != nil {
+		set["nutrition_source"] = *update.NutritionSource
+	}
+	if update.Allergens != nil {
+		set["allergens"] = *update.Allergens
+	}
+	if update.DietLabels != nil {
+		set["diet_labels"] = *update.DietLabels
+	}
+	if update.CookTime != nil {
+		set["cook_time"] = *update.CookTime
+	}
+	if update.Servings != nil {
+		set["servings"] = *update.Servings
 	}
 
-	totalDocuments := int(count)
+	if n := update.Nutrition; n != nil {
+		if n.Calories != nil {
+			set["nutrition_info.calories"] = *n.Calories
+		}
+		if n.Protein != nil {
+			set["nutrition_info.protein"] = *n.Protein
+		}
+		if n.Fat != nil {
+			set["nutrition_info.fat"] = *n.Fat
+		}
+		if n.Carbohydrates != nil {
+			set["nutrition_info.carbohydrates"] = *n.Carbohydrates
+		}
+		if n.Fiber != nil {
+			set["nutrition_info.fiber"] = *n.Fiber
+		}
+		if n.Sugar != nil {
+			set["nutrition_info.sugar"] = *n.Sugar
+		}
+		if n.Sodium != nil {
+			set["nutrition_info.sodium"] = *n.Sodium
+		}
+	}
+
+	filter := bson.M{"_id": objectID}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	entity := &recipeEntity{}
+
+	err = repo.db.Collection(recipesCollection).FindOneAndUpdate(ctx, filter, bson.M{"$set": set}, opts).Decode(entity)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, database.ErrNotFound
+		}
+		return nil, err
+	}
 
-	return recipes, totalDocuments, nil
+	return entity.toRecipeModel(), nil
 }
 
-func (repo *repository) UpdateRecipe(ctx context.Context, id string, update recipe.UpdateRecipeDTO) error {
-	panic("unimplemented")
+func (repo *repository) AddStep(ctx context.Context, recipeID string, step *recipe.StepModel, position *int) error {
+	objectID, err := primitive.ObjectIDFromHex(recipeID)
+	if err != nil {
+		return database.ErrNotFound
+	}
+
+	entity := toStepEntity(*step)
+
+	push := bson.M{"$each": []stepEntity{entity}}
+	if position != nil {
+		push["$position"] = *position
+	}
+
+	filter := bson.M{"_id": objectID}
+	update := bson.M{
+		"$push": bson.M{"steps": push},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := repo.db.Collection(recipesCollection).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return database.ErrNotFound
+	}
+
+	step.ID = entity.ID
+	return nil
+}
+
+func (repo *repository) UpdateStep(ctx context.Context, recipeID, stepID string, update recipe.UpdateStepDTO) error {
+	objectID, err := primitive.ObjectIDFromHex(recipeID)
+	if err != nil {
+		return database.ErrNotFound
+	}
+
+	set := bson.M{"updated_at": time.Now()}
+
+	if update.Text != nil {
+		set["steps.$[step].text"] = *update.Text
+	}
+	if update.Duration != nil {
+		set["steps.$[step].duration"] = *update.Duration
+	}
+	if update.Temperature != nil {
+		set["steps.$[step].temperature"] = temperatureEntity{
+			Value: update.Temperature.Value,
+			Unit:  update.Temperature.Unit,
+		}
+	}
+	if update.IngredientIDs != nil {
+		set["steps.$[step].ingredient_ids"] = *update.IngredientIDs
+	}
+
+	filter := bson.M{"_id": objectID, "steps.id": stepID}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []any{bson.M{"step.id": stepID}},
+	})
+
+	result, err := repo.db.Collection(recipesCollection).UpdateOne(ctx, filter, bson.M{"$set": set}, opts)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return database.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *repository) DeleteStep(ctx context.Context, recipeID, stepID string) error {
+	objectID, err := primitive.ObjectIDFromHex(recipeID)
+	if err != nil {
+		return database.ErrNotFound
+	}
+
+	filter := bson.M{"_id": objectID, "steps.id": stepID}
+	update := bson.M{
+		"$pull": bson.M{"steps": bson.M{"id": stepID}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := repo.db.Collection(recipesCollection).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return database.ErrNotFound
+	}
+
+	return nil
+}
+
+// imageField is the path of the picture list of the recipe, or of the step matched by the
+// step array filter.
+func imageField(stepID string) string {
+	if stepID == "" {
+		return "images"
+	}
+
+	return "steps.$[step].images"
+}
+
+// imageUpdate runs an update on the picture list of the recipe or of one of its steps.
+func (repo *repository) imageUpdate(ctx context.Context, recipeID, stepID string, update bson.M) error {
+	objectID, err := primitive.ObjectIDFromHex(recipeID)
+	if err != nil {
+		return database.ErrNotFound
+	}
+
+	filter := bson.M{"_id": objectID}
+	opts := options.Update()
+	if stepID != "" {
+		filter["steps.id"] = stepID
+		opts.SetArrayFilters(options.ArrayFilters{
+			Filters: []any{bson.M{"step.id": stepID}},
+		})
+	}
+
+	update["$set"] = bson.M{"updated_at": time.Now()}
+
+	result, err := repo.db.Collection(recipesCollection).UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return database.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *repository) AddImage(ctx context.Context, recipeID, stepID string, image media.ImageModel) error {
+	return repo.imageUpdate(ctx, recipeID, stepID, bson.M{
+		"$push": bson.M{imageField(stepID): toImageEntity(image)},
+	})
+}
+
+func (repo *repository) RemoveImage(ctx context.Context, recipeID, stepID, imageID string) error {
+	return repo.imageUpdate(ctx, recipeID, stepID, bson.M{
+		"$pull": bson.M{imageField(stepID): bson.M{"id": imageID}},
+	})
 }
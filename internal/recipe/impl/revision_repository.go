@@ -0,0 +1,227 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/recipe"
+	"flove/job/pkg/fp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	revisionsCollection = "recipe_revisions"
+)
+
+type revisionEntity struct {
+	ID        primitive.ObjectID  `bson:"_id,omitempty"`
+	RecipeID  string              `bson:"recipe_id"`
+	Number    int                 `bson:"number"`
+	AuthorID  string              `bson:"author_id"`
+	Changes   []fieldChangeEntity `bson:"changes"`
+	Snapshot  recipeEntity        `bson:"snapshot"`
+	CreatedAt time.Time           `bson:"created_at"`
+}
+
+type fieldChangeEntity struct {
+	Field string        `bson:"field"`
+	Old   bson.RawValue `bson:"old"`
+	New   bson.RawValue `bson:"new"`
+}
+
+func (e *revisionEntity) toRevisionModel() (*recipe.RevisionModel, error) {
+	changes := make([]recipe.FieldChange, len(e.Changes))
+	for i, c := range e.Changes {
+		oldValue, err := decodeFieldValue(c.Field, c.Old)
+		if err != nil {
+			return nil, err
+		}
+
+		newValue, err := decodeFieldValue(c.Field, c.New)
+		if err != nil {
+			return nil, err
+		}
+
+		changes[i] = recipe.FieldChange{Field: c.Field, Old: oldValue, New: newValue}
+	}
+
+	snapshot := e.Snapshot.toRecipeModel()
+	snapshot.ID = e.RecipeID
+
+	return &recipe.RevisionModel{
+		ID:        e.ID.Hex(),
+		RecipeID:  e.RecipeID,
+		Number:    e.Number,
+		AuthorID:  e.AuthorID,
+		Changes:   changes,
+		Snapshot:  *snapshot,
+		CreatedAt: e.CreatedAt,
+	}, nil
+}
+
+func toRevisionEntity(r *recipe.RevisionModel) (*revisionEntity, error) {
+	changes := make([]fieldChangeEntity, len(r.Changes))
+	for i, c := range r.Changes {
+		oldValue, err := encodeFieldValue(c.Old)
+		if err != nil {
+			return nil, err
+		}
+
+		newValue, err := encodeFieldValue(c.New)
+		if err != nil {
+			return nil, err
+		}
+
+		changes[i] = fieldChangeEntity{Field: c.Field, Old: oldValue, New: newValue}
+	}
+
+	return &revisionEntity{
+		RecipeID:  r.RecipeID,
+		Number:    r.Number,
+		AuthorID:  r.AuthorID,
+		Changes:   changes,
+		Snapshot:  *toEntity(&r.Snapshot),
+		CreatedAt: r.CreatedAt,
+	}, nil
+}
+
+// encodeFieldValue stores ingredient and step lists in their entity form so they
+// can be decoded back into models by decodeFieldValue.
+func encodeFieldValue(value any) (bson.RawValue, error) {
+	switch v := value.(type) {
+	case []recipe.IngredientModel:
+		value = fp.Map(v, func(i recipe.IngredientModel) ingredientEntity { return ingredientEntity(i) })
+	case []recipe.StepModel:
+		value = fp.Map(v, toStepEntity)
+	}
+
+	t, data, err := bson.MarshalValue(value)
+	if err != nil {
+		return bson.RawValue{}, err
+	}
+
+	return bson.RawValue{Type: t, Value: data}, nil
+}
+
+func decodeFieldValue(field string, raw bson.RawValue) (any, error) {
+	switch field {
+	case recipe.FieldName, recipe.FieldDescription, recipe.FieldCategory, recipe.FieldNutritionSource:
+		var v string
+		return v, raw.Unmarshal(&v)
+	case recipe.FieldTags:
+		var v []string
+		return v, raw.Unmarshal(&v)
+	case recipe.FieldCookTime, recipe.FieldServings:
+		var v int
+		return v, raw.Unmarshal(&v)
+	case recipe.FieldIngredients:
+		var v []ingredientEntity
+		if err := raw.Unmarshal(&v); err != nil {
+			return nil, err
+		}
+		return fp.Map(v, func(i ingredientEntity) recipe.IngredientModel { return recipe.IngredientModel(i) }), nil
+	case recipe.FieldSteps:
+		var v []stepEntity
+		if err := raw.Unmarshal(&v); err != nil {
+			return nil, err
+		}
+		return fp.Map(v, stepEntity.toStepModel), nil
+	default:
+		var v float64
+		return v, raw.Unmarshal(&v)
+	}
+}
+
+type revisionRepository struct {
+	config *config.Config
+	db     *mongo.Database
+}
+
+func NewRevisionRepository(config *config.Config, db *mongo.Database) recipe.RevisionRepository {
+	ctx := context.Background()
+
+	db.Collection(revisionsCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "recipe_id", Value: 1}, {Key: "number", Value: -1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	return &revisionRepository{
+		config: config,
+		db:     db,
+	}
+}
+
+func (repo *revisionRepository) CreateRevision(ctx context.Context, r *recipe.RevisionModel) error {
+	entity, err := toRevisionEntity(r)
+	if err != nil {
+		return err
+	}
+
+	result, err := repo.db.Collection(revisionsCollection).InsertOne(ctx, entity)
+	if err != nil {
+		return err
+	}
+
+	r.ID = result.InsertedID.(primitive.ObjectID).Hex()
+	return nil
+}
+
+func (repo *revisionRepository) GetRevision(ctx context.Context, recipeID string, number int) (*recipe.RevisionModel, error) {
+	filter := bson.M{"recipe_id": recipeID, "number": number}
+	return repo.findOne(ctx, filter, options.FindOne())
+}
+
+func (repo *revisionRepository) GetLatestRevision(ctx context.Context, recipeID string) (*recipe.RevisionModel, error) {
+	filter := bson.M{"recipe_id": recipeID}
+	return repo.findOne(ctx, filter, options.FindOne().SetSort(bson.M{"number": -1}))
+}
+
+func (repo *revisionRepository) findOne(ctx context.Context, filter bson.M, opts *options.FindOneOptions) (*recipe.RevisionModel, error) {
+	entity := &revisionEntity{}
+
+	if err := repo.db.Collection(revisionsCollection).FindOne(ctx, filter, opts).Decode(entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, database.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return entity.toRevisionModel()
+}
+
+func (repo *revisionRepository) ListRevisions(ctx context.Context, recipeID string) ([]*recipe.RevisionModel, error) {
+	filter := bson.M{"recipe_id": recipeID}
+	opts := options.Find().SetSort(bson.M{"number": -1})
+
+	cursor, err := repo.db.Collection(revisionsCollection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*revisionEntity
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	revisions := make([]*recipe.RevisionModel, len(results))
+	for i, r := range results {
+		revision, err := r.toRevisionModel()
+		if err != nil {
+			return nil, err
+		}
+		revisions[i] = revision
+	}
+
+	return revisions, nil
+}
+
+func (repo *revisionRepository) DeleteRevisions(ctx context.Context, recipeID string) error {
+	_, err := repo.db.Collection(revisionsCollection).DeleteMany(ctx, bson.M{"recipe_id": recipeID})
+	return err
+}
@@ -0,0 +1,75 @@
+package impl
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"flove/job/internal/recipe"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// searchCursor points right after the last recipe of a page. Every sort key is numeric,
+// dates as Unix milliseconds, and the recipe ID breaks ties between equal keys.
+type searchCursor struct {
+	Sort  recipe.SortOrder `json:"s"`
+	Value float64          `json:"v"`
+	ID    string           `json:"id"`
+}
+
+func (c searchCursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeSearchCursor(token string, sort recipe.SortOrder) (*searchCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, recipe.ErrInvalidCursor
+	}
+
+	var c searchCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Sort != sort {
+		return nil, recipe.ErrInvalidCursor
+	}
+
+	if _, err := primitive.ObjectIDFromHex(c.ID); err != nil {
+		return nil, recipe.ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+// sortKey returns the expression a search is ordered by and whether the order is ascending.
+// Missing counters count as zero, so that old recipes keep a comparable key.
+func sortKey(sort recipe.SortOrder) (any, bool) {
+	switch sort {
+	case recipe.SortMostViewed:
+		return bson.M{"$ifNull": bson.A{"$views", 0}}, false
+	case recipe.SortMostLiked:
+		return bson.M{"$ifNull": bson.A{"$likes", 0}}, false
+	case recipe.SortHighestRated:
+		return bson.M{"$ifNull": bson.A{"$rating.average", 0}}, false
+	case recipe.SortFewestCalories:
+		return perServing("$nutrition_info.calories"), true
+	case recipe.SortRelevance:
+		return "$rank", true
+	default:
+		return bson.M{"$toLong": "$created_at"}, false
+	}
+}
+
+// afterCursor matches the recipes that come after the cursor in the sort order.
+func afterCursor(c *searchCursor, ascending bool) bson.M {
+	operator := "$lt"
+	if ascending {
+		operator = "$gt"
+	}
+
+	id, _ := primitive.ObjectIDFromHex(c.ID)
+
+	return bson.M{"$or": bson.A{
+		bson.M{"sort_key": bson.M{operator: c.Value}},
+		bson.M{"sort_key": c.Value, "_id": bson.M{operator: id}},
+	}}
+}
@@ -0,0 +1,53 @@
+package impl
+
+import (
+	"encoding/base64"
+	"errors"
+	"flove/job/internal/recipe"
+	"testing"
+)
+
+func TestSearchCursorRoundTrip(t *testing.T) {
+	tests := []searchCursor{
+		{Sort: recipe.SortNewest, Value: 1760745600000, ID: "652f1c9e8b3e4a0012345678"},
+		{Sort: recipe.SortHighestRated, Value: 4.25, ID: "652f1c9e8b3e4a0012345679"},
+		{Sort: recipe.SortFewestCalories, Value: 0, ID: "652f1c9e8b3e4a001234567a"},
+		{Sort: recipe.SortRelevance, Value: 999, ID: "652f1c9e8b3e4a001234567b"},
+	}
+
+	for _, want := range tests {
+		t.Run(string(want.Sort), func(t *testing.T) {
+			got, err := decodeSearchCursor(want.encode(), want.Sort)
+			if err != nil {
+				t.Fatalf("decodeSearchCursor: %v", err)
+			}
+
+			if *got != want {
+				t.Errorf("decodeSearchCursor(encode(%+v)) = %+v", want, *got)
+			}
+		})
+	}
+}
+
+func TestDecodeSearchCursorInvalid(t *testing.T) {
+	valid := searchCursor{Sort: recipe.SortNewest, Value: 1, ID: "652f1c9e8b3e4a0012345678"}.encode()
+
+	tests := []struct {
+		name  string
+		token string
+		sort  recipe.SortOrder
+	}{
+		{name: "other sort order", token: valid, sort: recipe.SortMostLiked},
+		{name: "not base64", token: "not a cursor!", sort: recipe.SortNewest},
+		{name: "not json", token: base64.RawURLEncoding.EncodeToString([]byte("newest")), sort: recipe.SortNewest},
+		{name: "invalid recipe ID", token: searchCursor{Sort: recipe.SortNewest, ID: "42"}.encode(), sort: recipe.SortNewest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeSearchCursor(tt.token, tt.sort); !errors.Is(err, recipe.ErrInvalidCursor) {
+				t.Errorf("decodeSearchCursor(%q) error = %v, want %v", tt.token, err, recipe.ErrInvalidCursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package impl
+
+import (
+	"context"
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/recipe"
+	"flove/job/pkg/search"
+	"fmt"
+	"log"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	SearchBackendEmbedded = "embedded"
+	SearchBackendMongo    = "mongo"
+)
+
+// searchBoosts weigh a match in the recipe name over one in the ingredients, the
+// description and the steps. The Mongo text index uses the same weights.
+var searchBoosts = map[string]float64{
+	recipe.SearchFieldName:        4,
+	recipe.SearchFieldIngredients: 2,
+	recipe.SearchFieldDescription: 1.5,
+	recipe.SearchFieldSteps:       1,
+}
+
+// NewSearchIndex opens the search backend selected in the config. The embedded engine
+// falls back to the Mongo text index when its files cannot be opened.
+func NewSearchIndex(config *config.Config, db *mongo.Database) recipe.SearchIndex {
+	if config.Search.Backend == SearchBackendEmbedded {
+		index, err := search.Open(config.Search.Path, searchBoosts)
+		if err == nil {
+			return &embeddedSearchIndex{index: index}
+		}
+
+		log.Printf("Error opening the search index at %s, falling back to mongo: %v", config.Search.Path, err)
+	}
+
+	return &mongoSearchIndex{db: db}
+}
+
+// SyncSearchIndex indexes every recipe, which catches up with the events missed while
+// the service was down, and keeps the index in sync with the recipe:* events afterwards.
+func SyncSearchIndex(ctx context.Context, eventBus *database.EventBus, repo recipe.RecipeRepository, index recipe.SearchIndex) error {
+	if _, ok := index.(*mongoSearchIndex); ok {
+		return nil
+	}
+
+	recipes, err := repo.ListRecipes(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range recipes {
+		if r.Status != recipe.StatusPublished {
+			continue
+		}
+
+		if err := index.IndexRecipe(ctx, r); err != nil {
+			return err
+		}
+	}
+
+	reindex := func(ctx context.Context, recipeID string) error {
+		r, err := repo.GetRecipeByID(ctx, recipeID)
+		switch {
+		case err == database.ErrNotFound:
+			err = index.RemoveRecipe(ctx, recipeID)
+		case err == nil && r.Status != recipe.StatusPublished:
+			err = index.RemoveRecipe(ctx, recipeID)
+		case err == nil:
+			err = index.IndexRecipe(ctx, r)
+		}
+		if err != nil {
+			return fmt.Errorf("reindexing recipe %s: %w", recipeID, err)
+		}
+
+		return nil
+	}
+
+	// every instance keeps an index of its own when the engine is embedded
+	group := eventBus.LocalGroup("search-index")
+	database.Subscribe(eventBus, group, func(ctx context.Context, event recipe.RecipeCreated) error {
+		return reindex(ctx, event.ID)
+	})
+	database.Subscribe(eventBus, group, func(ctx context.Context, event recipe.RecipeUpdated) error {
+		return reindex(ctx, event.ID)
+	})
+	database.Subscribe(eventBus, group, func(ctx context.Context, event recipe.RecipeDeleted) error {
+		if err := index.RemoveRecipe(ctx, event.ID); err != nil {
+			return fmt.Errorf("removing recipe %s from the search index: %w", event.ID, err)
+		}
+
+		return nil
+	})
+
+	return nil
+}
+
+type embeddedSearchIndex struct {
+	index *search.Index
+}
+
+func (idx *embeddedSearchIndex) IndexRecipe(_ context.Context, r *recipe.RecipeModel) error {
+	ingredients := make([]string, len(r.Ingredients))
+	for i, line := range r.Ingredients {
+		ingredients[i] = line.Name
+	}
+
+	steps := make([]string, len(r.Steps))
+	for i, step := range r.Steps {
+		steps[i] = step.Text
+	}
+
+	return idx.index.Put(search.Document{
+		ID: r.ID,
+		Fields: map[string]string{
+			recipe.SearchFieldName:        r.Name,
+			recipe.SearchFieldDescription: r.Description,
+			recipe.SearchFieldIngredients: strings.Join(ingredients, ", "),
+			recipe.SearchFieldSteps:       strings.Join(steps, "\n"),
+		},
+	})
+}
+
+func (idx *embeddedSearchIndex) RemoveRecipe(_ context.Context, id string) error {
+	return idx.index.Delete(id)
+}
+
+func (idx *embeddedSearchIndex) Search(_ context.Context, query string, limit int) ([]recipe.SearchHit, error) {
+	hits := idx.index.Search(query, limit)
+
+	result := make([]recipe.SearchHit, len(hits))
+	for i, hit := range hits {
+		result[i] = recipe.SearchHit{
+			RecipeID:   hit.ID,
+			Score:      hit.Score,
+			Highlights: hit.Highlights,
+		}
+	}
+
+	return result, nil
+}
+
+// mongoSearchIndex queries the text index of the recipes collection directly, so it needs
+// no feeding. It does not highlight matches.
+type mongoSearchIndex struct {
+	db *mongo.Database
+}
+
+// textIndexModel is the weighted text index the Mongo backend searches.
+func textIndexModel() mongo.IndexModel {
+	weights := bson.D{}
+	for _, field := range []struct{ name, path string }{
+		{recipe.SearchFieldName, "name"},
+		{recipe.SearchFieldDescription, "description"},
+		{recipe.SearchFieldIngredients, "ingredients.name"},
+		{recipe.SearchFieldSteps, "steps.text"},
+	} {
+		// text index weights are integers, doubling keeps the half boosts
+		weights = append(weights, bson.E{Key: field.path, Value: int(searchBoosts[field.name] * 2)})
+	}
+
+	keys := bson.D{}
+	for _, weight := range weights {
+		keys = append(keys, bson.E{Key: weight.Key, Value: "text"})
+	}
+
+	return mongo.IndexModel{
+		Keys:    keys,
+		Options: options.Index().SetName("recipe_text").SetWeights(weights),
+	}
+}
+
+func (idx *mongoSearchIndex) IndexRecipe(context.Context, *recipe.RecipeModel) error {
+	return nil
+}
+
+func (idx *mongoSearchIndex) RemoveRecipe(context.Context, string) error {
+	return nil
+}
+
+func (idx *mongoSearchIndex) Search(ctx context.Context, query string, limit int) ([]recipe.SearchHit, error) {
+	opts := options.Find().
+		SetProjection(bson.M{"_id": 1, "score": bson.M{"$meta": "textScore"}}).
+		SetSort(bson.M{"score": bson.M{"$meta": "textScore"}}).
+		SetLimit(int64(limit))
+
+	// drafts must not take up the hits the search is capped at
+	filter := bson.M{
+		"$text":  bson.M{"$search": query},
+		"status": bson.M{"$in": bson.A{recipe.StatusPublished, nil}},
+	}
+
+	cursor, err := idx.db.Collection(recipesCollection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []struct {
+		ID    primitive.ObjectID `bson:"_id"`
+		Score float64            `bson:"score"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	hits := make([]recipe.SearchHit, len(results))
+	for i, r := range results {
+		hits[i] = recipe.SearchHit{RecipeID: r.ID.Hex(), Score: r.Score}
+	}
+
+	return hits, nil
+}
@@ -0,0 +1,99 @@
+package impl
+
+import (
+	"context"
+	"flove/job/internal/base/database"
+	"flove/job/internal/recipe"
+	"flove/job/pkg/suggest"
+	"fmt"
+)
+
+type suggestIndex struct {
+	index      *suggest.Index
+	recipeRepo recipe.RecipeRepository
+}
+
+// NewSuggestIndex loads every recipe into the autocomplete index and keeps it in sync
+// with the recipe:* events. Created and updated recipes are reloaded from the repository,
+// since the event only carries what the recommendation graph needs.
+func NewSuggestIndex(ctx context.Context, eventBus *database.EventBus, repo recipe.RecipeRepository) (recipe.SuggestIndex, error) {
+	idx := &suggestIndex{
+		index:      suggest.NewIndex(),
+		recipeRepo: repo,
+	}
+
+	recipes, err := repo.ListRecipes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range recipes {
+		idx.put(r)
+	}
+
+	// the index is kept in memory, every instance needs all the events
+	group := eventBus.LocalGroup("suggest-index")
+	database.Subscribe(eventBus, group, func(ctx context.Context, event recipe.RecipeCreated) error {
+		return idx.reload(ctx, event.ID)
+	})
+	database.Subscribe(eventBus, group, func(ctx context.Context, event recipe.RecipeUpdated) error {
+		return idx.reload(ctx, event.ID)
+	})
+	database.Subscribe(eventBus, group, func(_ context.Context, event recipe.RecipeDeleted) error {
+		idx.index.Remove(event.ID)
+		return nil
+	})
+
+	return idx, nil
+}
+
+func (idx *suggestIndex) reload(ctx context.Context, recipeID string) error {
+	r, err := idx.recipeRepo.GetRecipeByID(ctx, recipeID)
+	if err != nil {
+		if err == database.ErrNotFound {
+			idx.index.Remove(recipeID)
+			return nil
+		}
+
+		return fmt.Errorf("reloading recipe %s into the suggest index: %w", recipeID, err)
+	}
+
+	idx.put(r)
+	return nil
+}
+
+func (idx *suggestIndex) put(r *recipe.RecipeModel) {
+	// drafts and archived recipes are not suggested
+	if r.Status != recipe.StatusPublished {
+		idx.index.Remove(r.ID)
+		return
+	}
+
+	entries := []suggest.Entry{{Text: r.Name, Kind: recipe.SuggestionRecipe}}
+
+	for _, tag := range r.Tags {
+		entries = append(entries, suggest.Entry{Text: tag, Kind: recipe.SuggestionTag})
+	}
+
+	for _, line := range r.Ingredients {
+		entries = append(entries, suggest.Entry{Text: line.Name, Kind: recipe.SuggestionIngredient})
+	}
+
+	idx.index.Put(r.ID, entries, r.Popularity())
+}
+
+func (idx *suggestIndex) Suggest(query string, limit int) []recipe.SuggestionModel {
+	suggestions := idx.index.Suggest(query, limit)
+
+	result := make([]recipe.SuggestionModel, len(suggestions))
+	for i, s := range suggestions {
+		result[i] = recipe.SuggestionModel{
+			Text:      s.Text,
+			Kind:      s.Kind,
+			RecipeIDs: s.DocIDs,
+			Score:     s.Score,
+		}
+	}
+
+	return result
+}
@@ -2,79 +2,617 @@ package impl
 
 import (
 	"context"
+	"errors"
 	"flove/job/config"
 	"flove/job/internal/base/database"
+	"flove/job/internal/ingredient"
+	"flove/job/internal/media"
 	"flove/job/internal/recipe"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 )
 
 type usecase struct {
-	config     *config.Config
-	eventBus   *database.EventBus
-	recipeRepo recipe.RecipeRepository
+	config         *config.Config
+	eventBus       *database.EventBus
+	transactor     *database.Transactor
+	recipeRepo     recipe.RecipeRepository
+	revisionRepo   recipe.RevisionRepository
+	ratingRepo     recipe.RatingRepository
+	ingredientRepo ingredient.IngredientRepository
+	suggestIndex   recipe.SuggestIndex
+	imageUC        media.ImageUC
 }
 
-func NewRecipeUC(config *config.Config, eventBus *database.EventBus, repo recipe.RecipeRepository) recipe.RecipeUC {
+func NewRecipeUC(config *config.Config, eventBus *database.EventBus, transactor *database.Transactor, repo recipe.RecipeRepository, revisionRepo recipe.RevisionRepository, ratingRepo recipe.RatingRepository, ingredientRepo ingredient.IngredientRepository, suggestIndex recipe.SuggestIndex, imageUC media.ImageUC) recipe.RecipeUC {
 	return &usecase{
-		config:     config,
-		eventBus:   eventBus,
-		recipeRepo: repo,
+		config:         config,
+		eventBus:       eventBus,
+		transactor:     transactor,
+		recipeRepo:     repo,
+		revisionRepo:   revisionRepo,
+		ratingRepo:     ratingRepo,
+		ingredientRepo: ingredientRepo,
+		suggestIndex:   suggestIndex,
+		imageUC:        imageUC,
 	}
 }
 
-func (uc *usecase) CreateRecipe(ctx context.Context, recipe *recipe.RecipeModel) error {
-	if err := uc.recipeRepo.CreateRecipe(ctx, recipe); err != nil {
+// lookupIngredients resolves the catalogue entries referenced by an ingredient list.
+func (uc *usecase) lookupIngredients(ctx context.Context, lines []recipe.IngredientModel) (map[string]*ingredient.IngredientModel, error) {
+	catalogue := make(map[string]*ingredient.IngredientModel, len(lines))
+
+	for _, line := range lines {
+		entry, err := uc.ingredientRepo.GetIngredientByID(ctx, line.IngredientID)
+		if err != nil {
+			if err == database.ErrNotFound {
+				return nil, fmt.Errorf("%w: %s", recipe.ErrUnknownIngredient, line.IngredientID)
+			}
+			return nil, err
+		}
+
+		catalogue[entry.ID] = entry
+	}
+
+	return catalogue, nil
+}
+
+func (uc *usecase) CreateRecipe(ctx context.Context, actor recipe.Actor, r *recipe.RecipeModel) error {
+	if r.NutritionSource == recipe.NutritionOverridden && !actor.IsAdmin() {
+		return recipe.ErrNutritionOverride
+	}
+
+	// recipes written by admins skip the review
+	r.AuthorID = actor.UserID
+	r.Status = recipe.StatusDraft
+	if actor.IsAdmin() {
+		r.Status = recipe.StatusPublished
+	}
+
+	catalogue, err := uc.lookupIngredients(ctx, r.Ingredients)
+	if err != nil {
+		return err
+	}
+
+	if r.NutritionSource != recipe.NutritionOverridden {
+		r.Nutrition, _ = recipe.ComputeNutrition(r.Ingredients, catalogue)
+		r.NutritionSource = recipe.NutritionComputed
+	}
+
+	r.Allergens, r.DietLabels = recipe.InferLabels(r.Ingredients, catalogue, r.Nutrition)
+
+	if err := uc.recipeRepo.CreateRecipe(ctx, r); err != nil {
 		return err
 	}
 
-	if err := uc.eventBus.Publish("recipe:created",
-		fmt.Sprintf("%s:%s:%s:%s",
-			recipe.ID,
-			recipe.Name,
-			recipe.Category,
-			strings.Join(recipe.Tags, ","),
-		)); err != nil {
+	if err := uc.eventBus.Publish(recipe.RecipeCreated{RecipeSnapshot: recipe.NewRecipeSnapshot(r)}); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (uc *usecase) DeleteRecipe(ctx context.Context, id string) error {
+// editableRecipe loads a recipe the actor is allowed to change.
+func (uc *usecase) editableRecipe(ctx context.Context, actor recipe.Actor, id string) (*recipe.RecipeModel, error) {
+	r, err := uc.recipeRepo.GetRecipeByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.IsVisibleTo(actor) {
+		return nil, database.ErrNotFound
+	}
+
+	if !r.CanEdit(actor) {
+		return nil, recipe.ErrForbidden
+	}
+
+	return r, nil
+}
+
+func (uc *usecase) DeleteRecipe(ctx context.Context, actor recipe.Actor, id string) error {
+	r, err := uc.editableRecipe(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+
 	if err := uc.recipeRepo.DeleteRecipe(ctx, id); err != nil {
 		return err
 	}
 
-	if err := uc.eventBus.Publish("recipe:deleted", id); err != nil {
+	// files left behind by a failed removal are not reachable from any recipe anymore
+	for _, image := range r.AllImages() {
+		_ = uc.imageUC.DeleteImage(ctx, image)
+	}
+
+	if err := uc.ratingRepo.DeleteRecipeRatings(ctx, id); err != nil {
+		return err
+	}
+
+	if err := uc.revisionRepo.DeleteRevisions(ctx, id); err != nil {
+		return err
+	}
+
+	if err := uc.eventBus.Publish(recipe.RecipeDeleted{ID: id}); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (uc *usecase) GetRecipeByID(ctx context.Context, id string) (*recipe.RecipeModel, error) {
-	recipe, err := uc.recipeRepo.GetRecipeByID(ctx, id)
+func (uc *usecase) GetRecipeByID(ctx context.Context, actor recipe.Actor, id string) (*recipe.RecipeModel, error) {
+	r, err := uc.recipeRepo.GetRecipeByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	return recipe, nil
+	if !r.IsVisibleTo(actor) {
+		return nil, database.ErrNotFound
+	}
+
+	return r, nil
+}
+
+func (uc *usecase) UpdateRecipe(ctx context.Context, actor recipe.Actor, id string, dto recipe.UpdateRecipeDTO) (*recipe.RecipeModel, error) {
+	// the nutrition is computed from the catalogue unless an admin overrides it
+	if (dto.Nutrition != nil || dto.NutritionSource != nil || dto.RecomputeNutrition) && !actor.IsAdmin() {
+		return nil, recipe.ErrNutritionOverride
+	}
+
+	return uc.update(ctx, actor, id, dto)
+}
+
+// update applies the changes of an UpdateRecipe or a rollback.
+func (uc *usecase) update(ctx context.Context, actor recipe.Actor, id string, dto recipe.UpdateRecipeDTO) (*recipe.RecipeModel, error) {
+	_, updated, err := uc.edit(ctx, actor, id, func(ctx context.Context, previous *recipe.RecipeModel) (*recipe.RecipeModel, error) {
+		// the update is resolved on a copy, a retried transaction starts over from the request
+		dto := dto
+		if err := uc.resolveNutrition(ctx, previous, &dto); err != nil {
+			return nil, err
+		}
+
+		return uc.recipeRepo.UpdateRecipe(ctx, id, dto)
+	})
+
+	return updated, err
+}
+
+// edit applies a change to the recipe and records it as a revision in one transaction,
+// so that concurrent changes of a recipe go one after the other and each revision holds
+// the state its change was made on. The change is announced once committed.
+func (uc *usecase) edit(ctx context.Context, actor recipe.Actor, id string, change func(ctx context.Context, previous *recipe.RecipeModel) (*recipe.RecipeModel, error)) (previous, updated *recipe.RecipeModel, err error) {
+	err = uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if previous, err = uc.editableRecipe(ctx, actor, id); err != nil {
+			return err
+		}
+
+		if updated, err = change(ctx, previous); err != nil {
+			return err
+		}
+
+		if updated, err = uc.reviewAgain(ctx, actor, previous, updated); err != nil {
+			return err
+		}
+
+		return uc.recordRevision(ctx, actor.UserID, previous, updated)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := uc.eventBus.Publish(recipe.RecipeUpdated{RecipeSnapshot: recipe.NewRecipeSnapshot(updated)}); err != nil {
+		return nil, nil, err
+	}
+
+	return previous, updated, nil
+}
+
+// reviewAgain sends a published recipe changed by its author back to review, so that the
+// change is only public once an admin published it again.
+func (uc *usecase) reviewAgain(ctx context.Context, actor recipe.Actor, previous, updated *recipe.RecipeModel) (*recipe.RecipeModel, error) {
+	if !previous.NeedsReview(actor) {
+		return updated, nil
+	}
+
+	return uc.recipeRepo.SetStatus(ctx, updated.ID, recipe.StatusPendingReview)
 }
 
-func (uc *usecase) UpdateRecipe(ctx context.Context, id string, dto recipe.UpdateRecipeDTO) error {
-	if err := uc.recipeRepo.UpdateRecipe(ctx, id, dto); err != nil {
+// resolveNutrition marks manually set nutrition as overridden and recomputes it when the
+// ingredients of a recipe with computed nutrition change or the override is dropped.
+// Allergens and diet labels are inferred again from the resulting recipe.
+func (uc *usecase) resolveNutrition(ctx context.Context, previous *recipe.RecipeModel, dto *recipe.UpdateRecipeDTO) error {
+	ingredients := previous.Ingredients
+	if dto.Ingredients != nil {
+		ingredients = *dto.Ingredients
+	}
+
+	catalogue, err := uc.lookupIngredients(ctx, ingredients)
+	if err != nil {
 		return err
 	}
 
+	computed := previous.NutritionSource == recipe.NutritionComputed
+
+	switch {
+	case dto.Nutrition != nil && !dto.RecomputeNutrition:
+		if dto.NutritionSource == nil {
+			source := recipe.NutritionOverridden
+			dto.NutritionSource = &source
+		}
+	case dto.RecomputeNutrition || computed && dto.Ingredients != nil:
+		nutrition, _ := recipe.ComputeNutrition(ingredients, catalogue)
+		source := recipe.NutritionComputed
+
+		dto.Nutrition = &recipe.UpdateNutritionDTO{
+			Calories:      &nutrition.Calories,
+			Protein:       &nutrition.Protein,
+			Fat:           &nutrition.Fat,
+			Carbohydrates: &nutrition.Carbohydrates,
+			Fiber:         &nutrition.Fiber,
+			Sugar:         &nutrition.Sugar,
+			Sodium:        &nutrition.Sodium,
+		}
+		dto.NutritionSource = &source
+	}
+
+	allergens, diets := recipe.InferLabels(ingredients, catalogue, dto.Nutrition.Apply(previous.Nutrition))
+	dto.Allergens = &allergens
+	dto.DietLabels = &diets
+
 	return nil
 }
 
-func (uc *usecase) SearchRecipe(ctx context.Context, query string, tags []string, page, limit int64) ([]*recipe.RecipeModel, int, error) {
-	recipes, totakDocuments, err := uc.recipeRepo.SearchRecipe(ctx, query, tags, page, limit)
+// recordRevision stores the change as a new revision. Recipes that have no history yet
+// get a baseline revision first, so that the state before the first update can be restored.
+// It runs in the transaction of the change, which keeps the revision numbers unique.
+func (uc *usecase) recordRevision(ctx context.Context, userID string, previous, updated *recipe.RecipeModel) error {
+	latest, err := uc.revisionRepo.GetLatestRevision(ctx, updated.ID)
+	if err != nil {
+		if err != database.ErrNotFound {
+			return err
+		}
+
+		latest = &recipe.RevisionModel{
+			RecipeID:  previous.ID,
+			Number:    1,
+			Changes:   []recipe.FieldChange{},
+			Snapshot:  *previous,
+			CreatedAt: previous.UpdatedAt,
+		}
+
+		if err := uc.revisionRepo.CreateRevision(ctx, latest); err != nil {
+			return err
+		}
+	}
+
+	return uc.revisionRepo.CreateRevision(ctx, &recipe.RevisionModel{
+		RecipeID:  updated.ID,
+		Number:    latest.Number + 1,
+		AuthorID:  userID,
+		Changes:   recipe.Diff(previous, updated),
+		Snapshot:  *updated,
+		CreatedAt: updated.UpdatedAt,
+	})
+}
+
+func (uc *usecase) SearchRecipe(ctx context.Context, params recipe.SearchParams) (*recipe.SearchResult, error) {
+	params.Limit = params.PageSize()
+
+	hasQuery := strings.TrimSpace(params.Query) != ""
+	switch {
+	case params.Sort == "" && hasQuery:
+		params.Sort = recipe.SortRelevance
+	case params.Sort == "" || params.Sort == recipe.SortRelevance && !hasQuery:
+		params.Sort = recipe.SortNewest
+	}
+
+	result, err := uc.recipeRepo.SearchRecipe(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (uc *usecase) ListRecipesUsing(ctx context.Context, ingredientIDs []string, params recipe.SearchParams, limit int) ([]*recipe.RecipeModel, error) {
+	if len(ingredientIDs) == 0 {
+		return []*recipe.RecipeModel{}, nil
+	}
+
+	return uc.recipeRepo.ListRecipesUsing(ctx, ingredientIDs, params, limit)
+}
+
+func (uc *usecase) Suggest(ctx context.Context, query string, limit int) ([]recipe.SuggestionModel, error) {
+	return uc.suggestIndex.Suggest(query, limit), nil
+}
+
+func (uc *usecase) ChangeStatus(ctx context.Context, actor recipe.Actor, id string, status recipe.Status) (*recipe.RecipeModel, error) {
+	r, err := uc.recipeRepo.GetRecipeByID(ctx, id)
 	if err != nil {
+		return nil, err
+	}
+
+	if !r.IsVisibleTo(actor) {
+		return nil, database.ErrNotFound
+	}
+
+	if !r.Status.CanTransition(status) {
+		return nil, fmt.Errorf("%w: %s to %s", recipe.ErrInvalidTransition, r.Status, status)
+	}
+
+	if !r.CanChangeStatus(actor, status) {
+		return nil, recipe.ErrForbidden
+	}
+
+	updated, err := uc.recipeRepo.SetStatus(ctx, id, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.eventBus.Publish(recipe.RecipeUpdated{RecipeSnapshot: recipe.NewRecipeSnapshot(updated)}); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (uc *usecase) ListAuthorRecipes(ctx context.Context, authorID string, statuses []recipe.Status) ([]*recipe.RecipeModel, error) {
+	return uc.recipeRepo.ListRecipesByAuthor(ctx, authorID, statuses)
+}
+
+func (uc *usecase) AddStep(ctx context.Context, actor recipe.Actor, recipeID string, step *recipe.StepModel, position *int) error {
+	_, _, err := uc.edit(ctx, actor, recipeID, func(ctx context.Context, _ *recipe.RecipeModel) (*recipe.RecipeModel, error) {
+		if err := uc.recipeRepo.AddStep(ctx, recipeID, step, position); err != nil {
+			return nil, err
+		}
+
+		return uc.recipeRepo.GetRecipeByID(ctx, recipeID)
+	})
+
+	return err
+}
+
+func (uc *usecase) UpdateStep(ctx context.Context, actor recipe.Actor, recipeID, stepID string, dto recipe.UpdateStepDTO) error {
+	_, _, err := uc.edit(ctx, actor, recipeID, func(ctx context.Context, _ *recipe.RecipeModel) (*recipe.RecipeModel, error) {
+		if err := uc.recipeRepo.UpdateStep(ctx, recipeID, stepID, dto); err != nil {
+			return nil, err
+		}
+
+		return uc.recipeRepo.GetRecipeByID(ctx, recipeID)
+	})
+
+	return err
+}
+
+func (uc *usecase) DeleteStep(ctx context.Context, actor recipe.Actor, recipeID, stepID string) error {
+	previous, _, err := uc.edit(ctx, actor, recipeID, func(ctx context.Context, _ *recipe.RecipeModel) (*recipe.RecipeModel, error) {
+		if err := uc.recipeRepo.DeleteStep(ctx, recipeID, stepID); err != nil {
+			return nil, err
+		}
+
+		return uc.recipeRepo.GetRecipeByID(ctx, recipeID)
+	})
+	if err != nil {
+		return err
+	}
+
+	// the files go once the step is gone for good
+	images, _ := previous.StepImages(stepID)
+	for _, image := range images {
+		_ = uc.imageUC.DeleteImage(ctx, image)
+	}
+
+	return nil
+}
+
+// imagesChanged announces a change of the pictures. The image updates do not return the
+// recipe, so it is read again.
+func (uc *usecase) imagesChanged(ctx context.Context, actor recipe.Actor, previous *recipe.RecipeModel) error {
+	updated, err := uc.recipeRepo.GetRecipeByID(ctx, previous.ID)
+	if err != nil {
+		return err
+	}
+
+	if updated, err = uc.reviewAgain(ctx, actor, previous, updated); err != nil {
+		return err
+	}
+
+	return uc.eventBus.Publish(recipe.RecipeUpdated{RecipeSnapshot: recipe.NewRecipeSnapshot(updated)})
+}
+
+func (uc *usecase) AddImage(ctx context.Context, actor recipe.Actor, recipeID, stepID string, data []byte) (*media.ImageModel, error) {
+	r, err := uc.editableRecipe(ctx, actor, recipeID)
+	if err != nil {
+		return nil, err
+	}
+
+	images, ok := r.StepImages(stepID)
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+
+	if len(images) >= recipe.MaxImages {
+		return nil, recipe.ErrTooManyImages
+	}
+
+	image, err := uc.imageUC.UploadImage(ctx, recipe.ImagePrefix(recipeID, stepID), data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.recipeRepo.AddImage(ctx, recipeID, stepID, *image); err != nil {
+		_ = uc.imageUC.DeleteImage(ctx, *image)
+		return nil, err
+	}
+
+	if err := uc.imagesChanged(ctx, actor, r); err != nil {
+		return nil, err
+	}
+
+	return image, nil
+}
+
+func (uc *usecase) DeleteImage(ctx context.Context, actor recipe.Actor, recipeID, stepID, imageID string) error {
+	r, err := uc.editableRecipe(ctx, actor, recipeID)
+	if err != nil {
+		return err
+	}
+
+	images, _ := r.StepImages(stepID)
+	i := slices.IndexFunc(images, func(image media.ImageModel) bool { return image.ID == imageID })
+	if i < 0 {
+		return database.ErrNotFound
+	}
+
+	if err := uc.recipeRepo.RemoveImage(ctx, recipeID, stepID, imageID); err != nil {
+		return err
+	}
+
+	if err := uc.imageUC.DeleteImage(ctx, images[i]); err != nil {
+		return err
+	}
+
+	return uc.imagesChanged(ctx, actor, r)
+}
+
+func (uc *usecase) GetCookingMode(ctx context.Context, actor recipe.Actor, recipeID string, opts recipe.ViewOptions) (*recipe.CookingModeModel, error) {
+	r, err := uc.GetRecipeByID(ctx, actor, recipeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.Catalogue) == 0 {
+		if opts.Catalogue, err = uc.IngredientCatalogue(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.View(opts).CookingMode(), nil
+}
+
+func (uc *usecase) IngredientCatalogue(ctx context.Context, recipes ...*recipe.RecipeModel) (map[string]*ingredient.IngredientModel, error) {
+	catalogue := map[string]*ingredient.IngredientModel{}
+
+	for _, r := range recipes {
+		for _, line := range r.Ingredients {
+			if _, ok := catalogue[line.IngredientID]; ok || line.IngredientID == "" {
+				continue
+			}
+
+			entry, err := uc.ingredientRepo.GetIngredientByID(ctx, line.IngredientID)
+			if errors.Is(err, database.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			catalogue[entry.ID] = entry
+		}
+	}
+
+	return catalogue, nil
+}
+
+func (uc *usecase) ListRevisions(ctx context.Context, recipeID string) ([]*recipe.RevisionModel, error) {
+	return uc.revisionRepo.ListRevisions(ctx, recipeID)
+}
+
+func (uc *usecase) GetRevision(ctx context.Context, recipeID string, number int) (*recipe.RevisionModel, error) {
+	return uc.revisionRepo.GetRevision(ctx, recipeID, number)
+}
+
+func (uc *usecase) DiffRevisions(ctx context.Context, recipeID string, from, to int) ([]recipe.FieldChange, error) {
+	fromRevision, err := uc.revisionRepo.GetRevision(ctx, recipeID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toRevision, err := uc.revisionRepo.GetRevision(ctx, recipeID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return recipe.Diff(&fromRevision.Snapshot, &toRevision.Snapshot), nil
+}
+
+func (uc *usecase) RollbackRecipe(ctx context.Context, actor recipe.Actor, recipeID string, number int) (*recipe.RecipeModel, error) {
+	revision, err := uc.revisionRepo.GetRevision(ctx, recipeID, number)
+	if err != nil {
+		return nil, err
+	}
+
+	// the snapshot carries the nutrition of the revision, rollbacks are left to admins
+	return uc.update(ctx, actor, recipeID, revision.Snapshot.ToUpdateDTO())
+}
+
+func (uc *usecase) RateRecipe(ctx context.Context, actor recipe.Actor, recipeID string, stars int, review string) (*recipe.RatingModel, error) {
+	r, err := uc.GetRecipeByID(ctx, actor, recipeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.AuthorID == actor.UserID {
+		return nil, recipe.ErrOwnRecipe
+	}
+
+	now := time.Now()
+	rating := &recipe.RatingModel{
+		RecipeID:  recipeID,
+		UserID:    actor.UserID,
+		Stars:     stars,
+		Review:    review,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err = uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.ratingRepo.SaveRating(ctx, rating); err != nil {
+			return err
+		}
+
+		return uc.recipeRepo.RefreshRating(ctx, recipeID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.eventBus.Publish(recipe.RecipeRated{RecipeID: recipeID, UserID: actor.UserID, Stars: stars}); err != nil {
+		return nil, err
+	}
+
+	return rating, nil
+}
+
+func (uc *usecase) GetRating(ctx context.Context, actor recipe.Actor, recipeID string) (*recipe.RatingModel, error) {
+	if _, err := uc.GetRecipeByID(ctx, actor, recipeID); err != nil {
+		return nil, err
+	}
+
+	return uc.ratingRepo.GetRating(ctx, recipeID, actor.UserID)
+}
+
+func (uc *usecase) ListRatings(ctx context.Context, actor recipe.Actor, recipeID string, page, limit int64) ([]*recipe.RatingModel, int64, error) {
+	if _, err := uc.GetRecipeByID(ctx, actor, recipeID); err != nil {
 		return nil, 0, err
 	}
 
-	return recipes, totakDocuments, nil
+	return uc.ratingRepo.ListRatings(ctx, recipeID, page, limit)
+}
+
+func (uc *usecase) DeleteRating(ctx context.Context, actor recipe.Actor, recipeID string) error {
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.ratingRepo.DeleteRating(ctx, recipeID, actor.UserID); err != nil {
+			return err
+		}
+
+		return uc.recipeRepo.RefreshRating(ctx, recipeID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return uc.eventBus.Publish(recipe.RecipeUnrated{RecipeID: recipeID, UserID: actor.UserID})
 }
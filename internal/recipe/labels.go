@@ -0,0 +1,86 @@
+package recipe
+
+import (
+	"flove/job/internal/ingredient"
+	"slices"
+)
+
+const (
+	DietVegan       = "vegan"
+	DietVegetarian  = "vegetarian"
+	DietPescatarian = "pescatarian"
+	DietKeto        = "keto"
+	DietGlutenFree  = "gluten-free"
+	DietDairyFree   = "dairy-free"
+)
+
+// ketoCarbShare is the highest share of calories that may come from net carbohydrates
+// for a recipe to be labelled keto.
+const ketoCarbShare = 0.1
+
+// InferLabels derives the allergens of a recipe from its ingredients and the diets it
+// fits. Keto is judged on the recipe nutrition, so manual overrides are respected.
+func InferLabels(lines []IngredientModel, catalogue map[string]*ingredient.IngredientModel, nutrition NutritionInfo) (allergens []string, diets []string) {
+	allergens = []string{}
+	origins := map[string]bool{}
+
+	for _, line := range lines {
+		entry, ok := catalogue[line.IngredientID]
+		if !ok {
+			continue
+		}
+
+		origins[entry.Origin] = true
+		for _, allergen := range entry.Allergens {
+			if !slices.Contains(allergens, allergen) {
+				allergens = append(allergens, allergen)
+			}
+		}
+	}
+
+	slices.Sort(allergens)
+
+	diets = []string{}
+	meat, fish := origins[ingredient.OriginMeat], origins[ingredient.OriginFish]
+	animal := meat || fish || origins[ingredient.OriginDairy] || origins[ingredient.OriginEgg] || origins[ingredient.OriginHoney]
+
+	if !animal {
+		diets = append(diets, DietVegan)
+	}
+	if !meat && !fish {
+		diets = append(diets, DietVegetarian)
+	}
+	if !meat {
+		diets = append(diets, DietPescatarian)
+	}
+	if nutrition.Calories > 0 && (nutrition.Carbohydrates-nutrition.Fiber)*4 <= nutrition.Calories*ketoCarbShare {
+		diets = append(diets, DietKeto)
+	}
+	if !slices.Contains(allergens, ingredient.AllergenGluten) {
+		diets = append(diets, DietGlutenFree)
+	}
+	if !slices.Contains(allergens, ingredient.AllergenDairy) {
+		diets = append(diets, DietDairyFree)
+	}
+
+	return allergens, diets
+}
+
+// FitsDiet tells whether an ingredient may appear in a recipe of the diet, following the
+// same rules as InferLabels. Keto depends on the whole recipe and accepts any ingredient.
+func FitsDiet(entry *ingredient.IngredientModel, diet string) bool {
+	switch diet {
+	case DietVegan:
+		return !slices.Contains([]string{ingredient.OriginMeat, ingredient.OriginFish, ingredient.OriginDairy, ingredient.OriginEgg, ingredient.OriginHoney}, entry.Origin)
+	case DietVegetarian:
+		return entry.Origin != ingredient.OriginMeat && entry.Origin != ingredient.OriginFish
+	case DietPescatarian:
+		return entry.Origin != ingredient.OriginMeat
+	case DietGlutenFree:
+		return !slices.Contains(entry.Allergens, ingredient.AllergenGluten)
+	case DietDairyFree:
+		return !slices.Contains(entry.Allergens, ingredient.AllergenDairy)
+	default:
+		return true
+	}
+}
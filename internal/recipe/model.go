@@ -1,19 +1,97 @@
 package recipe
 
 import (
+	"flove/job/internal/media"
 	"time"
 )
 
 type RecipeModel struct {
 	ID          string
+	AuthorID    string
+	Status      Status
 	Name        string
 	Description string
 	Category    string
 	Tags        []string
+	Ingredients []IngredientModel
+	Steps       []StepModel
+	Images      []media.ImageModel
 	Nutrition   NutritionInfo
-	Servings    int
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// NutritionSource tells whether Nutrition was computed from the ingredients
+	// or typed in by an admin, NutritionPerServing is derived from the totals.
+	NutritionSource     string
+	NutritionPerServing NutritionInfo
+	CookTime            int
+	Servings            int
+	// Allergens and DietLabels are inferred from the ingredients on every write.
+	Allergens  []string
+	DietLabels []string
+	Likes      int
+	Views      int
+	Rating     RatingSummary
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// IngredientModel is a single line of the recipe ingredient list.
+// IngredientID references the canonical ingredient, Name is kept for display.
+type IngredientModel struct {
+	IngredientID string
+	Name         string
+	Quantity     float64
+	Unit         string
+	Note         string
+}
+
+// StepModel is a single preparation step. A zero Duration means the step has no timer,
+// IngredientIDs reference entries of the recipe ingredient list.
+type StepModel struct {
+	ID            string
+	Text          string
+	Duration      time.Duration
+	Temperature   *Temperature
+	IngredientIDs []string
+	Images        []media.ImageModel
+}
+
+type Temperature struct {
+	Value float64
+	Unit  string
+}
+
+// CookingModeModel is the recipe steps laid out on a timeline for step-by-step cooking.
+type CookingModeModel struct {
+	RecipeID  string
+	Steps     []CookingStepModel
+	TotalTime time.Duration
+}
+
+type CookingStepModel struct {
+	Number   int
+	Step     StepModel
+	StartsAt time.Duration
+	EndsAt   time.Duration
+}
+
+func (r *RecipeModel) CookingMode() *CookingModeModel {
+	mode := &CookingModeModel{
+		RecipeID: r.ID,
+		Steps:    make([]CookingStepModel, len(r.Steps)),
+	}
+
+	var elapsed time.Duration
+	for i, step := range r.Steps {
+		mode.Steps[i] = CookingStepModel{
+			Number:   i + 1,
+			Step:     step,
+			StartsAt: elapsed,
+			EndsAt:   elapsed + step.Duration,
+		}
+		elapsed += step.Duration
+	}
+
+	mode.TotalTime = elapsed
+	return mode
 }
 
 type NutritionInfo struct {
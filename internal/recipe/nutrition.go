@@ -0,0 +1,97 @@
+package recipe
+
+import (
+	"flove/job/internal/ingredient"
+	"flove/job/pkg/units"
+)
+
+const (
+	NutritionComputed   = "computed"
+	NutritionOverridden = "overridden"
+)
+
+// ComputeNutrition sums the nutrition of an ingredient list from the catalogue values
+// per 100 g. Lines that cannot be weighed, because their unit is unknown or a counted
+// ingredient has no piece weight, are left out and returned as skipped.
+func ComputeNutrition(lines []IngredientModel, catalogue map[string]*ingredient.IngredientModel) (NutritionInfo, []IngredientModel) {
+	var (
+		total   NutritionInfo
+		skipped []IngredientModel
+	)
+
+	for _, line := range lines {
+		entry, ok := catalogue[line.IngredientID]
+		if !ok {
+			skipped = append(skipped, line)
+			continue
+		}
+
+		grams, ok := IngredientWeight(line, entry)
+		if !ok {
+			skipped = append(skipped, line)
+			continue
+		}
+
+		total = total.Add(NutritionInfo(entry.Nutrition).multiply(grams / 100))
+	}
+
+	return total.round(), skipped
+}
+
+// Density returns the density in g/ml of an ingredient line. The catalogue entry has the
+// final say, the common densities by name only stand in for ingredients it does not know.
+func Density(line IngredientModel, entry *ingredient.IngredientModel) float64 {
+	if entry != nil && entry.Density > 0 {
+		return entry.Density
+	}
+
+	return units.DensityOf(line.Name)
+}
+
+// IngredientWeight converts an ingredient line to grams, using its density for volumes
+// (water-like when unknown) and the piece weight for counted ingredients.
+func IngredientWeight(line IngredientModel, entry *ingredient.IngredientModel) (float64, bool) {
+	unit, ok := units.Lookup(line.Unit)
+	if !ok {
+		return 0, false
+	}
+
+	switch unit.Dimension {
+	case units.Mass:
+		return line.Quantity * unit.Factor, true
+	case units.Volume:
+		density := Density(line, entry)
+		if density == 0 {
+			density = 1
+		}
+		return line.Quantity * unit.Factor * density, true
+	case units.Count:
+		if entry.PieceWeight == 0 {
+			return 0, false
+		}
+		return line.Quantity * entry.PieceWeight, true
+	default:
+		return 0, false
+	}
+}
+
+func (n NutritionInfo) Add(other NutritionInfo) NutritionInfo {
+	return NutritionInfo{
+		Calories:      n.Calories + other.Calories,
+		Protein:       n.Protein + other.Protein,
+		Fat:           n.Fat + other.Fat,
+		Carbohydrates: n.Carbohydrates + other.Carbohydrates,
+		Fiber:         n.Fiber + other.Fiber,
+		Sugar:         n.Sugar + other.Sugar,
+		Sodium:        n.Sodium + other.Sodium,
+	}
+}
+
+// PerServing divides the recipe totals between the servings.
+func (n NutritionInfo) PerServing(servings int) NutritionInfo {
+	if servings <= 0 {
+		return n
+	}
+
+	return n.Scale(1 / float64(servings))
+}
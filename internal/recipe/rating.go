@@ -0,0 +1,28 @@
+package recipe
+
+import "time"
+
+const (
+	MinStars = 1
+	MaxStars = 5
+)
+
+// RatingModel is the rating a user gave a recipe, Review is optional. A user rates a
+// recipe at most once, rating it again replaces the previous rating.
+type RatingModel struct {
+	ID        string
+	RecipeID  string
+	UserID    string
+	Stars     int
+	Review    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// RatingSummary aggregates the ratings of a recipe. Histogram[i] counts the ratings
+// of i+1 stars.
+type RatingSummary struct {
+	Average   float64
+	Count     int
+	Histogram [MaxStars]int
+}
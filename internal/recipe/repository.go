@@ -2,13 +2,49 @@ package recipe
 
 import (
 	"context"
+	"flove/job/internal/media"
 )
 
 type RecipeRepository interface {
 	CreateRecipe(ctx context.Context, recipe *RecipeModel) error
 	GetRecipeByID(ctx context.Context, id string) (*RecipeModel, error)
-	UpdateRecipe(ctx context.Context, id string, update UpdateRecipeDTO) error
+	UpdateRecipe(ctx context.Context, id string, update UpdateRecipeDTO) (*RecipeModel, error)
 	DeleteRecipe(ctx context.Context, id string) error
+	ListRecipes(ctx context.Context) ([]*RecipeModel, error)
+	ListRecipesByAuthor(ctx context.Context, authorID string, statuses []Status) ([]*RecipeModel, error)
+	SetStatus(ctx context.Context, id string, status Status) (*RecipeModel, error)
+	// RefreshRating recomputes the rating summary of the recipe from its ratings. It is
+	// meant to run in the transaction that changed them, so that it cannot drift from them.
+	RefreshRating(ctx context.Context, id string) error
 
-	SearchRecipe(ctx context.Context, query string, tags []string, page, limit int64) ([]*RecipeModel, int, error)
+	AddStep(ctx context.Context, recipeID string, step *StepModel, position *int) error
+	UpdateStep(ctx context.Context, recipeID, stepID string, update UpdateStepDTO) error
+	DeleteStep(ctx context.Context, recipeID, stepID string) error
+
+	// AddImage appends a picture to the recipe, or to one of its steps when stepID is set.
+	AddImage(ctx context.Context, recipeID, stepID string, image media.ImageModel) error
+	RemoveImage(ctx context.Context, recipeID, stepID, imageID string) error
+
+	SearchRecipe(ctx context.Context, params SearchParams) (*SearchResult, error)
+	// ListRecipesUsing lists the published recipes matching the search filters that use at
+	// least one of the ingredients, those using the most of them first.
+	ListRecipesUsing(ctx context.Context, ingredientIDs []string, params SearchParams, limit int) ([]*RecipeModel, error)
+}
+
+type RatingRepository interface {
+	// SaveRating creates or replaces the rating of the user and fills the rating with the
+	// stored one, which keeps the ID and creation time of a replaced rating.
+	SaveRating(ctx context.Context, rating *RatingModel) error
+	GetRating(ctx context.Context, recipeID, userID string) (*RatingModel, error)
+	ListRatings(ctx context.Context, recipeID string, page, limit int64) ([]*RatingModel, int64, error)
+	DeleteRating(ctx context.Context, recipeID, userID string) (*RatingModel, error)
+	DeleteRecipeRatings(ctx context.Context, recipeID string) error
+}
+
+type RevisionRepository interface {
+	CreateRevision(ctx context.Context, revision *RevisionModel) error
+	GetRevision(ctx context.Context, recipeID string, number int) (*RevisionModel, error)
+	GetLatestRevision(ctx context.Context, recipeID string) (*RevisionModel, error)
+	ListRevisions(ctx context.Context, recipeID string) ([]*RevisionModel, error)
+	DeleteRevisions(ctx context.Context, recipeID string) error
 }
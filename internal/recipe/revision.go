@@ -0,0 +1,117 @@
+package recipe
+
+import (
+	"reflect"
+	"time"
+)
+
+// RevisionModel is an immutable record of a single recipe change. Snapshot holds the
+// recipe as it was right after the change, Changes the difference to the previous revision.
+type RevisionModel struct {
+	ID        string
+	RecipeID  string
+	Number    int
+	AuthorID  string
+	Changes   []FieldChange
+	Snapshot  RecipeModel
+	CreatedAt time.Time
+}
+
+type FieldChange struct {
+	Field string
+	Old   any
+	New   any
+}
+
+const (
+	FieldName          = "name"
+	FieldDescription   = "description"
+	FieldCategory      = "category"
+	FieldTags          = "tags"
+	FieldIngredients   = "ingredients"
+	FieldSteps         = "steps"
+	FieldCookTime      = "cook_time"
+	FieldServings      = "servings"
+	FieldCalories      = "nutrition.calories"
+	FieldProtein       = "nutrition.protein"
+	FieldFat           = "nutrition.fat"
+	FieldCarbohydrates = "nutrition.carbohydrates"
+	FieldFiber         = "nutrition.fiber"
+	FieldSugar         = "nutrition.sugar"
+	FieldSodium        = "nutrition.sodium"
+
+	FieldNutritionSource = "nutrition_source"
+)
+
+func fieldValues(r *RecipeModel) []FieldChange {
+	return []FieldChange{
+		{Field: FieldName, New: r.Name},
+		{Field: FieldDescription, New: r.Description},
+		{Field: FieldCategory, New: r.Category},
+		{Field: FieldTags, New: r.Tags},
+		{Field: FieldIngredients, New: r.Ingredients},
+		{Field: FieldSteps, New: r.Steps},
+		{Field: FieldCookTime, New: r.CookTime},
+		{Field: FieldServings, New: r.Servings},
+		{Field: FieldCalories, New: r.Nutrition.Calories},
+		{Field: FieldProtein, New: r.Nutrition.Protein},
+		{Field: FieldFat, New: r.Nutrition.Fat},
+		{Field: FieldCarbohydrates, New: r.Nutrition.Carbohydrates},
+		{Field: FieldFiber, New: r.Nutrition.Fiber},
+		{Field: FieldSugar, New: r.Nutrition.Sugar},
+		{Field: FieldSodium, New: r.Nutrition.Sodium},
+		{Field: FieldNutritionSource, New: r.NutritionSource},
+	}
+}
+
+// Diff returns the fields that differ between two versions of a recipe.
+func Diff(from, to *RecipeModel) []FieldChange {
+	oldValues := fieldValues(from)
+	newValues := fieldValues(to)
+
+	changes := []FieldChange{}
+	for i := range newValues {
+		if isEmpty(oldValues[i].New) && isEmpty(newValues[i].New) {
+			continue
+		}
+
+		if !reflect.DeepEqual(oldValues[i].New, newValues[i].New) {
+			changes = append(changes, FieldChange{
+				Field: newValues[i].Field,
+				Old:   oldValues[i].New,
+				New:   newValues[i].New,
+			})
+		}
+	}
+
+	return changes
+}
+
+func isEmpty(value any) bool {
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Slice && v.Len() == 0
+}
+
+// ToUpdateDTO builds an update that restores every field of the snapshot.
+func (r *RecipeModel) ToUpdateDTO() UpdateRecipeDTO {
+	return UpdateRecipeDTO{
+		Name:        &r.Name,
+		Description: &r.Description,
+		Category:    &r.Category,
+		Tags:        &r.Tags,
+		Ingredients: &r.Ingredients,
+		Steps:       &r.Steps,
+		Nutrition: &UpdateNutritionDTO{
+			Calories:      &r.Nutrition.Calories,
+			Protein:       &r.Nutrition.Protein,
+			Fat:           &r.Nutrition.Fat,
+			Carbohydrates: &r.Nutrition.Carbohydrates,
+			Fiber:         &r.Nutrition.Fiber,
+			Sugar:         &r.Nutrition.Sugar,
+			Sodium:        &r.Nutrition.Sodium,
+		},
+		CookTime:        &r.CookTime,
+		Servings:        &r.Servings,
+		NutritionSource: &r.NutritionSource,
+	}
+}
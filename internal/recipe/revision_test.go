@@ -0,0 +1,110 @@
+package recipe
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	base := RecipeModel{
+		Name:        "Pancakes",
+		Tags:        []string{"breakfast"},
+		Ingredients: []IngredientModel{{IngredientID: "flour", Name: "flour", Quantity: 200, Unit: "g"}},
+		Servings:    4,
+		Nutrition:   NutritionInfo{Calories: 800},
+	}
+
+	tests := []struct {
+		name   string
+		change func(r *RecipeModel)
+		want   []FieldChange
+	}{
+		{
+			name:   "no change",
+			change: func(r *RecipeModel) {},
+			want:   []FieldChange{},
+		},
+		{
+			name:   "scalar fields",
+			change: func(r *RecipeModel) { r.Name = "Crêpes"; r.Servings = 2 },
+			want: []FieldChange{
+				{Field: FieldName, Old: "Pancakes", New: "Crêpes"},
+				{Field: FieldServings, Old: 4, New: 2},
+			},
+		},
+		{
+			name:   "nutrition values",
+			change: func(r *RecipeModel) { r.Nutrition.Calories = 900 },
+			want:   []FieldChange{{Field: FieldCalories, Old: 800.0, New: 900.0}},
+		},
+		{
+			name: "ingredient lists",
+			change: func(r *RecipeModel) {
+				r.Ingredients = []IngredientModel{{IngredientID: "flour", Name: "flour", Quantity: 250, Unit: "g"}}
+			},
+			want: []FieldChange{{
+				Field: FieldIngredients,
+				Old:   []IngredientModel{{IngredientID: "flour", Name: "flour", Quantity: 200, Unit: "g"}},
+				New:   []IngredientModel{{IngredientID: "flour", Name: "flour", Quantity: 250, Unit: "g"}},
+			}},
+		},
+		{
+			name:   "nil and empty lists are equal",
+			change: func(r *RecipeModel) { r.Steps = []StepModel{} },
+			want:   []FieldChange{},
+		},
+		{
+			name:   "cleared tags",
+			change: func(r *RecipeModel) { r.Tags = nil },
+			want:   []FieldChange{{Field: FieldTags, Old: []string{"breakfast"}, New: []string(nil)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from := base
+			to := base
+			to.Tags = append([]string(nil), base.Tags...)
+			to.Ingredients = append([]IngredientModel(nil), base.Ingredients...)
+			tt.change(&to)
+
+			if got := Diff(&from, &to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToUpdateDTORestoresSnapshot(t *testing.T) {
+	snapshot := &RecipeModel{
+		Name:            "Pancakes",
+		Description:     "Fluffy",
+		Category:        "breakfast",
+		Tags:            []string{"sweet"},
+		Ingredients:     []IngredientModel{{IngredientID: "egg", Name: "egg", Quantity: 2, Unit: "pc"}},
+		Steps:           []StepModel{{ID: "1", Text: "Whisk"}},
+		CookTime:        20,
+		Servings:        4,
+		Nutrition:       NutritionInfo{Calories: 800, Protein: 20},
+		NutritionSource: "manual",
+	}
+
+	dto := snapshot.ToUpdateDTO()
+
+	restored := &RecipeModel{
+		Name:            *dto.Name,
+		Description:     *dto.Description,
+		Category:        *dto.Category,
+		Tags:            *dto.Tags,
+		Ingredients:     *dto.Ingredients,
+		Steps:           *dto.Steps,
+		CookTime:        *dto.CookTime,
+		Servings:        *dto.Servings,
+		Nutrition:       dto.Nutrition.Apply(NutritionInfo{}),
+		NutritionSource: *dto.NutritionSource,
+	}
+
+	if changes := Diff(snapshot, restored); len(changes) != 0 {
+		t.Errorf("restoring the snapshot left changes: %+v", changes)
+	}
+}
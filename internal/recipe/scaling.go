@@ -0,0 +1,132 @@
+package recipe
+
+import (
+	"flove/job/internal/ingredient"
+	"flove/job/pkg/units"
+	"math"
+)
+
+// Scale returns a copy of the recipe adjusted to the given number of servings.
+// Ingredient quantities and nutrition totals are multiplied by the same factor,
+// then rounded to values that make sense in a kitchen.
+func (r *RecipeModel) Scale(servings int) *RecipeModel {
+	scaled := *r
+	if servings <= 0 || r.Servings <= 0 || servings == r.Servings {
+		return &scaled
+	}
+
+	factor := float64(servings) / float64(r.Servings)
+
+	scaled.Servings = servings
+	scaled.Ingredients = make([]IngredientModel, len(r.Ingredients))
+	for i, ingredient := range r.Ingredients {
+		ingredient.Quantity = ScaleQuantity(ingredient.Quantity, factor, ingredient.Unit)
+		scaled.Ingredients[i] = ingredient
+	}
+
+	scaled.Nutrition = r.Nutrition.Scale(factor)
+
+	return &scaled
+}
+
+func (n NutritionInfo) Scale(factor float64) NutritionInfo {
+	return n.multiply(factor).round()
+}
+
+func (n NutritionInfo) multiply(factor float64) NutritionInfo {
+	return NutritionInfo{
+		Calories:      n.Calories * factor,
+		Protein:       n.Protein * factor,
+		Fat:           n.Fat * factor,
+		Carbohydrates: n.Carbohydrates * factor,
+		Fiber:         n.Fiber * factor,
+		Sugar:         n.Sugar * factor,
+		Sodium:        n.Sodium * factor,
+	}
+}
+
+func (n NutritionInfo) round() NutritionInfo {
+	return NutritionInfo{
+		Calories:      roundTo(n.Calories, 0.1),
+		Protein:       roundTo(n.Protein, 0.1),
+		Fat:           roundTo(n.Fat, 0.1),
+		Carbohydrates: roundTo(n.Carbohydrates, 0.1),
+		Fiber:         roundTo(n.Fiber, 0.1),
+		Sugar:         roundTo(n.Sugar, 0.1),
+		Sodium:        roundTo(n.Sodium, 0.1),
+	}
+}
+
+// ScaleQuantity multiplies a quantity and rounds the result according to its unit.
+func ScaleQuantity(quantity, factor float64, unit string) float64 {
+	if quantity <= 0 {
+		return quantity
+	}
+
+	return RoundQuantity(quantity*factor, unit)
+}
+
+// RoundQuantity rounds a quantity to a step that fits the unit: whole or half pieces
+// for countable ingredients, quarters for spoons and cups, whole grams and millilitres.
+func RoundQuantity(quantity float64, unit string) float64 {
+	u, ok := units.Lookup(unit)
+
+	switch {
+	case ok && u.Fractional:
+		return math.Max(roundTo(quantity, 0.25), 0.125)
+	case ok && u.Dimension == units.Count:
+		if quantity < 2 {
+			return math.Max(roundTo(quantity, 0.5), 0.5)
+		}
+		return roundTo(quantity, 1)
+	case quantity >= 10:
+		return roundTo(quantity, 1)
+	case quantity >= 1:
+		return roundTo(quantity, 0.1)
+	default:
+		return math.Max(roundTo(quantity, 0.01), 0.01)
+	}
+}
+
+func roundTo(value, step float64) float64 {
+	inverse := 1 / step
+	return math.Round(value*inverse) / inverse
+}
+
+// ConvertUnits returns a copy of the recipe with ingredient quantities and step
+// temperatures expressed in the given unit system. Densities come from the catalogue
+// entries of the ingredients, as for the nutrition.
+func (r *RecipeModel) ConvertUnits(system units.System, catalogue map[string]*ingredient.IngredientModel) *RecipeModel {
+	converted := *r
+	if system == "" {
+		return &converted
+	}
+
+	converted.Ingredients = make([]IngredientModel, len(r.Ingredients))
+	for i, ingredient := range r.Ingredients {
+		quantity, unit := units.ToSystem(ingredient.Quantity, ingredient.Unit, system, Density(ingredient, catalogue[ingredient.IngredientID]))
+		if unit != ingredient.Unit {
+			ingredient.Quantity = RoundQuantity(quantity, unit)
+			ingredient.Unit = unit
+		}
+		converted.Ingredients[i] = ingredient
+	}
+
+	converted.Steps = make([]StepModel, len(r.Steps))
+	for i, step := range r.Steps {
+		if step.Temperature != nil {
+			value, unit := units.ToSystem(step.Temperature.Value, step.Temperature.Unit, system, 0)
+			if unit != step.Temperature.Unit {
+				step.Temperature = &Temperature{Value: math.Round(value), Unit: unit}
+			}
+		}
+		converted.Steps[i] = step
+	}
+
+	return &converted
+}
+
+// View applies the reader's presentation options to the recipe.
+func (r *RecipeModel) View(opts ViewOptions) *RecipeModel {
+	return r.Scale(opts.Servings).ConvertUnits(opts.UnitSystem, opts.Catalogue)
+}
@@ -0,0 +1,149 @@
+package recipe
+
+import "context"
+
+// TagMatch tells whether a recipe has to carry any or all of the requested tags.
+type TagMatch string
+
+const (
+	TagMatchAny TagMatch = "any"
+	TagMatchAll TagMatch = "all"
+)
+
+// Nutrients that can be filtered on, the values are per serving.
+const (
+	NutrientCalories      = "calories"
+	NutrientProtein       = "protein"
+	NutrientFat           = "fat"
+	NutrientCarbohydrates = "carbohydrates"
+	NutrientFiber         = "fiber"
+	NutrientSugar         = "sugar"
+	NutrientSodium        = "sodium"
+)
+
+// SortOrder orders search results. Relevance only applies to text queries, other
+// searches fall back to the newest recipes first.
+type SortOrder string
+
+const (
+	SortNewest         SortOrder = "newest"
+	SortMostViewed     SortOrder = "most_viewed"
+	SortMostLiked      SortOrder = "most_liked"
+	SortHighestRated   SortOrder = "highest_rated"
+	SortFewestCalories SortOrder = "fewest_calories"
+	SortRelevance      SortOrder = "relevance"
+)
+
+// Page sizes of a search, larger limits are capped rather than rejected.
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+// Range bounds a numeric facet, a nil bound is open.
+type Range struct {
+	Min *float64
+	Max *float64
+}
+
+func (r Range) IsZero() bool {
+	return r.Min == nil && r.Max == nil
+}
+
+// SearchResult is a page of recipes together with the facet counts of the whole match.
+// Highlights holds the matched fragments per recipe ID and per field for text queries.
+// NextCursor continues the search after the last recipe, it is empty on the last page.
+// Truncated tells that the text query matched more than MaxSearchHits recipes, so the
+// total and the facets only count the best ranked of them.
+type SearchResult struct {
+	Recipes    []*RecipeModel
+	NextCursor string
+	Total      int
+	Truncated  bool
+	Facets     Facets
+	Highlights map[string]map[string][]string
+}
+
+// Fields of a recipe searched by text.
+const (
+	SearchFieldName        = "name"
+	SearchFieldDescription = "description"
+	SearchFieldIngredients = "ingredients"
+	SearchFieldSteps       = "steps"
+)
+
+// MaxSearchHits caps the number of published recipes a text query can match before
+// the profile and facet filters apply.
+const MaxSearchHits = 1000
+
+// SearchHit is a recipe matching a text query, best hits have the highest score.
+type SearchHit struct {
+	RecipeID   string
+	Score      float64
+	Highlights map[string][]string
+}
+
+// SearchIndex ranks published recipes for text queries. Implementations that keep their own copy of
+// the recipes are fed through IndexRecipe and RemoveRecipe, others may ignore those calls.
+type SearchIndex interface {
+	IndexRecipe(ctx context.Context, r *RecipeModel) error
+	RemoveRecipe(ctx context.Context, id string) error
+	Search(ctx context.Context, query string, limit int) ([]SearchHit, error)
+}
+
+// Facets count the recipes matching a search by value or by range, so that a client can
+// show how many recipes remain after narrowing the search down.
+type Facets struct {
+	Categories []FacetCount
+	Tags       []FacetCount
+	DietLabels []FacetCount
+	Servings   []FacetBucket
+	CookTime   []FacetBucket
+	Calories   []FacetBucket
+}
+
+type FacetCount struct {
+	Value string
+	Count int
+}
+
+// FacetBucket counts the recipes with Min <= value < Max, the last bucket has no Max.
+type FacetBucket struct {
+	Min   float64
+	Max   *float64
+	Count int
+}
+
+// Bucket boundaries of the numeric facets. Calories are per serving, cook time in minutes.
+var (
+	ServingsBuckets = []float64{0, 3, 5, 7}
+	CookTimeBuckets = []float64{0, 15, 30, 60, 120}
+	CaloriesBuckets = []float64{0, 200, 400, 600, 800}
+)
+
+// Kinds of autocomplete suggestions.
+const (
+	SuggestionRecipe     = "recipe"
+	SuggestionTag        = "tag"
+	SuggestionIngredient = "ingredient"
+)
+
+// SuggestionModel is a recipe name, tag or ingredient matching what the user has typed.
+// RecipeIDs lists the recipes carrying it, Score is their summed popularity.
+type SuggestionModel struct {
+	Text      string
+	Kind      string
+	RecipeIDs []string
+	Score     float64
+}
+
+// SuggestIndex answers autocomplete queries. It is kept in memory and follows the
+// recipe events, so it may briefly lag behind the database.
+type SuggestIndex interface {
+	Suggest(query string, limit int) []SuggestionModel
+}
+
+// Popularity weighs likes over views when ranking suggestions.
+func (r *RecipeModel) Popularity() float64 {
+	return 1 + float64(r.Views) + 5*float64(r.Likes)
+}
@@ -0,0 +1,67 @@
+package recipe
+
+import (
+	"flove/job/internal/user"
+	"slices"
+)
+
+// Status is the stage of the publishing workflow a recipe is in. Only published recipes
+// are searchable and part of the recommendation graph.
+type Status string
+
+const (
+	StatusDraft         Status = "draft"
+	StatusPendingReview Status = "pending_review"
+	StatusPublished     Status = "published"
+	StatusArchived      Status = "archived"
+)
+
+// transitions lists the statuses a recipe can move to from each status. A pending recipe
+// is either published by a reviewer or sent back to draft. Besides these, a published
+// recipe changed by its author goes back to pending review on its own.
+var transitions = map[Status][]Status{
+	StatusDraft:         {StatusPendingReview},
+	StatusPendingReview: {StatusPublished, StatusDraft},
+	StatusPublished:     {StatusArchived},
+	StatusArchived:      {StatusDraft},
+}
+
+func (s Status) CanTransition(to Status) bool {
+	return slices.Contains(transitions[s], to)
+}
+
+// Actor is the user acting on a recipe.
+type Actor struct {
+	UserID string
+	Role   user.Role
+}
+
+func (a Actor) IsAdmin() bool {
+	return a.Role >= user.RoleAdmin
+}
+
+// CanEdit tells whether the actor may change or delete the recipe, admins may edit any.
+func (r *RecipeModel) CanEdit(a Actor) bool {
+	return a.IsAdmin() || r.AuthorID != "" && r.AuthorID == a.UserID
+}
+
+// IsVisibleTo hides unpublished recipes from everyone but their editors.
+func (r *RecipeModel) IsVisibleTo(a Actor) bool {
+	return r.Status == StatusPublished || r.CanEdit(a)
+}
+
+// NeedsReview tells whether a change by the actor sends the recipe back to review, as only
+// admins may change what is published.
+func (r *RecipeModel) NeedsReview(a Actor) bool {
+	return r.Status == StatusPublished && !a.IsAdmin()
+}
+
+// CanChangeStatus tells whether the actor may move the recipe to the status. Authors
+// manage their own recipes, publishing is left to admins as it concludes the review.
+func (r *RecipeModel) CanChangeStatus(a Actor, to Status) bool {
+	if to == StatusPublished {
+		return a.IsAdmin()
+	}
+
+	return r.CanEdit(a)
+}
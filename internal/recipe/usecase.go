@@ -1,12 +1,44 @@
 package recipe
 
-import "context"
+import (
+	"context"
+	"flove/job/internal/ingredient"
+	"flove/job/internal/media"
+)
 
 type RecipeUC interface {
-	CreateRecipe(ctx context.Context, recipe *RecipeModel) error
-	GetRecipeByID(ctx context.Context, id string) (*RecipeModel, error)
-	DeleteRecipe(ctx context.Context, id string) error
-	UpdateRecipe(ctx context.Context, id string, dto UpdateRecipeDTO) error
+	CreateRecipe(ctx context.Context, actor Actor, recipe *RecipeModel) error
+	GetRecipeByID(ctx context.Context, actor Actor, id string) (*RecipeModel, error)
+	DeleteRecipe(ctx context.Context, actor Actor, id string) error
+	UpdateRecipe(ctx context.Context, actor Actor, id string, dto UpdateRecipeDTO) (*RecipeModel, error)
+	ChangeStatus(ctx context.Context, actor Actor, id string, status Status) (*RecipeModel, error)
+	ListAuthorRecipes(ctx context.Context, authorID string, statuses []Status) ([]*RecipeModel, error)
 
-	SearchRecipe(ctx context.Context, query string, tags []string, page, limit int64) ([]*RecipeModel, int, error)
+	AddStep(ctx context.Context, actor Actor, recipeID string, step *StepModel, position *int) error
+	UpdateStep(ctx context.Context, actor Actor, recipeID, stepID string, dto UpdateStepDTO) error
+	DeleteStep(ctx context.Context, actor Actor, recipeID, stepID string) error
+	GetCookingMode(ctx context.Context, actor Actor, recipeID string, opts ViewOptions) (*CookingModeModel, error)
+	// IngredientCatalogue returns the catalogue entries of the ingredients the recipes use,
+	// for the ViewOptions. Ingredients the catalogue does not know are left out.
+	IngredientCatalogue(ctx context.Context, recipes ...*RecipeModel) (map[string]*ingredient.IngredientModel, error)
+
+	// AddImage uploads a picture of the recipe, or of one of its steps when stepID is set.
+	AddImage(ctx context.Context, actor Actor, recipeID, stepID string, data []byte) (*media.ImageModel, error)
+	DeleteImage(ctx context.Context, actor Actor, recipeID, stepID, imageID string) error
+
+	ListRevisions(ctx context.Context, recipeID string) ([]*RevisionModel, error)
+	GetRevision(ctx context.Context, recipeID string, number int) (*RevisionModel, error)
+	DiffRevisions(ctx context.Context, recipeID string, from, to int) ([]FieldChange, error)
+	RollbackRecipe(ctx context.Context, actor Actor, recipeID string, number int) (*RecipeModel, error)
+
+	RateRecipe(ctx context.Context, actor Actor, recipeID string, stars int, review string) (*RatingModel, error)
+	GetRating(ctx context.Context, actor Actor, recipeID string) (*RatingModel, error)
+	ListRatings(ctx context.Context, actor Actor, recipeID string, page, limit int64) ([]*RatingModel, int64, error)
+	DeleteRating(ctx context.Context, actor Actor, recipeID string) error
+
+	SearchRecipe(ctx context.Context, params SearchParams) (*SearchResult, error)
+	// ListRecipesUsing lists the published recipes matching the search filters that use at
+	// least one of the ingredients, those using the most of them first.
+	ListRecipesUsing(ctx context.Context, ingredientIDs []string, params SearchParams, limit int) ([]*RecipeModel, error)
+	Suggest(ctx context.Context, query string, limit int) ([]SuggestionModel, error)
 }
@@ -3,6 +3,7 @@ package recommendation
 import (
 	"flove/job/config"
 	"flove/job/internal/base/response"
+	"flove/job/internal/user"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -11,28 +12,75 @@ import (
 type RecommendationHandler struct {
 	cfg              *config.Config
 	recommendationUC RecommendationUC
+	userUC           user.UserUC
 }
 
-func NewRecommendationHandler(cfg *config.Config, uc RecommendationUC) *RecommendationHandler {
+func NewRecommendationHandler(cfg *config.Config, uc RecommendationUC, userUC user.UserUC) *RecommendationHandler {
 	return &RecommendationHandler{
 		cfg:              cfg,
 		recommendationUC: uc,
+		userUC:           userUC,
 	}
 }
 
+type filterRequest struct {
+	ExcludeAllergens []string `form:"exclude_allergens" binding:"omitempty"`
+	Diets            []string `form:"diets" binding:"omitempty"`
+	// IgnoreProfile skips the dietary profile of the user, which is applied by default.
+	IgnoreProfile bool `form:"ignore_profile" binding:"omitempty"`
+}
+
+// filter builds the recommendation filter, restricted by the dietary profile of the user
+// unless the caller opted out.
+func (h *RecommendationHandler) filter(ctx *gin.Context, userID string, req filterRequest) (Filter, error) {
+	filter := Filter{
+		ExcludeAllergens: req.ExcludeAllergens,
+		DietLabels:       req.Diets,
+	}
+
+	if req.IgnoreProfile {
+		return filter, nil
+	}
+
+	u, err := h.userUC.GetUserByID(ctx, userID)
+	if err != nil {
+		return Filter{}, err
+	}
+
+	filter.Restrict(u.Dietary)
+
+	return filter, nil
+}
+
 // @Summary Get recommendation by similar users
 // @Description Get recommendation based on similar users
 // @Security BasicAuth
 // @Tags Recommendation
 // @Accept json
 // @Produce json
+// @Param exclude_allergens query []string false "Allergens the recipes must not contain"
+// @Param diets query []string false "Diets the recipes must fit"
+// @Param ignore_profile query bool false "Skip the dietary profile of the user"
 // @Success 200 {object} response.Response
 // @Failure 400 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /recommendation/collaborative [get]
 func (h *RecommendationHandler) GetRecommendationCollaborative(ctx *gin.Context) {
+	var req filterRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	userID := ctx.Value("userID").(string)
-	recipes, err := h.recommendationUC.GetRecommendationCollaborative(ctx, userID)
+	filter, err := h.filter(ctx, userID, req)
+	if err != nil {
+		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	recipes, err := h.recommendationUC.GetRecommendationCollaborative(ctx, userID, filter)
 	if err != nil {
 		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
@@ -47,13 +95,29 @@ func (h *RecommendationHandler) GetRecommendationCollaborative(ctx *gin.Context)
 // @Tags Recommendation
 // @Accept json
 // @Produce json
+// @Param exclude_allergens query []string false "Allergens the recipes must not contain"
+// @Param diets query []string false "Diets the recipes must fit"
+// @Param ignore_profile query bool false "Skip the dietary profile of the user"
 // @Success 200 {object} response.Response
 // @Failure 400 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /recommendation/preferences [get]
 func (h *RecommendationHandler) GetRecommendationByPreferences(ctx *gin.Context) {
+	var req filterRequest
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	userID := ctx.Value("userID").(string)
-	recipes, err := h.recommendationUC.GetRecommendationPreferences(ctx, userID)
+	filter, err := h.filter(ctx, userID, req)
+	if err != nil {
+		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	recipes, err := h.recommendationUC.GetRecommendationPreferences(ctx, userID, filter)
 	if err != nil {
 		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
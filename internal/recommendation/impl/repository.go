@@ -57,16 +57,61 @@ func (r *repository) NewInteraction(ctx context.Context, userID string, recipeID
 	return err
 }
 
+// Rate creates or updates the RATED relationship, which carries the stars next to the
+// weight derived from them.
+func (r *repository) Rate(ctx context.Context, userID, recipeID string, stars int) error {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (u:User {userID: $userID}), (r:Recipe {recipeID: $recipeID})
+		MERGE (u)-[rel:RATED]->(r)
+		SET rel.stars = $stars, rel.weight = $weight
+	`
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		return tx.Run(ctx, query, map[string]interface{}{
+			"userID":   userID,
+			"recipeID": recipeID,
+			"stars":    stars,
+			"weight":   recommendation.RatingWeight(stars),
+		})
+	})
+
+	return err
+}
+
+func (r *repository) Unrate(ctx context.Context, userID, recipeID string) error {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (u:User {userID: $userID})-[rel:RATED]->(r:Recipe {recipeID: $recipeID})
+		DELETE rel
+	`
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		return tx.Run(ctx, query, map[string]interface{}{
+			"userID":   userID,
+			"recipeID": recipeID,
+		})
+	})
+
+	return err
+}
+
+// RecalculatePreferences scores every tag of the recipes the user interacted with by the
+// share of the weights, poorly rated recipes give their tags a negative score.
 func (r *repository) RecalculatePreferences(ctx context.Context, userID string) error {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
 	defer session.Close(ctx)
 
 	query := `
-		MATCH (u:User {userID: $userID})-[rel:LIKED|SAVED|VIEWED]->(r:Recipe)
+		MATCH (u:User {userID: $userID})-[rel:LIKED|SAVED|VIEWED|RATED]->(r:Recipe)
 		UNWIND r.tags AS tag
-		WITH SUM(rel.weight) as totalSum
+		WITH SUM(abs(rel.weight)) as totalSum
 
-		MATCH (u:User {userID: $userID})-[rel:LIKED|SAVED|VIEWED]->(r:Recipe)
+		MATCH (u:User {userID: $userID})-[rel:LIKED|SAVED|VIEWED|RATED]->(r:Recipe)
 		UNWIND r.tags AS tag
 		WITH u, tag, SUM(rel.weight) AS tagScore, totalSum as totalSum
 		WITH u, tag, toFloat(tagScore) / totalSum as coefficient
@@ -88,20 +133,56 @@ func NewRecommendationRepository(cfg *config.Config, driver neo4j.DriverWithCont
 	}
 }
 
-func (r *repository) GetRecommendationCollaborative(ctx context.Context, userID string) ([]recommendation.RecipeModel, error) {
+// filterClause keeps recipes without the excluded allergens and ingredients that fit
+// every requested diet and stay under the calorie ceiling. Recipes synced before their
+// allergens were known are left out whenever allergens are excluded.
+const filterClause = `
+		AND (size($excludeAllergens) = 0 OR r.allergens IS NOT NULL AND NOT any(allergen IN r.allergens WHERE allergen IN $excludeAllergens))
+		AND all(diet IN $diets WHERE diet IN coalesce(r.diet_labels, []))
+		AND NOT any(ingredient IN coalesce(r.ingredient_ids, []) WHERE ingredient IN $excludeIngredients)
+		AND ($maxCalories = 0 OR coalesce(r.calories_per_serving, 0) <= $maxCalories)`
+
+func filterParams(userID string, filter recommendation.Filter) map[string]interface{} {
+	params := map[string]interface{}{
+		"userID":             userID,
+		"excludeAllergens":   []string{},
+		"diets":              []string{},
+		"excludeIngredients": []string{},
+		"maxCalories":        filter.MaxCalories,
+	}
+
+	if filter.ExcludeAllergens != nil {
+		params["excludeAllergens"] = filter.ExcludeAllergens
+	}
+	if filter.DietLabels != nil {
+		params["diets"] = filter.DietLabels
+	}
+	if filter.ExcludeIngredients != nil {
+		params["excludeIngredients"] = filter.ExcludeIngredients
+	}
+
+	return params
+}
+
+// GetRecommendationCollaborative recommends what users agreeing with this one, liking or
+// disliking the same recipes, rated well on balance.
+func (r *repository) GetRecommendationCollaborative(ctx context.Context, userID string, filter recommendation.Filter) ([]recommendation.RecipeModel, error) {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 	defer session.Close(ctx)
 
 	query := `
-		MATCH (u:User {userID: $userID})-[:LIKED|SAVED|VIEWED]->(:Recipe)<-[:LIKED|SAVED|VIEWED]-(similar:User)-[interaction:LIKED|SAVED|VIEWED]->(r:Recipe)
-		WHERE NOT (u)-[:LIKED|SAVED|VIEWED]->(r)
-		RETURN r.name AS name, r.category as category, r.tags as tags, SUM(interaction.weight) AS weightedScore
+		MATCH (u:User {userID: $userID})-[mine:LIKED|SAVED|VIEWED|RATED]->(:Recipe)<-[theirs:LIKED|SAVED|VIEWED|RATED]-(similar:User)-[interaction:LIKED|SAVED|VIEWED|RATED]->(r:Recipe)
+		WHERE sign(mine.weight) = sign(theirs.weight)
+		AND NOT (u)-[:LIKED|SAVED|VIEWED|RATED]->(r)` + filterClause + `
+		WITH r, SUM(interaction.weight) AS weightedScore
+		WHERE weightedScore > 0
+		RETURN r.name AS name, r.category as category, r.tags as tags, weightedScore
 		ORDER BY weightedScore DESC
 		LIMIT 5
 	`
 
 	results, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
-		records, err := tx.Run(ctx, query, map[string]interface{}{"userID": userID})
+		records, err := tx.Run(ctx, query, filterParams(userID, filter))
 		if err != nil {
 			return nil, err
 		}
@@ -128,7 +209,7 @@ func (r *repository) GetRecommendationCollaborative(ctx context.Context, userID
 	return results.([]recommendation.RecipeModel), err
 }
 
-func (r *repository) GetRecommendationPreferences(ctx context.Context, userID string) ([]recommendation.RecipeModel, error) {
+func (r *repository) GetRecommendationPreferences(ctx context.Context, userID string, filter recommendation.Filter) ([]recommendation.RecipeModel, error) {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 	defer session.Close(ctx)
 
@@ -139,7 +220,7 @@ func (r *repository) GetRecommendationPreferences(ctx context.Context, userID st
 		WITH u, u.preference_coefficients[i] AS coefficient, u.preference_tags[i] AS tag
 
 		MATCH (r:Recipe)
-		WHERE NOT (u)-[:LIKED|SAVED|VIEWED]->(r)
+		WHERE NOT (u)-[:LIKED|SAVED|VIEWED|RATED]->(r)` + filterClause + `
 		UNWIND r.tags AS recipeTag
 		WITH r, recipeTag, coefficient
 		WHERE recipeTag = tag
@@ -151,7 +232,7 @@ func (r *repository) GetRecommendationPreferences(ctx context.Context, userID st
 	`
 
 	results, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
-		records, err := tx.Run(ctx, query, map[string]interface{}{"userID": userID})
+		records, err := tx.Run(ctx, query, filterParams(userID, filter))
 		if err != nil {
 			return nil, err
 		}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"flove/job/config"
 	"flove/job/internal/base/database"
+	"flove/job/internal/collection"
+	"flove/job/internal/recipe"
 	"flove/job/internal/recommendation"
+	"fmt"
 )
 
 type usecase struct {
@@ -21,8 +24,8 @@ func NewRecommendationUC(config *config.Config, eventBus *database.EventBus, rep
 	}
 }
 
-func (u *usecase) GetRecommendationCollaborative(ctx context.Context, userID string) ([]recommendation.RecipeModel, error) {
-	recipes, err := u.recommendationRepo.GetRecommendationCollaborative(ctx, userID)
+func (u *usecase) GetRecommendationCollaborative(ctx context.Context, userID string, filter recommendation.Filter) ([]recommendation.RecipeModel, error) {
+	recipes, err := u.recommendationRepo.GetRecommendationCollaborative(ctx, userID, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -30,8 +33,8 @@ func (u *usecase) GetRecommendationCollaborative(ctx context.Context, userID str
 	return recipes, nil
 }
 
-func (u *usecase) GetRecommendationPreferences(ctx context.Context, userID string) ([]recommendation.RecipeModel, error) {
-	recipes, err := u.recommendationRepo.GetRecommendationPreferences(ctx, userID)
+func (u *usecase) GetRecommendationPreferences(ctx context.Context, userID string, filter recommendation.Filter) ([]recommendation.RecipeModel, error) {
+	recipes, err := u.recommendationRepo.GetRecommendationPreferences(ctx, userID, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -52,3 +55,49 @@ func (u *usecase) NewInteraction(ctx context.Context, userID string, recipeID st
 
 	return nil
 }
+
+func (u *usecase) Rate(ctx context.Context, userID, recipeID string, stars int) error {
+	if err := u.recommendationRepo.Rate(ctx, userID, recipeID, stars); err != nil {
+		return err
+	}
+
+	return u.recommendationRepo.RecalculatePreferences(ctx, userID)
+}
+
+func (u *usecase) Unrate(ctx context.Context, userID, recipeID string) error {
+	if err := u.recommendationRepo.Unrate(ctx, userID, recipeID); err != nil {
+		return err
+	}
+
+	return u.recommendationRepo.RecalculatePreferences(ctx, userID)
+}
+
+// WatchRatings feeds the recipe:rated and recipe:unrated events into the graph.
+func WatchRatings(eventBus *database.EventBus, uc recommendation.RecommendationUC) {
+	database.Subscribe(eventBus, "recommendations", func(ctx context.Context, event recipe.RecipeRated) error {
+		if err := uc.Rate(ctx, event.UserID, event.RecipeID, event.Stars); err != nil {
+			return fmt.Errorf("rating recipe %s in Neo4j: %w", event.RecipeID, err)
+		}
+
+		return nil
+	})
+
+	database.Subscribe(eventBus, "recommendations", func(ctx context.Context, event recipe.RecipeUnrated) error {
+		if err := uc.Unrate(ctx, event.UserID, event.RecipeID); err != nil {
+			return fmt.Errorf("removing rating of recipe %s from Neo4j: %w", event.RecipeID, err)
+		}
+
+		return nil
+	})
+}
+
+// WatchCollections records a SAVED interaction for every recipe added to a collection.
+func WatchCollections(eventBus *database.EventBus, uc recommendation.RecommendationUC) {
+	database.Subscribe(eventBus, "recommendations", func(ctx context.Context, event collection.RecipeAdded) error {
+		if err := uc.NewInteraction(ctx, event.UserID, event.RecipeID, recommendation.SAVED); err != nil {
+			return fmt.Errorf("saving recipe %s in Neo4j: %w", event.RecipeID, err)
+		}
+
+		return nil
+	})
+}
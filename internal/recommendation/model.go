@@ -1,13 +1,50 @@
 package recommendation
 
+import (
+	"flove/job/internal/user"
+)
+
 const (
 	VIEWED = iota
 	LIKED
 	SAVED
 )
 
+// RatingWeight turns a star rating into the weight of the RATED relationship. Poor
+// ratings weigh negatively, which lowers the score of the tags of the recipe and of
+// the recipes that users with the same taste rated poorly.
+func RatingWeight(stars int) int {
+	switch stars {
+	case 1:
+		return -10
+	case 2:
+		return -5
+	case 3:
+		return 1
+	case 4:
+		return 5
+	default:
+		return 10
+	}
+}
+
 type RecipeModel struct {
 	Name     string
 	Category string
 	Tags     []string
 }
+
+// Filter narrows recommendations down to recipes without the excluded allergens
+// that fit every listed diet. ExcludeIngredients and MaxCalories (per serving, zero
+// means no limit) come from the dietary profile of the user.
+type Filter struct {
+	ExcludeAllergens   []string
+	DietLabels         []string
+	ExcludeIngredients []string
+	MaxCalories        float64
+}
+
+// Restrict narrows the filter down to recipes that do not violate the dietary profile.
+func (f *Filter) Restrict(profile user.DietaryProfile) {
+	profile.Restrict(&f.ExcludeAllergens, &f.DietLabels, &f.ExcludeIngredients, &f.MaxCalories)
+}
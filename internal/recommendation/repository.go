@@ -4,7 +4,9 @@ import "context"
 
 type RecommendationRepository interface {
 	NewInteraction(ctx context.Context, userID, recipeID string, interaction int) error
+	Rate(ctx context.Context, userID, recipeID string, stars int) error
+	Unrate(ctx context.Context, userID, recipeID string) error
 	RecalculatePreferences(ctx context.Context, userID string) error
-	GetRecommendationCollaborative(ctx context.Context, userID string) ([]RecipeModel, error)
-	GetRecommendationPreferences(ctx context.Context, userID string) ([]RecipeModel, error)
+	GetRecommendationCollaborative(ctx context.Context, userID string, filter Filter) ([]RecipeModel, error)
+	GetRecommendationPreferences(ctx context.Context, userID string, filter Filter) ([]RecipeModel, error)
 }
@@ -4,6 +4,8 @@ import "context"
 
 type RecommendationUC interface {
 	NewInteraction(ctx context.Context, userID, recipeID string, interaction int) error
-	GetRecommendationCollaborative(ctx context.Context, userID string) ([]RecipeModel, error)
-	GetRecommendationPreferences(ctx context.Context, userID string) ([]RecipeModel, error)
+	Rate(ctx context.Context, userID, recipeID string, stars int) error
+	Unrate(ctx context.Context, userID, recipeID string) error
+	GetRecommendationCollaborative(ctx context.Context, userID string, filter Filter) ([]RecipeModel, error)
+	GetRecommendationPreferences(ctx context.Context, userID string, filter Filter) ([]RecipeModel, error)
 }
@@ -0,0 +1,27 @@
+package shopping
+
+import "time"
+
+// BuildListDTO tells what a new list is made of: recipes at the given servings, the meals
+// planned from one date to another, or both. A list built from nothing starts empty and
+// is filled by hand.
+type BuildListDTO struct {
+	Name    string
+	Recipes []RecipeServingsDTO
+	From    *time.Time
+	To      *time.Time
+}
+
+// RecipeServingsDTO selects a recipe, zero servings keeps the servings of the recipe.
+type RecipeServingsDTO struct {
+	RecipeID string
+	Servings int
+}
+
+type UpdateItemDTO struct {
+	Name     *string
+	Quantity *float64
+	Unit     *string
+	Note     *string
+	Checked  *bool
+}
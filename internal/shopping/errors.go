@@ -0,0 +1,10 @@
+package shopping
+
+import "errors"
+
+var (
+	ErrForbidden      = errors.New("only the owner of the list can do this")
+	ErrListFull       = errors.New("shopping list is full")
+	ErrOwnList        = errors.New("the owner of a list cannot be added as a member")
+	ErrTooManyMembers = errors.New("shopping list is shared with too many members")
+)
@@ -0,0 +1,507 @@
+package shopping
+
+import (
+	"errors"
+	"flove/job/internal/base/database"
+	"flove/job/internal/base/response"
+	"flove/job/internal/mealplan"
+	"flove/job/internal/recipe"
+	"flove/job/internal/user"
+	"flove/job/pkg/fp"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ShoppingListHandler struct {
+	shoppingListUC ShoppingListUC
+}
+
+func NewShoppingListHandler(uc ShoppingListUC) *ShoppingListHandler {
+	return &ShoppingListHandler{
+		shoppingListUC: uc,
+	}
+}
+
+// actor identifies the authenticated user making the request.
+func actor(ctx *gin.Context) recipe.Actor {
+	role, _ := ctx.Get("role")
+	r, _ := role.(user.Role)
+
+	return recipe.Actor{
+		UserID: ctx.GetString("userID"),
+		Role:   r,
+	}
+}
+
+// writeError maps the errors of the shopping list use cases to a response.
+func writeError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrForbidden), errors.Is(err, recipe.ErrForbidden):
+		response.WriteResponse(ctx, http.StatusForbidden, err.Error())
+	case errors.Is(err, ErrListFull), errors.Is(err, ErrTooManyMembers):
+		response.WriteResponse(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, ErrOwnList), errors.Is(err, mealplan.ErrInvalidRange):
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+	default:
+		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+type itemResponse struct {
+	ID           string   `json:"id"`
+	IngredientID string   `json:"ingredient_id,omitempty"`
+	Name         string   `json:"name"`
+	Quantity     float64  `json:"quantity"`
+	Unit         string   `json:"unit"`
+	Note         string   `json:"note,omitempty"`
+	RecipeIDs    []string `json:"recipe_ids,omitempty"`
+	Manual       bool     `json:"manual"`
+	Checked      bool     `json:"checked"`
+	CheckedBy    string   `json:"checked_by,omitempty"`
+}
+
+func toItemResponse(i ItemModel) itemResponse {
+	return itemResponse{
+		ID:           i.ID,
+		IngredientID: i.IngredientID,
+		Name:         i.Name,
+		Quantity:     i.Quantity,
+		Unit:         i.Unit,
+		Note:         i.Note,
+		RecipeIDs:    i.RecipeIDs,
+		Manual:       i.Manual,
+		Checked:      i.Checked,
+		CheckedBy:    i.CheckedBy,
+	}
+}
+
+type aisleResponse struct {
+	Aisle string         `json:"aisle" example:"produce"`
+	Items []itemResponse `json:"items"`
+}
+
+type recipeSourceResponse struct {
+	RecipeID string `json:"recipe_id"`
+	Name     string `json:"name"`
+	Servings int    `json:"servings"`
+}
+
+type listResponse struct {
+	ID           string                 `json:"id"`
+	OwnerID      string                 `json:"owner_id"`
+	Name         string                 `json:"name"`
+	SharedWith   []string               `json:"shared_with"`
+	Recipes      []recipeSourceResponse `json:"recipes"`
+	Aisles       []aisleResponse        `json:"aisles"`
+	ItemCount    int                    `json:"item_count"`
+	CheckedCount int                    `json:"checked_count"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+}
+
+func toListResponse(l *ShoppingListModel) listResponse {
+	aisles := fp.Map(l.ByAisle(), func(g AisleGroup) aisleResponse {
+		return aisleResponse{Aisle: g.Aisle, Items: fp.Map(g.Items, toItemResponse)}
+	})
+	if aisles == nil {
+		aisles = []aisleResponse{}
+	}
+
+	return listResponse{
+		ID:         l.ID,
+		OwnerID:    l.OwnerID,
+		Name:       l.Name,
+		SharedWith: l.SharedWith,
+		Recipes: fp.Map(l.Recipes, func(r RecipeSourceModel) recipeSourceResponse {
+			return recipeSourceResponse(r)
+		}),
+		Aisles:       aisles,
+		ItemCount:    len(l.Items),
+		CheckedCount: l.CheckedCount(),
+		CreatedAt:    l.CreatedAt,
+		UpdatedAt:    l.UpdatedAt,
+	}
+}
+
+type listSummaryResponse struct {
+	ID           string    `json:"id"`
+	OwnerID      string    `json:"owner_id"`
+	Name         string    `json:"name"`
+	SharedWith   []string  `json:"shared_with"`
+	ItemCount    int       `json:"item_count"`
+	CheckedCount int       `json:"checked_count"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func toListSummaryResponse(l *ShoppingListModel) listSummaryResponse {
+	return listSummaryResponse{
+		ID:           l.ID,
+		OwnerID:      l.OwnerID,
+		Name:         l.Name,
+		SharedWith:   l.SharedWith,
+		ItemCount:    len(l.Items),
+		CheckedCount: l.CheckedCount(),
+		UpdatedAt:    l.UpdatedAt,
+	}
+}
+
+type recipeServingsRequest struct {
+	RecipeID string `json:"recipe_id" binding:"required"`
+	Servings int    `json:"servings" binding:"omitempty,gte=1,lte=100"`
+}
+
+type mealPlanRangeRequest struct {
+	From string `json:"from" binding:"required,datetime=2006-01-02" example:"2024-06-03"`
+	To   string `json:"to" binding:"required,datetime=2006-01-02" example:"2024-06-09"`
+}
+
+type createListRequest struct {
+	Name     string                  `json:"name" binding:"required,max=100"`
+	Recipes  []recipeServingsRequest `json:"recipes" binding:"max=50,dive"`
+	MealPlan *mealPlanRangeRequest   `json:"meal_plan"`
+}
+
+// @Summary Create a shopping list
+// @Description Build a shopping list from recipes at the given servings and from the meals
+// @Description planned over a date range. The ingredients are added up across recipes with
+// @Description their units converted, e.g. 200 g and 0.5 kg of flour make 700 g, and the
+// @Description items are grouped by store aisle. A list built from nothing starts empty.
+// @Security BasicAuth
+// @Tags ShoppingList
+// @Accept json
+// @Produce json
+// @Param list body createListRequest true "Shopping list"
+// @Success 201 {object} response.Response{body=listResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /shopping-lists [post]
+func (h *ShoppingListHandler) CreateList(ctx *gin.Context) {
+	var req createListRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dto := BuildListDTO{
+		Name: req.Name,
+		Recipes: fp.Map(req.Recipes, func(r recipeServingsRequest) RecipeServingsDTO {
+			return RecipeServingsDTO(r)
+		}),
+	}
+
+	if req.MealPlan != nil {
+		// the request binding has validated the layout already
+		from, _ := time.Parse(mealplan.DateLayout, req.MealPlan.From)
+		to, _ := time.Parse(mealplan.DateLayout, req.MealPlan.To)
+		dto.From, dto.To = &from, &to
+	}
+
+	list, err := h.shoppingListUC.CreateList(ctx, actor(ctx), dto)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusCreated, "shopping list succesfully created", toListResponse(list))
+}
+
+// @Summary List my shopping lists
+// @Description List the shopping lists the user owns or that are shared with them, most
+// @Description recently updated first
+// @Security BasicAuth
+// @Tags ShoppingList
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.Response{body=[]listSummaryResponse}
+// @Failure 500 {object} response.Response
+// @Router /shopping-lists [get]
+func (h *ShoppingListHandler) ListLists(ctx *gin.Context) {
+	lists, err := h.shoppingListUC.ListLists(ctx, actor(ctx))
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", fp.Map(lists, toListSummaryResponse))
+}
+
+type listRequest struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+// @Summary Get a shopping list
+// @Description Get a shopping list with its items grouped by store aisle, items left to buy
+// @Description first. Only the owner and the members of the list can see it.
+// @Security BasicAuth
+// @Tags ShoppingList
+// @Accept json
+// @Produce json
+// @Param id path string true "Shopping list ID"
+// @Success 200 {object} response.Response{body=listResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /shopping-lists/{id} [get]
+func (h *ShoppingListHandler) GetList(ctx *gin.Context) {
+	var req listRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	list, err := h.shoppingListUC.GetList(ctx, actor(ctx), req.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", toListResponse(list))
+}
+
+// @Summary Delete a shopping list
+// @Description Delete a shopping list, only its owner can
+// @Security BasicAuth
+// @Tags ShoppingList
+// @Accept json
+// @Produce json
+// @Param id path string true "Shopping list ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /shopping-lists/{id} [delete]
+func (h *ShoppingListHandler) DeleteList(ctx *gin.Context) {
+	var req listRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.shoppingListUC.DeleteList(ctx, actor(ctx), req.ID); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponse(ctx, http.StatusOK, "shopping list succesfully deleted")
+}
+
+type addItemRequest struct {
+	ID           string  `uri:"id" binding:"required"`
+	IngredientID string  `json:"ingredient_id"`
+	Name         string  `json:"name" binding:"required_without=IngredientID,max=100"`
+	Quantity     float64 `json:"quantity" binding:"gte=0"`
+	Unit         string  `json:"unit" binding:"max=20"`
+	Aisle        string  `json:"aisle" binding:"omitempty,oneof=produce bakery meat seafood dairy baking pantry canned spices condiments beverages other" example:"produce"`
+	Note         string  `json:"note" binding:"max=200"`
+}
+
+// @Summary Add an item to a shopping list
+// @Description Add an item by hand. An item naming a catalogue ingredient takes its name and
+// @Description aisle unless they are given, other items go to the given aisle or to other.
+// @Security BasicAuth
+// @Tags ShoppingList
+// @Accept json
+// @Produce json
+// @Param id path string true "Shopping list ID"
+// @Param item body addItemRequest true "Item"
+// @Success 201 {object} response.Response{body=listResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /shopping-lists/{id}/items [post]
+func (h *ShoppingListHandler) AddItem(ctx *gin.Context) {
+	var req addItemRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	list, err := h.shoppingListUC.AddItem(ctx, actor(ctx), req.ID, &ItemModel{
+		IngredientID: req.IngredientID,
+		Name:         req.Name,
+		Quantity:     req.Quantity,
+		Unit:         req.Unit,
+		Aisle:        req.Aisle,
+		Note:         req.Note,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusCreated, "item succesfully added", toListResponse(list))
+}
+
+type itemRequest struct {
+	ID     string `uri:"id" binding:"required"`
+	ItemID string `uri:"itemID" binding:"required"`
+}
+
+type updateItemRequest struct {
+	ID       string   `uri:"id" binding:"required"`
+	ItemID   string   `uri:"itemID" binding:"required"`
+	Name     *string  `json:"name" binding:"omitempty,min=1,max=100"`
+	Quantity *float64 `json:"quantity" binding:"omitempty,gte=0"`
+	Unit     *string  `json:"unit" binding:"omitempty,max=20"`
+	Note     *string  `json:"note" binding:"omitempty,max=200"`
+	Checked  *bool    `json:"checked"`
+}
+
+// @Summary Update a shopping list item
+// @Description Check or uncheck an item, or change its name, quantity, unit or note. Members
+// @Description of the list can update any item.
+// @Security BasicAuth
+// @Tags ShoppingList
+// @Accept json
+// @Produce json
+// @Param id path string true "Shopping list ID"
+// @Param itemID path string true "Item ID"
+// @Param item body updateItemRequest true "Fields to update"
+// @Success 200 {object} response.Response{body=listResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /shopping-lists/{id}/items/{itemID} [patch]
+func (h *ShoppingListHandler) UpdateItem(ctx *gin.Context) {
+	var req updateItemRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	list, err := h.shoppingListUC.UpdateItem(ctx, actor(ctx), req.ID, req.ItemID, UpdateItemDTO{
+		Name:     req.Name,
+		Quantity: req.Quantity,
+		Unit:     req.Unit,
+		Note:     req.Note,
+		Checked:  req.Checked,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "item succesfully updated", toListResponse(list))
+}
+
+// @Summary Remove a shopping list item
+// @Description Remove an item from a shopping list
+// @Security BasicAuth
+// @Tags ShoppingList
+// @Accept json
+// @Produce json
+// @Param id path string true "Shopping list ID"
+// @Param itemID path string true "Item ID"
+// @Success 200 {object} response.Response{body=listResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /shopping-lists/{id}/items/{itemID} [delete]
+func (h *ShoppingListHandler) RemoveItem(ctx *gin.Context) {
+	var req itemRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	list, err := h.shoppingListUC.RemoveItem(ctx, actor(ctx), req.ID, req.ItemID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "item succesfully removed", toListResponse(list))
+}
+
+type memberRequest struct {
+	ID     string `uri:"id" binding:"required"`
+	UserID string `uri:"userID" binding:"required"`
+}
+
+// @Summary Share a shopping list
+// @Description Share a shopping list with a member of the household, who can then see it,
+// @Description check items and add new ones. Only the owner of the list can share it.
+// @Security BasicAuth
+// @Tags ShoppingList
+// @Accept json
+// @Produce json
+// @Param id path string true "Shopping list ID"
+// @Param userID path string true "User ID"
+// @Success 200 {object} response.Response{body=listResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /shopping-lists/{id}/members/{userID} [put]
+func (h *ShoppingListHandler) Share(ctx *gin.Context) {
+	var req memberRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	list, err := h.shoppingListUC.Share(ctx, actor(ctx), req.ID, req.UserID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "shopping list succesfully shared", toListResponse(list))
+}
+
+// @Summary Unshare a shopping list
+// @Description Remove a member from a shopping list. The owner can remove anyone, members can
+// @Description only remove themselves to leave the list.
+// @Security BasicAuth
+// @Tags ShoppingList
+// @Accept json
+// @Produce json
+// @Param id path string true "Shopping list ID"
+// @Param userID path string true "User ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /shopping-lists/{id}/members/{userID} [delete]
+func (h *ShoppingListHandler) Unshare(ctx *gin.Context) {
+	var req memberRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.shoppingListUC.Unshare(ctx, actor(ctx), req.ID, req.UserID); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponse(ctx, http.StatusOK, "member succesfully removed")
+}
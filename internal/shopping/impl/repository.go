@@ -0,0 +1,271 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/shopping"
+	"flove/job/pkg/fp"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var listsCollection = "shopping_lists"
+
+type listEntity struct {
+	ID         primitive.ObjectID   `bson:"_id,omitempty"`
+	OwnerID    string               `bson:"owner_id"`
+	Name       string               `bson:"name"`
+	SharedWith []string             `bson:"shared_with"`
+	Recipes    []recipeSourceEntity `bson:"recipes"`
+	Items      []itemEntity         `bson:"items"`
+	CreatedAt  time.Time            `bson:"created_at"`
+	UpdatedAt  time.Time            `bson:"updated_at"`
+}
+
+type recipeSourceEntity struct {
+	RecipeID string `bson:"recipe_id"`
+	Name     string `bson:"name"`
+	Servings int    `bson:"servings"`
+}
+
+type itemEntity struct {
+	ID           string   `bson:"id"`
+	IngredientID string   `bson:"ingredient_id,omitempty"`
+	Name         string   `bson:"name"`
+	Quantity     float64  `bson:"quantity"`
+	Unit         string   `bson:"unit"`
+	Aisle        string   `bson:"aisle"`
+	Note         string   `bson:"note,omitempty"`
+	RecipeIDs    []string `bson:"recipe_ids,omitempty"`
+	Manual       bool     `bson:"manual"`
+	Checked      bool     `bson:"checked"`
+	CheckedBy    string   `bson:"checked_by,omitempty"`
+}
+
+func (e *listEntity) toListModel() *shopping.ShoppingListModel {
+	return &shopping.ShoppingListModel{
+		ID:         e.ID.Hex(),
+		OwnerID:    e.OwnerID,
+		Name:       e.Name,
+		SharedWith: e.SharedWith,
+		Recipes: fp.Map(e.Recipes, func(r recipeSourceEntity) shopping.RecipeSourceModel {
+			return shopping.RecipeSourceModel(r)
+		}),
+		Items: fp.Map(e.Items, func(i itemEntity) shopping.ItemModel {
+			return shopping.ItemModel(i)
+		}),
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+func toItemEntity(i shopping.ItemModel) itemEntity {
+	return itemEntity(i)
+}
+
+func toEntity(l *shopping.ShoppingListModel) *listEntity {
+	return &listEntity{
+		OwnerID:    l.OwnerID,
+		Name:       l.Name,
+		SharedWith: l.SharedWith,
+		Recipes: fp.Map(l.Recipes, func(r shopping.RecipeSourceModel) recipeSourceEntity {
+			return recipeSourceEntity(r)
+		}),
+		Items:     fp.Map(l.Items, toItemEntity),
+		CreatedAt: l.CreatedAt,
+		UpdatedAt: l.UpdatedAt,
+	}
+}
+
+type repository struct {
+	config *config.Config
+	db     *mongo.Database
+}
+
+func NewShoppingListRepository(config *config.Config, db *mongo.Database) shopping.ShoppingListRepository {
+	db.Collection(listsCollection).Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "owner_id", Value: 1}, {Key: "updated_at", Value: -1}}},
+		{Keys: bson.D{{Key: "shared_with", Value: 1}, {Key: "updated_at", Value: -1}}},
+	})
+
+	return &repository{
+		config: config,
+		db:     db,
+	}
+}
+
+func (repo *repository) CreateList(ctx context.Context, l *shopping.ShoppingListModel) error {
+	for i := range l.Items {
+		l.Items[i].ID = primitive.NewObjectID().Hex()
+	}
+
+	result, err := repo.db.Collection(listsCollection).InsertOne(ctx, toEntity(l))
+	if err != nil {
+		return err
+	}
+
+	l.ID = result.InsertedID.(primitive.ObjectID).Hex()
+	return nil
+}
+
+func (repo *repository) GetList(ctx context.Context, id string) (*shopping.ShoppingListModel, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, database.ErrNotFound
+	}
+
+	entity := &listEntity{}
+	if err := repo.db.Collection(listsCollection).FindOne(ctx, bson.M{"_id": objectID}).Decode(entity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, database.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return entity.toListModel(), nil
+}
+
+func (repo *repository) ListLists(ctx context.Context, userID string) ([]*shopping.ShoppingListModel, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"owner_id": userID},
+		bson.M{"shared_with": userID},
+	}}
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}})
+
+	cursor, err := repo.db.Collection(listsCollection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*listEntity
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return fp.Map(results, (*listEntity).toListModel), nil
+}
+
+func (repo *repository) DeleteList(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return database.ErrNotFound
+	}
+
+	result, err := repo.db.Collection(listsCollection).DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return database.ErrNotFound
+	}
+
+	return nil
+}
+
+// updateList runs an update on a list. A filter that does not match an existing list is
+// reported with the error given for it.
+func (repo *repository) updateList(ctx context.Context, id string, filter, update bson.M, unmatched error, opts ...*options.UpdateOptions) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return database.ErrNotFound
+	}
+
+	filter["_id"] = objectID
+
+	result, err := repo.db.Collection(listsCollection).UpdateOne(ctx, filter, update, opts...)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return unmatched
+	}
+
+	return nil
+}
+
+func (repo *repository) AddItem(ctx context.Context, id string, item *shopping.ItemModel) error {
+	item.ID = primitive.NewObjectID().Hex()
+
+	filter := bson.M{"items." + strconv.Itoa(shopping.MaxItems-1): bson.M{"$exists": false}}
+	update := bson.M{
+		"$push": bson.M{"items": toItemEntity(*item)},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	return repo.updateList(ctx, id, filter, update, shopping.ErrListFull)
+}
+
+func (repo *repository) UpdateItem(ctx context.Context, id, itemID string, update shopping.UpdateItemDTO, checkedBy string) error {
+	set := bson.M{"updated_at": time.Now()}
+
+	if update.Name != nil {
+		set["items.$[item].name"] = *update.Name
+	}
+	if update.Quantity != nil {
+		set["items.$[item].quantity"] = *update.Quantity
+	}
+	if update.Unit != nil {
+		set["items.$[item].unit"] = *update.Unit
+	}
+	if update.Note != nil {
+		set["items.$[item].note"] = *update.Note
+	}
+	if update.Checked != nil {
+		set["items.$[item].checked"] = *update.Checked
+		if *update.Checked {
+			set["items.$[item].checked_by"] = checkedBy
+		} else {
+			set["items.$[item].checked_by"] = ""
+		}
+	}
+
+	filter := bson.M{"items.id": itemID}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []any{bson.M{"item.id": itemID}},
+	})
+
+	return repo.updateList(ctx, id, filter, bson.M{"$set": set}, database.ErrNotFound, opts)
+}
+
+func (repo *repository) RemoveItem(ctx context.Context, id, itemID string) error {
+	filter := bson.M{"items.id": itemID}
+	update := bson.M{
+		"$pull": bson.M{"items": bson.M{"id": itemID}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	return repo.updateList(ctx, id, filter, update, database.ErrNotFound)
+}
+
+func (repo *repository) AddMember(ctx context.Context, id, userID string) error {
+	// a member already in the list matches whatever the number of members
+	filter := bson.M{"$or": bson.A{
+		bson.M{"shared_with": userID},
+		bson.M{"shared_with." + strconv.Itoa(shopping.MaxMembers-1): bson.M{"$exists": false}},
+	}}
+	update := bson.M{
+		"$addToSet": bson.M{"shared_with": userID},
+		"$set":      bson.M{"updated_at": time.Now()},
+	}
+
+	return repo.updateList(ctx, id, filter, update, shopping.ErrTooManyMembers)
+}
+
+func (repo *repository) RemoveMember(ctx context.Context, id, userID string) error {
+	filter := bson.M{"shared_with": userID}
+	update := bson.M{
+		"$pull": bson.M{"shared_with": userID},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	return repo.updateList(ctx, id, filter, update, database.ErrNotFound)
+}
@@ -0,0 +1,284 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/ingredient"
+	"flove/job/internal/mealplan"
+	"flove/job/internal/recipe"
+	"flove/job/internal/shopping"
+	"flove/job/internal/user"
+	"time"
+)
+
+type usecase struct {
+	config       *config.Config
+	listRepo     shopping.ShoppingListRepository
+	recipeUC     recipe.RecipeUC
+	mealPlanUC   mealplan.MealPlanUC
+	ingredientUC ingredient.IngredientUC
+	userUC       user.UserUC
+}
+
+func NewShoppingListUC(config *config.Config, repo shopping.ShoppingListRepository, recipeUC recipe.RecipeUC, mealPlanUC mealplan.MealPlanUC, ingredientUC ingredient.IngredientUC, userUC user.UserUC) shopping.ShoppingListUC {
+	return &usecase{
+		config:       config,
+		listRepo:     repo,
+		recipeUC:     recipeUC,
+		mealPlanUC:   mealPlanUC,
+		ingredientUC: ingredientUC,
+		userUC:       userUC,
+	}
+}
+
+// memberList loads a list the actor is a member of, the lists of other households do not
+// exist for them.
+func (uc *usecase) memberList(ctx context.Context, actor recipe.Actor, id string) (*shopping.ShoppingListModel, error) {
+	l, err := uc.listRepo.GetList(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !l.IsMember(actor.UserID) {
+		return nil, database.ErrNotFound
+	}
+
+	return l, nil
+}
+
+// ownList loads a list the actor owns.
+func (uc *usecase) ownList(ctx context.Context, actor recipe.Actor, id string) (*shopping.ShoppingListModel, error) {
+	l, err := uc.memberList(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !l.IsOwner(actor.UserID) {
+		return nil, shopping.ErrForbidden
+	}
+
+	return l, nil
+}
+
+// builder collects what the recipes of a list need.
+type builder struct {
+	needs   []shopping.Need
+	sources []shopping.RecipeSourceModel
+}
+
+func (b *builder) add(r *recipe.RecipeModel, servings int) {
+	scaled := r.Scale(servings)
+
+	for _, i := range scaled.Ingredients {
+		b.needs = append(b.needs, shopping.Need{RecipeID: r.ID, Ingredient: i})
+	}
+
+	for i := range b.sources {
+		if b.sources[i].RecipeID == r.ID {
+			b.sources[i].Servings += scaled.Servings
+			return
+		}
+	}
+	b.sources = append(b.sources, shopping.RecipeSourceModel{RecipeID: r.ID, Name: r.Name, Servings: scaled.Servings})
+}
+
+func (uc *usecase) CreateList(ctx context.Context, actor recipe.Actor, dto shopping.BuildListDTO) (*shopping.ShoppingListModel, error) {
+	b := &builder{}
+
+	for _, selected := range dto.Recipes {
+		r, err := uc.recipeUC.GetRecipeByID(ctx, actor, selected.RecipeID)
+		if err != nil {
+			return nil, err
+		}
+		b.add(r, selected.Servings)
+	}
+
+	if dto.From != nil && dto.To != nil {
+		slots, err := uc.mealPlanUC.ListSlots(ctx, actor, *dto.From, *dto.To)
+		if err != nil {
+			return nil, err
+		}
+
+		// a recipe planned several times is loaded once, nil marks the ones out of reach
+		recipes := map[string]*recipe.RecipeModel{}
+		for _, slot := range slots {
+			r, ok := recipes[slot.RecipeID]
+			if !ok {
+				r, err = uc.recipeUC.GetRecipeByID(ctx, actor, slot.RecipeID)
+				if err != nil && !errors.Is(err, database.ErrNotFound) {
+					return nil, err
+				}
+				recipes[slot.RecipeID] = r
+			}
+
+			if r != nil {
+				b.add(r, slot.Servings)
+			}
+		}
+	}
+
+	catalogue := map[string]*ingredient.IngredientModel{}
+	for _, need := range b.needs {
+		id := need.Ingredient.IngredientID
+		if _, ok := catalogue[id]; ok || id == "" {
+			continue
+		}
+
+		known, err := uc.ingredientUC.GetIngredientByID(ctx, id)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, err
+		}
+		catalogue[id] = known
+	}
+
+	u, err := uc.userUC.GetUserByID(ctx, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := shopping.Merge(b.needs, catalogue, u.UnitSystem)
+	if len(items) > shopping.MaxItems {
+		return nil, shopping.ErrListFull
+	}
+
+	now := time.Now()
+	l := &shopping.ShoppingListModel{
+		OwnerID:    actor.UserID,
+		Name:       dto.Name,
+		SharedWith: []string{},
+		Recipes:    b.sources,
+		Items:      items,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	// the list is updated with $push and $addToSet, its arrays must not be null
+	if l.Recipes == nil {
+		l.Recipes = []shopping.RecipeSourceModel{}
+	}
+	if l.Items == nil {
+		l.Items = []shopping.ItemModel{}
+	}
+
+	if err := uc.listRepo.CreateList(ctx, l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (uc *usecase) GetList(ctx context.Context, actor recipe.Actor, id string) (*shopping.ShoppingListModel, error) {
+	return uc.memberList(ctx, actor, id)
+}
+
+func (uc *usecase) ListLists(ctx context.Context, actor recipe.Actor) ([]*shopping.ShoppingListModel, error) {
+	return uc.listRepo.ListLists(ctx, actor.UserID)
+}
+
+func (uc *usecase) DeleteList(ctx context.Context, actor recipe.Actor, id string) error {
+	if _, err := uc.ownList(ctx, actor, id); err != nil {
+		return err
+	}
+
+	return uc.listRepo.DeleteList(ctx, id)
+}
+
+func (uc *usecase) AddItem(ctx context.Context, actor recipe.Actor, id string, item *shopping.ItemModel) (*shopping.ShoppingListModel, error) {
+	l, err := uc.memberList(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(l.Items) >= shopping.MaxItems {
+		return nil, shopping.ErrListFull
+	}
+
+	if item.IngredientID != "" {
+		known, err := uc.ingredientUC.GetIngredientByID(ctx, item.IngredientID)
+		if err != nil {
+			return nil, err
+		}
+
+		if item.Name == "" {
+			item.Name = known.Name
+		}
+		if item.Aisle == "" {
+			item.Aisle = known.Aisle
+		}
+	}
+
+	if !shopping.IsAisle(item.Aisle) {
+		item.Aisle = ingredient.AisleOther
+	}
+
+	item.Manual = true
+	item.RecipeIDs = nil
+	item.Checked = false
+	item.CheckedBy = ""
+
+	if err := uc.listRepo.AddItem(ctx, id, item); err != nil {
+		return nil, err
+	}
+
+	return uc.listRepo.GetList(ctx, id)
+}
+
+func (uc *usecase) UpdateItem(ctx context.Context, actor recipe.Actor, id, itemID string, dto shopping.UpdateItemDTO) (*shopping.ShoppingListModel, error) {
+	if _, err := uc.memberList(ctx, actor, id); err != nil {
+		return nil, err
+	}
+
+	if err := uc.listRepo.UpdateItem(ctx, id, itemID, dto, actor.UserID); err != nil {
+		return nil, err
+	}
+
+	return uc.listRepo.GetList(ctx, id)
+}
+
+func (uc *usecase) RemoveItem(ctx context.Context, actor recipe.Actor, id, itemID string) (*shopping.ShoppingListModel, error) {
+	if _, err := uc.memberList(ctx, actor, id); err != nil {
+		return nil, err
+	}
+
+	if err := uc.listRepo.RemoveItem(ctx, id, itemID); err != nil {
+		return nil, err
+	}
+
+	return uc.listRepo.GetList(ctx, id)
+}
+
+func (uc *usecase) Share(ctx context.Context, actor recipe.Actor, id, userID string) (*shopping.ShoppingListModel, error) {
+	l, err := uc.ownList(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.IsOwner(userID) {
+		return nil, shopping.ErrOwnList
+	}
+
+	if _, err := uc.userUC.GetUserByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.listRepo.AddMember(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	return uc.listRepo.GetList(ctx, id)
+}
+
+func (uc *usecase) Unshare(ctx context.Context, actor recipe.Actor, id, userID string) error {
+	l, err := uc.memberList(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+
+	if !l.IsOwner(actor.UserID) && userID != actor.UserID {
+		return shopping.ErrForbidden
+	}
+
+	return uc.listRepo.RemoveMember(ctx, id, userID)
+}
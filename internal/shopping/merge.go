@@ -0,0 +1,153 @@
+package shopping
+
+import (
+	"flove/job/internal/ingredient"
+	"flove/job/internal/recipe"
+	"flove/job/pkg/units"
+	"slices"
+	"strings"
+)
+
+// Need is an ingredient a recipe calls for, already scaled to the servings planned.
+type Need struct {
+	RecipeID   string
+	Ingredient recipe.IngredientModel
+}
+
+// tally adds up the needs of a single ingredient. Amounts are kept per kind of measure:
+// grams for masses, millilitres for volumes and the unit itself for anything counted.
+type tally struct {
+	ingredientID string
+	name         string
+	aisle        string
+	density      float64
+	pieceWeight  float64
+	amounts      map[string]float64
+	measures     []string
+	recipeIDs    []string
+}
+
+func (t *tally) add(measure string, quantity float64) {
+	if _, ok := t.amounts[measure]; !ok {
+		t.measures = append(t.measures, measure)
+	}
+	t.amounts[measure] += quantity
+}
+
+// fold moves an amount into another measure and forgets the first one.
+func (t *tally) fold(from, to string, factor float64) {
+	if _, ok := t.amounts[from]; !ok || factor <= 0 {
+		return
+	}
+	if _, ok := t.amounts[to]; !ok {
+		return
+	}
+
+	t.amounts[to] += t.amounts[from] * factor
+	delete(t.amounts, from)
+	t.measures = slices.DeleteFunc(t.measures, func(m string) bool { return m == from })
+}
+
+// Merge adds up the needs into shopping items, e.g. 200 g and 0.5 kg of flour become
+// 700 g. Ingredients are matched by their catalogue ID, or by name when they have none.
+// Volumes are weighed and pieces are counted as grams when the same ingredient is also
+// needed by weight and the catalogue knows its density or piece weight, amounts that
+// still cannot be added up stay separate items. Quantities are given in the most
+// readable unit of the system.
+func Merge(needs []Need, catalogue map[string]*ingredient.IngredientModel, system units.System) []ItemModel {
+	tallies := map[string]*tally{}
+	var keys []string
+
+	for _, need := range needs {
+		key := need.Ingredient.IngredientID
+		if key == "" {
+			key = "name:" + strings.ToLower(strings.TrimSpace(need.Ingredient.Name))
+		}
+
+		t, ok := tallies[key]
+		if !ok {
+			t = newTally(need.Ingredient, catalogue[need.Ingredient.IngredientID])
+			tallies[key] = t
+			keys = append(keys, key)
+		}
+
+		if need.RecipeID != "" && !slices.Contains(t.recipeIDs, need.RecipeID) {
+			t.recipeIDs = append(t.recipeIDs, need.RecipeID)
+		}
+
+		measure, quantity := normalize(need.Ingredient.Quantity, need.Ingredient.Unit)
+		t.add(measure, quantity)
+	}
+
+	var items []ItemModel
+	for _, key := range keys {
+		t := tallies[key]
+		t.fold("ml", "g", t.density)
+		t.fold("pc", "g", t.pieceWeight)
+
+		for _, measure := range t.measures {
+			quantity, unit := units.Simplify(t.amounts[measure], measure, system)
+			if quantity > 0 {
+				quantity = recipe.RoundQuantity(quantity, unit)
+			}
+
+			items = append(items, ItemModel{
+				IngredientID: t.ingredientID,
+				Name:         t.name,
+				Quantity:     quantity,
+				Unit:         unit,
+				Aisle:        t.aisle,
+				RecipeIDs:    t.recipeIDs,
+			})
+		}
+	}
+
+	slices.SortStableFunc(items, func(a, b ItemModel) int {
+		if order := slices.Index(ingredient.Aisles, a.Aisle) - slices.Index(ingredient.Aisles, b.Aisle); order != 0 {
+			return order
+		}
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+
+	return items
+}
+
+func newTally(need recipe.IngredientModel, known *ingredient.IngredientModel) *tally {
+	t := &tally{
+		ingredientID: need.IngredientID,
+		name:         strings.TrimSpace(need.Name),
+		aisle:        ingredient.AisleOther,
+		density:      units.DensityOf(need.Name),
+		amounts:      map[string]float64{},
+	}
+
+	if known != nil {
+		t.name = known.Name
+		t.pieceWeight = known.PieceWeight
+		if known.Density > 0 {
+			t.density = known.Density
+		}
+		if IsAisle(known.Aisle) {
+			t.aisle = known.Aisle
+		}
+	}
+
+	return t
+}
+
+// normalize returns the measure a quantity is added up in with the quantity expressed in it.
+func normalize(quantity float64, unit string) (string, float64) {
+	u, ok := units.Lookup(unit)
+	if !ok {
+		return strings.ToLower(strings.TrimSpace(unit)), quantity
+	}
+
+	switch u.Dimension {
+	case units.Mass:
+		return "g", quantity * u.Factor
+	case units.Volume:
+		return "ml", quantity * u.Factor
+	default:
+		return u.Symbol, quantity
+	}
+}
@@ -0,0 +1,139 @@
+package shopping
+
+import (
+	"flove/job/internal/ingredient"
+	"flove/job/internal/recipe"
+	"flove/job/pkg/units"
+	"slices"
+	"testing"
+)
+
+func need(recipeID, ingredientID, name string, quantity float64, unit string) Need {
+	return Need{
+		RecipeID: recipeID,
+		Ingredient: recipe.IngredientModel{
+			IngredientID: ingredientID,
+			Name:         name,
+			Quantity:     quantity,
+			Unit:         unit,
+		},
+	}
+}
+
+type amount struct {
+	quantity float64
+	unit     string
+}
+
+func TestMerge(t *testing.T) {
+	catalogue := map[string]*ingredient.IngredientModel{
+		"sugar": {ID: "sugar", Name: "Sugar", Density: 0.85, Aisle: ingredient.AisleBaking},
+		"egg":   {ID: "egg", Name: "Egg", PieceWeight: 50, Aisle: ingredient.AisleDairy},
+		"lemon": {ID: "lemon", Name: "Lemon", Aisle: ingredient.AisleProduce},
+		"milk":  {ID: "milk", Name: "Milk", Aisle: ingredient.AisleDairy},
+	}
+
+	tests := []struct {
+		name   string
+		needs  []Need
+		system units.System
+		want   []amount
+	}{
+		{
+			name:  "masses add up in grams",
+			needs: []Need{need("a", "sugar", "sugar", 200, "g"), need("b", "sugar", "sugar", 0.5, "kg")},
+			want:  []amount{{700, "g"}},
+		},
+		{
+			name:  "large totals move to a larger unit",
+			needs: []Need{need("a", "sugar", "sugar", 800, "g"), need("b", "sugar", "sugar", 0.7, "kg")},
+			want:  []amount{{1.5, "kg"}},
+		},
+		{
+			name:  "volumes add up in millilitres",
+			needs: []Need{need("a", "milk", "milk", 1, "l"), need("b", "milk", "milk", 500, "ml")},
+			want:  []amount{{1.5, "l"}},
+		},
+		{
+			name:  "volumes are weighed with the density",
+			needs: []Need{need("a", "sugar", "sugar", 100, "g"), need("b", "sugar", "sugar", 100, "ml")},
+			want:  []amount{{185, "g"}},
+		},
+		{
+			name:  "volumes alone stay volumes",
+			needs: []Need{need("a", "sugar", "sugar", 100, "ml")},
+			want:  []amount{{100, "ml"}},
+		},
+		{
+			name:  "volumes without a density stay separate",
+			needs: []Need{need("a", "milk", "milk", 100, "g"), need("b", "milk", "milk", 200, "ml")},
+			want:  []amount{{100, "g"}, {200, "ml"}},
+		},
+		{
+			name:  "pieces are weighed with the piece weight",
+			needs: []Need{need("a", "egg", "egg", 2, "pc"), need("b", "egg", "egg", 100, "g")},
+			want:  []amount{{200, "g"}},
+		},
+		{
+			name:  "pieces without a piece weight stay separate",
+			needs: []Need{need("a", "lemon", "lemon", 100, "g"), need("b", "lemon", "lemon", 2, "pieces")},
+			want:  []amount{{100, "g"}, {2, "pc"}},
+		},
+		{
+			name:  "unknown units add up by name",
+			needs: []Need{need("a", "", "Basil", 1, "Sprig"), need("b", "", " basil ", 2, "sprig")},
+			want:  []amount{{3, "sprig"}},
+		},
+		{
+			name:   "imperial system",
+			needs:  []Need{need("a", "milk", "milk", 1, "cup"), need("b", "milk", "milk", 1, "cup")},
+			system: units.Imperial,
+			want:   []amount{{2, "cup"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []amount
+			for _, item := range Merge(tt.needs, catalogue, tt.system) {
+				got = append(got, amount{item.Quantity, item.Unit})
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Merge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeItems(t *testing.T) {
+	catalogue := map[string]*ingredient.IngredientModel{
+		"egg":   {ID: "egg", Name: "Egg", PieceWeight: 50, Aisle: ingredient.AisleDairy},
+		"lemon": {ID: "lemon", Name: "Lemon", Aisle: ingredient.AisleProduce},
+	}
+
+	items := Merge([]Need{
+		need("a", "egg", "eggs", 2, "pc"),
+		need("b", "lemon", "lemons", 1, "pc"),
+		need("b", "egg", "eggs", 1, "pc"),
+		need("a", "", "Twine", 1, "m"),
+	}, catalogue, units.Metric)
+
+	var names []string
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+
+	// produce comes first, unknown ingredients go to the other aisle at the end
+	if want := []string{"Lemon", "Egg", "Twine"}; !slices.Equal(names, want) {
+		t.Fatalf("Merge() names = %v, want %v", names, want)
+	}
+
+	egg := items[1]
+	if egg.Quantity != 3 || egg.Unit != "pc" || egg.Aisle != ingredient.AisleDairy {
+		t.Errorf("egg = %v %s in %s, want 3 pc in %s", egg.Quantity, egg.Unit, egg.Aisle, ingredient.AisleDairy)
+	}
+	if !slices.Equal(egg.RecipeIDs, []string{"a", "b"}) {
+		t.Errorf("egg recipe IDs = %v, want [a b]", egg.RecipeIDs)
+	}
+}
@@ -0,0 +1,118 @@
+package shopping
+
+import (
+	"flove/job/internal/ingredient"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxItems bounds the items of a list, they are kept in its document.
+	MaxItems = 300
+	// MaxMembers bounds the household members a list is shared with.
+	MaxMembers = 10
+)
+
+// ShoppingListModel is a list of groceries. Its owner can share it with members of their
+// household, who can then check and add items too.
+type ShoppingListModel struct {
+	ID         string
+	OwnerID    string
+	Name       string
+	SharedWith []string
+	Recipes    []RecipeSourceModel
+	Items      []ItemModel
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// RecipeSourceModel is a recipe the list was built from, Servings adds up every time the
+// recipe was planned.
+type RecipeSourceModel struct {
+	RecipeID string
+	Name     string
+	Servings int
+}
+
+// ItemModel is a line of the list. Items built from recipes list the recipes that need
+// them, Manual ones were added by hand. A zero Quantity means the amount is left to the
+// shopper.
+type ItemModel struct {
+	ID           string
+	IngredientID string
+	Name         string
+	Quantity     float64
+	Unit         string
+	Aisle        string
+	Note         string
+	RecipeIDs    []string
+	Manual       bool
+	Checked      bool
+	CheckedBy    string
+}
+
+// AisleGroup holds the items of a list found in the same aisle of the store.
+type AisleGroup struct {
+	Aisle string
+	Items []ItemModel
+}
+
+func (l *ShoppingListModel) IsOwner(userID string) bool {
+	return l.OwnerID == userID
+}
+
+// IsMember tells whether the user can read and check the list, its owner is a member.
+func (l *ShoppingListModel) IsMember(userID string) bool {
+	return l.IsOwner(userID) || slices.Contains(l.SharedWith, userID)
+}
+
+// CheckedCount returns the number of items already checked.
+func (l *ShoppingListModel) CheckedCount() int {
+	count := 0
+	for _, item := range l.Items {
+		if item.Checked {
+			count++
+		}
+	}
+	return count
+}
+
+// ByAisle groups the items in the walking order of the store, items left to buy come
+// first within an aisle.
+func (l *ShoppingListModel) ByAisle() []AisleGroup {
+	groups := map[string][]ItemModel{}
+	for _, item := range l.Items {
+		aisle := item.Aisle
+		if !IsAisle(aisle) {
+			aisle = ingredient.AisleOther
+		}
+		groups[aisle] = append(groups[aisle], item)
+	}
+
+	var result []AisleGroup
+	for _, aisle := range ingredient.Aisles {
+		items, ok := groups[aisle]
+		if !ok {
+			continue
+		}
+
+		slices.SortStableFunc(items, func(a, b ItemModel) int {
+			if a.Checked != b.Checked {
+				if a.Checked {
+					return 1
+				}
+				return -1
+			}
+			return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+
+		result = append(result, AisleGroup{Aisle: aisle, Items: items})
+	}
+
+	return result
+}
+
+func IsAisle(aisle string) bool {
+	return slices.Contains(ingredient.Aisles, aisle)
+}
@@ -0,0 +1,21 @@
+package shopping
+
+import "context"
+
+type ShoppingListRepository interface {
+	CreateList(ctx context.Context, list *ShoppingListModel) error
+	GetList(ctx context.Context, id string) (*ShoppingListModel, error)
+	// ListLists lists the lists the user owns or is a member of, most recently updated first.
+	ListLists(ctx context.Context, userID string) ([]*ShoppingListModel, error)
+	DeleteList(ctx context.Context, id string) error
+
+	// AddItem appends the item and sets its ID.
+	AddItem(ctx context.Context, id string, item *ItemModel) error
+	// UpdateItem changes an item, checkedBy is recorded when it gets checked.
+	UpdateItem(ctx context.Context, id, itemID string, update UpdateItemDTO, checkedBy string) error
+	RemoveItem(ctx context.Context, id, itemID string) error
+
+	// AddMember shares the list, sharing twice is not an error.
+	AddMember(ctx context.Context, id, userID string) error
+	RemoveMember(ctx context.Context, id, userID string) error
+}
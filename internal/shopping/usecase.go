@@ -0,0 +1,25 @@
+package shopping
+
+import (
+	"context"
+	"flove/job/internal/recipe"
+)
+
+type ShoppingListUC interface {
+	// CreateList builds a list from recipes the actor can see and from their meal plan.
+	CreateList(ctx context.Context, actor recipe.Actor, dto BuildListDTO) (*ShoppingListModel, error)
+	GetList(ctx context.Context, actor recipe.Actor, id string) (*ShoppingListModel, error)
+	ListLists(ctx context.Context, actor recipe.Actor) ([]*ShoppingListModel, error)
+	DeleteList(ctx context.Context, actor recipe.Actor, id string) error
+
+	// AddItem adds an item by hand, an item naming a catalogue ingredient is shelved in
+	// its aisle.
+	AddItem(ctx context.Context, actor recipe.Actor, id string, item *ItemModel) (*ShoppingListModel, error)
+	UpdateItem(ctx context.Context, actor recipe.Actor, id, itemID string, dto UpdateItemDTO) (*ShoppingListModel, error)
+	RemoveItem(ctx context.Context, actor recipe.Actor, id, itemID string) (*ShoppingListModel, error)
+
+	// Share lets another user read and check the list, only its owner can share it.
+	Share(ctx context.Context, actor recipe.Actor, id, userID string) (*ShoppingListModel, error)
+	// Unshare removes a member, members can remove themselves to leave the list.
+	Unshare(ctx context.Context, actor recipe.Actor, id, userID string) error
+}
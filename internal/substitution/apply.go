@@ -0,0 +1,226 @@
+package substitution
+
+import (
+	"flove/job/internal/ingredient"
+	"flove/job/internal/recipe"
+	"flove/job/internal/user"
+	"flove/job/pkg/units"
+	"slices"
+)
+
+// Conflict returns why an ingredient does not fit a dietary profile, or an empty reason
+// when it does.
+func Conflict(entry *ingredient.IngredientModel, profile user.DietaryProfile) string {
+	switch {
+	case slices.ContainsFunc(entry.Allergens, func(a string) bool { return slices.Contains(profile.ExcludedAllergens, a) }):
+		return ReasonAllergen
+	case slices.Contains(profile.DislikedIngredients, entry.ID):
+		return ReasonDisliked
+	case !recipe.FitsDiet(entry, profile.Diet):
+		return ReasonDiet
+	default:
+		return ""
+	}
+}
+
+// Swapper applies the knowledge base to recipes. Rules holds the candidate rules per
+// ingredient in the order they are tried, Catalogue the ingredients of the recipes and
+// of the rules. A nil Profile leaves the dietary profile out, ingredients listed in
+// Replace are swapped whatever the profile.
+type Swapper struct {
+	Rules     map[string][]*RuleModel
+	Catalogue map[string]*ingredient.IngredientModel
+	Profile   *user.DietaryProfile
+	Replace   []string
+}
+
+// reason tells why a line has to be swapped, an empty reason keeps it.
+func (s *Swapper) reason(line recipe.IngredientModel) string {
+	if line.IngredientID == "" {
+		return ""
+	}
+
+	if slices.Contains(s.Replace, line.IngredientID) {
+		return ReasonRequested
+	}
+
+	entry, ok := s.Catalogue[line.IngredientID]
+	if !ok || s.Profile == nil {
+		return ""
+	}
+
+	return Conflict(entry, *s.Profile)
+}
+
+// fits tells whether an ingredient can be swapped in: it has to be known, at hand and
+// fit the profile.
+func (s *Swapper) fits(ingredientID string) bool {
+	entry, ok := s.Catalogue[ingredientID]
+	if !ok || slices.Contains(s.Replace, ingredientID) {
+		return false
+	}
+
+	return s.Profile == nil || Conflict(entry, *s.Profile) == ""
+}
+
+// replace returns the lines a rule puts in place of a recipe line, or false when the
+// rule does not fit or the amounts cannot be converted.
+func (s *Swapper) replace(line recipe.IngredientModel, rule *RuleModel) ([]recipe.IngredientModel, bool) {
+	quantity, unit := line.Quantity, line.Unit
+	if rule.Unit != "" {
+		converted, ok := convertLine(line, s.Catalogue[line.IngredientID], rule.Unit)
+		if !ok {
+			return nil, false
+		}
+		quantity, unit = converted, rule.Unit
+	}
+
+	var lines []recipe.IngredientModel
+	for _, r := range rule.Replacements {
+		if !s.fits(r.IngredientID) {
+			return nil, false
+		}
+
+		replacement := recipe.IngredientModel{
+			IngredientID: r.IngredientID,
+			Name:         r.Name,
+			Unit:         unit,
+			Note:         "instead of " + line.Name,
+		}
+		if r.Unit != "" {
+			replacement.Unit = r.Unit
+		}
+		if quantity > 0 {
+			replacement.Quantity = recipe.RoundQuantity(quantity*r.Ratio, replacement.Unit)
+		}
+
+		lines = append(lines, replacement)
+	}
+
+	return lines, true
+}
+
+// Apply returns a copy of the recipe with its lines swapped. The nutrition moves by the
+// difference between the removed and the added lines, so that totals typed in by an
+// admin are kept as the base, and the labels are inferred again.
+func (s *Swapper) Apply(r *recipe.RecipeModel) *SubstitutedRecipeModel {
+	result := &SubstitutedRecipeModel{
+		Swaps:      []SwapModel{},
+		Unresolved: []UnresolvedModel{},
+	}
+
+	swapped := *r
+	swapped.Ingredients = []recipe.IngredientModel{}
+	replacedBy := map[string][]string{}
+	var removed, added []recipe.IngredientModel
+
+	for _, line := range r.Ingredients {
+		reason := s.reason(line)
+		if reason == "" {
+			swapped.Ingredients = append(swapped.Ingredients, line)
+			continue
+		}
+
+		var swap *SwapModel
+		for _, rule := range s.Rules[line.IngredientID] {
+			if lines, ok := s.replace(line, rule); ok {
+				swap = &SwapModel{Original: line, Replacements: lines, Reason: reason, Rule: rule}
+				break
+			}
+		}
+
+		if swap == nil {
+			result.Unresolved = append(result.Unresolved, UnresolvedModel{Ingredient: line, Reason: reason})
+			swapped.Ingredients = append(swapped.Ingredients, line)
+			continue
+		}
+
+		result.Swaps = append(result.Swaps, *swap)
+		swapped.Ingredients = append(swapped.Ingredients, swap.Replacements...)
+		removed = append(removed, line)
+		added = append(added, swap.Replacements...)
+		for _, replacement := range swap.Replacements {
+			replacedBy[line.IngredientID] = append(replacedBy[line.IngredientID], replacement.IngredientID)
+		}
+	}
+
+	if len(result.Swaps) > 0 {
+		swapped.Steps = make([]recipe.StepModel, len(r.Steps))
+		for i, step := range r.Steps {
+			var ids []string
+			for _, id := range step.IngredientIDs {
+				replacements, ok := replacedBy[id]
+				if !ok {
+					replacements = []string{id}
+				}
+				for _, replacement := range replacements {
+					if !slices.Contains(ids, replacement) {
+						ids = append(ids, replacement)
+					}
+				}
+			}
+			step.IngredientIDs = ids
+			swapped.Steps[i] = step
+		}
+
+		minus, _ := recipe.ComputeNutrition(removed, s.Catalogue)
+		plus, _ := recipe.ComputeNutrition(added, s.Catalogue)
+		swapped.Nutrition = difference(r.Nutrition, minus, plus)
+		swapped.NutritionPerServing = swapped.Nutrition.PerServing(swapped.Servings)
+		swapped.Allergens, swapped.DietLabels = recipe.InferLabels(swapped.Ingredients, s.Catalogue, swapped.Nutrition)
+	}
+
+	result.Recipe = &swapped
+	return result
+}
+
+// difference returns the nutrition with some of it taken away and some added, nutrients
+// never drop below zero.
+func difference(base, minus, plus recipe.NutritionInfo) recipe.NutritionInfo {
+	change := func(b, m, p float64) float64 {
+		return max(b-m+p, 0)
+	}
+
+	return recipe.NutritionInfo{
+		Calories:      change(base.Calories, minus.Calories, plus.Calories),
+		Protein:       change(base.Protein, minus.Protein, plus.Protein),
+		Fat:           change(base.Fat, minus.Fat, plus.Fat),
+		Carbohydrates: change(base.Carbohydrates, minus.Carbohydrates, plus.Carbohydrates),
+		Fiber:         change(base.Fiber, minus.Fiber, plus.Fiber),
+		Sugar:         change(base.Sugar, minus.Sugar, plus.Sugar),
+		Sodium:        change(base.Sodium, minus.Sodium, plus.Sodium),
+	}.Scale(1)
+}
+
+// convertLine expresses the quantity of a recipe line in another unit, weighing it
+// through the catalogue when the dimensions differ.
+func convertLine(line recipe.IngredientModel, entry *ingredient.IngredientModel, unit string) (float64, bool) {
+	if entry == nil {
+		converted, err := units.Convert(line.Quantity, line.Unit, unit, 0)
+		return converted, err == nil
+	}
+
+	if converted, err := units.Convert(line.Quantity, line.Unit, unit, entry.Density); err == nil {
+		return converted, true
+	}
+
+	grams, ok := recipe.IngredientWeight(line, entry)
+	if !ok {
+		return 0, false
+	}
+
+	to, ok := units.Lookup(unit)
+	if !ok {
+		return 0, false
+	}
+
+	if to.Dimension == units.Count {
+		if entry.PieceWeight <= 0 {
+			return 0, false
+		}
+		return grams / entry.PieceWeight, true
+	}
+
+	converted, err := units.Convert(grams, "g", unit, entry.Density)
+	return converted, err == nil
+}
@@ -0,0 +1,14 @@
+package substitution
+
+import "flove/job/internal/recipe"
+
+// SubstituteOptions tell what to swap in a recipe. Replace lists the ingredients the user
+// lacks, ingredients violating their dietary profile are swapped too unless
+// IgnoreProfile is set. Rules picks the rules to use before the others, View applies to
+// the swapped recipe.
+type SubstituteOptions struct {
+	Replace       []string
+	Rules         []string
+	IgnoreProfile bool
+	View          recipe.ViewOptions
+}
@@ -0,0 +1,5 @@
+package substitution
+
+import "errors"
+
+var ErrUnknownRule = errors.New("unknown substitution rule")
@@ -0,0 +1,247 @@
+package substitution
+
+import (
+	"errors"
+	"flove/job/internal/base/database"
+	"flove/job/internal/base/response"
+	"flove/job/internal/recipe"
+	"flove/job/internal/user"
+	"flove/job/pkg/fp"
+	"flove/job/pkg/units"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SubstitutionHandler struct {
+	substitutionUC SubstitutionUC
+	userUC         user.UserUC
+}
+
+func NewSubstitutionHandler(uc SubstitutionUC, userUC user.UserUC) *SubstitutionHandler {
+	return &SubstitutionHandler{
+		substitutionUC: uc,
+		userUC:         userUC,
+	}
+}
+
+// actor identifies the authenticated user making the request.
+func actor(ctx *gin.Context) recipe.Actor {
+	role, _ := ctx.Get("role")
+	r, _ := role.(user.Role)
+
+	return recipe.Actor{
+		UserID: ctx.GetString("userID"),
+		Role:   r,
+	}
+}
+
+// writeError maps the errors of the substitution use cases to a response.
+func writeError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		response.WriteResponse(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrUnknownRule):
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+	case errors.Is(err, recipe.ErrForbidden):
+		response.WriteResponse(ctx, http.StatusForbidden, err.Error())
+	default:
+		response.WriteResponse(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+type replacementResponse struct {
+	IngredientID string  `json:"ingredient_id"`
+	Name         string  `json:"name"`
+	Ratio        float64 `json:"ratio" example:"0.94"`
+	Unit         string  `json:"unit,omitempty"`
+}
+
+type ruleResponse struct {
+	ID           string                `json:"id"`
+	IngredientID string                `json:"ingredient_id"`
+	Unit         string                `json:"unit,omitempty"`
+	Replacements []replacementResponse `json:"replacements"`
+	Context      string                `json:"context"`
+	Notes        string                `json:"notes"`
+}
+
+func toRuleResponse(r *RuleModel) ruleResponse {
+	return ruleResponse{
+		ID:           r.ID,
+		IngredientID: r.IngredientID,
+		Unit:         r.Unit,
+		Replacements: fp.Map(r.Replacements, func(replacement ReplacementModel) replacementResponse {
+			return replacementResponse(replacement)
+		}),
+		Context: r.Context,
+		Notes:   r.Notes,
+	}
+}
+
+type listSubstitutesRequest struct {
+	ID            string `uri:"id" binding:"required"`
+	IgnoreProfile bool   `form:"ignore_profile"`
+}
+
+// @Summary List the substitutes of an ingredient
+// @Description List what can stand in for a catalogue ingredient, with the ratio per unit of the
+// @Description original and notes on the dishes the swap suits. Swaps bringing in ingredients
+// @Description that violate the dietary profile of the user are left out unless ignore_profile is set.
+// @Security BasicAuth
+// @Tags Ingredient
+// @Accept json
+// @Produce json
+// @Param id path string true "Ingredient ID"
+// @Param ignore_profile query bool false "List the swaps whatever the dietary profile"
+// @Success 200 {object} response.Response{body=[]ruleResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /ingredients/{id}/substitutes [get]
+func (h *SubstitutionHandler) ListSubstitutes(ctx *gin.Context) {
+	var req listSubstitutesRequest
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rules, err := h.substitutionUC.ListSubstitutes(ctx, actor(ctx), req.ID, req.IgnoreProfile)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", fp.Map(rules, toRuleResponse))
+}
+
+type lineResponse struct {
+	IngredientID string  `json:"ingredient_id,omitempty"`
+	Name         string  `json:"name"`
+	Quantity     float64 `json:"quantity"`
+	Unit         string  `json:"unit"`
+	Note         string  `json:"note,omitempty"`
+}
+
+func toLineResponse(i recipe.IngredientModel) lineResponse {
+	return lineResponse(i)
+}
+
+type swapResponse struct {
+	Original     lineResponse   `json:"original"`
+	Replacements []lineResponse `json:"replacements"`
+	Reason       string         `json:"reason" example:"allergen"`
+	RuleID       string         `json:"rule_id"`
+	Context      string         `json:"context"`
+	Notes        string         `json:"notes"`
+}
+
+type unresolvedResponse struct {
+	Ingredient lineResponse `json:"ingredient"`
+	Reason     string       `json:"reason" example:"diet"`
+}
+
+type substitutedRecipeResponse struct {
+	Recipe     *recipe.RecipeModel  `json:"recipe" swaggertype:"object"`
+	Swaps      []swapResponse       `json:"swaps"`
+	Unresolved []unresolvedResponse `json:"unresolved"`
+}
+
+func toSubstitutedRecipeResponse(s *SubstitutedRecipeModel) substitutedRecipeResponse {
+	return substitutedRecipeResponse{
+		Recipe: s.Recipe,
+		Swaps: fp.Map(s.Swaps, func(swap SwapModel) swapResponse {
+			return swapResponse{
+				Original:     toLineResponse(swap.Original),
+				Replacements: fp.Map(swap.Replacements, toLineResponse),
+				Reason:       swap.Reason,
+				RuleID:       swap.Rule.ID,
+				Context:      swap.Rule.Context,
+				Notes:        swap.Rule.Notes,
+			}
+		}),
+		Unresolved: fp.Map(s.Unresolved, func(u UnresolvedModel) unresolvedResponse {
+			return unresolvedResponse{
+				Ingredient: toLineResponse(u.Ingredient),
+				Reason:     u.Reason,
+			}
+		}),
+	}
+}
+
+type substituteRecipeRequest struct {
+	Replace       []string `form:"replace" binding:"max=20"`
+	Rules         []string `form:"rule" binding:"max=20"`
+	IgnoreProfile bool     `form:"ignore_profile"`
+	Servings      int      `form:"servings" binding:"omitempty,gte=1,lte=100"`
+	Units         string   `form:"units" binding:"omitempty,oneof=metric imperial"`
+}
+
+// @Summary Get a recipe with substitutions
+// @Description Get a recipe with the ingredients listed in replace swapped, along with those that
+// @Description violate the dietary profile of the user unless ignore_profile is set. Rules given
+// @Description by ID are tried before the others. Nutrition, allergens and diet labels are those
+// @Description of the swapped version. Lines no rule fits are listed as unresolved and kept.
+// @Description Nothing is saved.
+// @Security BasicAuth
+// @Tags Recipe
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param replace query []string false "Ingredient IDs the user lacks" collectionFormat(multi)
+// @Param rule query []string false "Substitution rule IDs to prefer" collectionFormat(multi)
+// @Param ignore_profile query bool false "Leave the dietary profile out"
+// @Param servings query int false "Scale the recipe to this number of servings"
+// @Param units query string false "Unit system, metric or imperial, defaults to the user preference"
+// @Success 200 {object} response.Response{body=substitutedRecipeResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /recipes/{id}/substituted [get]
+func (h *SubstitutionHandler) SubstituteRecipe(ctx *gin.Context) {
+	var uri struct {
+		ID string `uri:"id" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req substituteRecipeRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.WriteResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	view := recipe.ViewOptions{
+		Servings:   req.Servings,
+		UnitSystem: units.ParseSystem(req.Units),
+	}
+
+	if view.UnitSystem == "" {
+		if u, err := h.userUC.GetUserByID(ctx, ctx.GetString("userID")); err == nil {
+			view.UnitSystem = u.UnitSystem
+		}
+	}
+
+	substituted, err := h.substitutionUC.SubstituteRecipe(ctx, actor(ctx), uri.ID, SubstituteOptions{
+		Replace:       req.Replace,
+		Rules:         req.Rules,
+		IgnoreProfile: req.IgnoreProfile,
+		View:          view,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	response.WriteResponseWithBody(ctx, http.StatusOK, "success", toSubstitutedRecipeResponse(substituted))
+}
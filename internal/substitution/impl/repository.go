@@ -0,0 +1,141 @@
+package impl
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/ingredient"
+	"flove/job/internal/substitution"
+	"flove/job/pkg/units"
+	"fmt"
+)
+
+//go:embed data/substitutions.json
+var dataset []byte
+
+type ruleEntity struct {
+	ID           string              `json:"id"`
+	IngredientID string              `json:"ingredient_id"`
+	Unit         string              `json:"unit"`
+	Replacements []replacementEntity `json:"replacements"`
+	Context      string              `json:"context"`
+	Notes        string              `json:"notes"`
+}
+
+type replacementEntity struct {
+	IngredientID string  `json:"ingredient_id"`
+	Ratio        float64 `json:"ratio"`
+	Unit         string  `json:"unit"`
+}
+
+func (e *ruleEntity) toRuleModel() *substitution.RuleModel {
+	model := &substitution.RuleModel{
+		ID:           e.ID,
+		IngredientID: e.IngredientID,
+		Unit:         e.Unit,
+		Replacements: make([]substitution.ReplacementModel, len(e.Replacements)),
+		Context:      e.Context,
+		Notes:        e.Notes,
+	}
+
+	for i, r := range e.Replacements {
+		model.Replacements[i] = substitution.ReplacementModel{
+			IngredientID: r.IngredientID,
+			Ratio:        r.Ratio,
+			Unit:         r.Unit,
+		}
+	}
+
+	return model
+}
+
+// repository serves the knowledge base bundled with the binary, it is read-only and
+// kept in memory like the ingredient catalogue it refers to.
+type repository struct {
+	config       *config.Config
+	byID         map[string]*substitution.RuleModel
+	byIngredient map[string][]*substitution.RuleModel
+}
+
+// NewRuleRepository loads the knowledge base, checking it against the catalogue so
+// that a bad entry stops the start rather than a request.
+func NewRuleRepository(config *config.Config, ingredientRepo ingredient.IngredientRepository) (substitution.RuleRepository, error) {
+	var entities []ruleEntity
+	if err := json.Unmarshal(dataset, &entities); err != nil {
+		return nil, err
+	}
+
+	repo := &repository{
+		config:       config,
+		byID:         make(map[string]*substitution.RuleModel, len(entities)),
+		byIngredient: map[string][]*substitution.RuleModel{},
+	}
+
+	ctx := context.Background()
+	for i := range entities {
+		model := entities[i].toRuleModel()
+		if err := repo.check(ctx, ingredientRepo, model); err != nil {
+			return nil, fmt.Errorf("substitution rule %q: %w", model.ID, err)
+		}
+
+		repo.byID[model.ID] = model
+		repo.byIngredient[model.IngredientID] = append(repo.byIngredient[model.IngredientID], model)
+	}
+
+	return repo, nil
+}
+
+// check validates a rule and fills in the names of its replacements.
+func (repo *repository) check(ctx context.Context, ingredientRepo ingredient.IngredientRepository, model *substitution.RuleModel) error {
+	if _, ok := repo.byID[model.ID]; ok {
+		return errors.New("duplicate id")
+	}
+
+	if _, err := ingredientRepo.GetIngredientByID(ctx, model.IngredientID); err != nil {
+		return fmt.Errorf("ingredient %q: %w", model.IngredientID, err)
+	}
+
+	if len(model.Replacements) == 0 {
+		return errors.New("no replacements")
+	}
+
+	if _, ok := units.Lookup(model.Unit); model.Unit != "" && !ok {
+		return fmt.Errorf("unknown unit %q", model.Unit)
+	}
+
+	for i := range model.Replacements {
+		r := &model.Replacements[i]
+
+		known, err := ingredientRepo.GetIngredientByID(ctx, r.IngredientID)
+		if err != nil {
+			return fmt.Errorf("replacement %q: %w", r.IngredientID, err)
+		}
+		r.Name = known.Name
+
+		if r.Ratio <= 0 {
+			return fmt.Errorf("replacement %q: ratio must be positive", r.IngredientID)
+		}
+
+		if _, ok := units.Lookup(r.Unit); r.Unit != "" && (!ok || model.Unit == "") {
+			return fmt.Errorf("replacement %q: unit %q needs a known rule unit", r.IngredientID, r.Unit)
+		}
+	}
+
+	return nil
+}
+
+func (repo *repository) GetRule(_ context.Context, id string) (*substitution.RuleModel, error) {
+	model, ok := repo.byID[id]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+
+	return model, nil
+}
+
+func (repo *repository) ListRules(_ context.Context, ingredientID string) ([]*substitution.RuleModel, error) {
+	return repo.byIngredient[ingredientID], nil
+}
@@ -0,0 +1,183 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"flove/job/config"
+	"flove/job/internal/base/database"
+	"flove/job/internal/ingredient"
+	"flove/job/internal/recipe"
+	"flove/job/internal/substitution"
+	"flove/job/internal/user"
+	"flove/job/pkg/units"
+	"slices"
+)
+
+type usecase struct {
+	config       *config.Config
+	ruleRepo     substitution.RuleRepository
+	recipeUC     recipe.RecipeUC
+	ingredientUC ingredient.IngredientUC
+	userUC       user.UserUC
+}
+
+func NewSubstitutionUC(config *config.Config, repo substitution.RuleRepository, recipeUC recipe.RecipeUC, ingredientUC ingredient.IngredientUC, userUC user.UserUC) substitution.SubstitutionUC {
+	return &usecase{
+		config:       config,
+		ruleRepo:     repo,
+		recipeUC:     recipeUC,
+		ingredientUC: ingredientUC,
+		userUC:       userUC,
+	}
+}
+
+// profile returns the dietary profile of the actor, or nil when it is ignored.
+func (uc *usecase) profile(ctx context.Context, actor recipe.Actor, ignore bool) (*user.DietaryProfile, error) {
+	if ignore {
+		return nil, nil
+	}
+
+	u, err := uc.userUC.GetUserByID(ctx, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &u.Dietary, nil
+}
+
+// load adds the ingredients to the catalogue, the ones outside of it are left out.
+func (uc *usecase) load(ctx context.Context, catalogue map[string]*ingredient.IngredientModel, ids ...string) error {
+	for _, id := range ids {
+		if _, ok := catalogue[id]; ok || id == "" {
+			continue
+		}
+
+		known, err := uc.ingredientUC.GetIngredientByID(ctx, id)
+		if errors.Is(err, database.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		catalogue[id] = known
+	}
+
+	return nil
+}
+
+func (uc *usecase) ListSubstitutes(ctx context.Context, actor recipe.Actor, ingredientID string, ignoreProfile bool) ([]*substitution.RuleModel, error) {
+	if _, err := uc.ingredientUC.GetIngredientByID(ctx, ingredientID); err != nil {
+		return nil, err
+	}
+
+	rules, err := uc.ruleRepo.ListRules(ctx, ingredientID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := uc.profile(ctx, actor, ignoreProfile)
+	if err != nil || profile == nil {
+		return append([]*substitution.RuleModel{}, rules...), err
+	}
+
+	fitting := []*substitution.RuleModel{}
+	for _, rule := range rules {
+		fits := true
+		for _, r := range rule.Replacements {
+			known, err := uc.ingredientUC.GetIngredientByID(ctx, r.IngredientID)
+			if err != nil {
+				return nil, err
+			}
+			fits = fits && substitution.Conflict(known, *profile) == ""
+		}
+
+		if fits {
+			fitting = append(fitting, rule)
+		}
+	}
+
+	return fitting, nil
+}
+
+func (uc *usecase) SubstituteRecipe(ctx context.Context, actor recipe.Actor, recipeID string, opts substitution.SubstituteOptions) (*substitution.SubstitutedRecipeModel, error) {
+	r, err := uc.recipeUC.GetRecipeByID(ctx, actor, recipeID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferred := map[string][]*substitution.RuleModel{}
+	for _, id := range opts.Rules {
+		rule, err := uc.ruleRepo.GetRule(ctx, id)
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, substitution.ErrUnknownRule
+		}
+		if err != nil {
+			return nil, err
+		}
+		preferred[rule.IngredientID] = append(preferred[rule.IngredientID], rule)
+	}
+
+	profile, err := uc.profile(ctx, actor, opts.IgnoreProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	swapper := &substitution.Swapper{
+		Rules:     map[string][]*substitution.RuleModel{},
+		Catalogue: map[string]*ingredient.IngredientModel{},
+		Profile:   profile,
+		Replace:   opts.Replace,
+	}
+
+	for _, line := range r.Ingredients {
+		if err := uc.load(ctx, swapper.Catalogue, line.IngredientID); err != nil {
+			return nil, err
+		}
+
+		if _, ok := swapper.Rules[line.IngredientID]; ok || line.IngredientID == "" {
+			continue
+		}
+
+		rules, err := uc.ruleRepo.ListRules(ctx, line.IngredientID)
+		if err != nil {
+			return nil, err
+		}
+
+		// the rules picked by the user are tried first, the others in knowledge base order
+		candidates := slices.Clone(preferred[line.IngredientID])
+		for _, rule := range rules {
+			if !slices.Contains(candidates, rule) {
+				candidates = append(candidates, rule)
+			}
+		}
+		swapper.Rules[line.IngredientID] = candidates
+
+		for _, rule := range candidates {
+			for _, replacement := range rule.Replacements {
+				if err := uc.load(ctx, swapper.Catalogue, replacement.IngredientID); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	// swapping after scaling keeps the replacement amounts in line with the servings
+	result := swapper.Apply(r.Scale(opts.View.Servings))
+	result.Recipe = result.Recipe.ConvertUnits(opts.View.UnitSystem, swapper.Catalogue)
+	for i := range result.Swaps {
+		swap := &result.Swaps[i]
+		swap.Original = convertLines([]recipe.IngredientModel{swap.Original}, opts.View.UnitSystem, swapper.Catalogue)[0]
+		swap.Replacements = convertLines(swap.Replacements, opts.View.UnitSystem, swapper.Catalogue)
+	}
+	for i := range result.Unresolved {
+		unresolved := &result.Unresolved[i]
+		unresolved.Ingredient = convertLines([]recipe.IngredientModel{unresolved.Ingredient}, opts.View.UnitSystem, swapper.Catalogue)[0]
+	}
+
+	return result, nil
+}
+
+// convertLines expresses ingredient lines in a unit system the way recipes are.
+func convertLines(lines []recipe.IngredientModel, system units.System, catalogue map[string]*ingredient.IngredientModel) []recipe.IngredientModel {
+	return (&recipe.RecipeModel{Ingredients: lines}).ConvertUnits(system, catalogue).Ingredients
+}
@@ -0,0 +1,55 @@
+package substitution
+
+import "flove/job/internal/recipe"
+
+// RuleModel is an entry of the substitution knowledge base: an ingredient and what can
+// stand in for it. Ratios are amounts of a replacement per unit of the original. Without
+// a Unit they apply in the unit of the recipe line, with one the line is first expressed
+// in that unit and every replacement carries its own unit. Context tells the kind of
+// dish the swap suits, Notes how to go about it.
+type RuleModel struct {
+	ID           string
+	IngredientID string
+	Unit         string
+	Replacements []ReplacementModel
+	Context      string
+	Notes        string
+}
+
+// ReplacementModel is an ingredient a rule swaps in, Name comes from the catalogue.
+type ReplacementModel struct {
+	IngredientID string
+	Name         string
+	Ratio        float64
+	Unit         string
+}
+
+// Reasons an ingredient of a recipe is swapped.
+const (
+	ReasonRequested = "requested"
+	ReasonAllergen  = "allergen"
+	ReasonDisliked  = "disliked"
+	ReasonDiet      = "diet"
+)
+
+// SwapModel is a recipe line replaced by the lines of a rule.
+type SwapModel struct {
+	Original     recipe.IngredientModel
+	Replacements []recipe.IngredientModel
+	Reason       string
+	Rule         *RuleModel
+}
+
+// UnresolvedModel is a recipe line that should be swapped but that no rule fits.
+type UnresolvedModel struct {
+	Ingredient recipe.IngredientModel
+	Reason     string
+}
+
+// SubstitutedRecipeModel is a recipe with swaps applied. Its nutrition, allergens and
+// diet labels are those of the swapped version.
+type SubstitutedRecipeModel struct {
+	Recipe     *recipe.RecipeModel
+	Swaps      []SwapModel
+	Unresolved []UnresolvedModel
+}
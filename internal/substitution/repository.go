@@ -0,0 +1,9 @@
+package substitution
+
+import "context"
+
+type RuleRepository interface {
+	GetRule(ctx context.Context, id string) (*RuleModel, error)
+	// ListRules lists the rules replacing the ingredient, best swaps first.
+	ListRules(ctx context.Context, ingredientID string) ([]*RuleModel, error)
+}
@@ -0,0 +1,14 @@
+package substitution
+
+import (
+	"context"
+	"flove/job/internal/recipe"
+)
+
+type SubstitutionUC interface {
+	// ListSubstitutes lists the rules replacing an ingredient, leaving out the ones that
+	// do not fit the dietary profile of the actor unless ignoreProfile is set.
+	ListSubstitutes(ctx context.Context, actor recipe.Actor, ingredientID string, ignoreProfile bool) ([]*RuleModel, error)
+	// SubstituteRecipe returns a recipe the actor can see with swaps applied, it is not saved.
+	SubstituteRecipe(ctx context.Context, actor recipe.Actor, recipeID string, opts SubstituteOptions) (*SubstitutedRecipeModel, error)
+}
@@ -1,6 +1,13 @@
 package user
 
+import "flove/job/pkg/units"
+
 type UpdateUserDTO struct {
-	Role  *Role   `bson:"role,omitempty"`
-	Phone *string `bson:"phone,omitempty"`
+	Role       *Role         `bson:"role,omitempty"`
+	Phone      *string       `bson:"phone,omitempty"`
+	UnitSystem *units.System `bson:"unit_system,omitempty"`
+	// Dietary replaces the whole dietary profile when set.
+	Dietary *DietaryProfile `bson:"dietary_profile,omitempty"`
+	// Goals replaces the daily nutrition goals when set.
+	Goals *NutritionGoals `bson:"nutrition_goals,omitempty"`
 }
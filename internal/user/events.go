@@ -0,0 +1,54 @@
+package user
+
+import (
+	"encoding/json"
+	"flove/job/internal/base/database"
+)
+
+// Types of the events published by the user use cases.
+const (
+	EventCreated = "user:created"
+	EventDeleted = "user:deleted"
+)
+
+type UserCreated struct {
+	ID string `json:"id"`
+}
+
+func (UserCreated) EventType() string { return EventCreated }
+func (UserCreated) EventVersion() int { return 1 }
+
+// Upcasters reads the legacy messages holding the bare user ID.
+func (UserCreated) Upcasters() map[int]database.Upcaster {
+	return map[int]database.Upcaster{
+		database.LegacyVersion: func(payload json.RawMessage) (json.RawMessage, error) {
+			fields, err := database.LegacyFields(payload, 1)
+			if err != nil {
+				return nil, err
+			}
+
+			return json.Marshal(UserCreated{ID: fields[0]})
+		},
+	}
+}
+
+type UserDeleted struct {
+	ID string `json:"id"`
+}
+
+func (UserDeleted) EventType() string { return EventDeleted }
+func (UserDeleted) EventVersion() int { return 1 }
+
+// Upcasters reads the legacy messages holding the bare user ID.
+func (UserDeleted) Upcasters() map[int]database.Upcaster {
+	return map[int]database.Upcaster{
+		database.LegacyVersion: func(payload json.RawMessage) (json.RawMessage, error) {
+			fields, err := database.LegacyFields(payload, 1)
+			if err != nil {
+				return nil, err
+			}
+
+			return json.Marshal(UserDeleted{ID: fields[0]})
+		},
+	}
+}
@@ -3,6 +3,7 @@ package user
 import (
 	"flove/job/internal/base/database"
 	"flove/job/internal/base/response"
+	"flove/job/pkg/units"
 	"net/http"
 	"time"
 
@@ -67,6 +68,51 @@ func (h *UserHandler) CreateUser(ctx *gin.Context) {
 	})
 }
 
+type dietaryProfile struct {
+	ExcludedAllergens   []string `json:"excluded_allergens" example:"gluten,peanuts"`
+	Diet                string   `json:"diet" binding:"omitempty,oneof=vegan vegetarian pescatarian keto gluten-free dairy-free" example:"vegetarian"`
+	DislikedIngredients []string `json:"disliked_ingredients" example:"cilantro"`
+	MaxCalories         float64  `json:"max_calories" binding:"omitempty,gte=0" example:"600"`
+}
+
+func (p *dietaryProfile) toModel() *DietaryProfile {
+	return &DietaryProfile{
+		ExcludedAllergens:   p.ExcludedAllergens,
+		Diet:                p.Diet,
+		DislikedIngredients: p.DislikedIngredients,
+		MaxCalories:         p.MaxCalories,
+	}
+}
+
+func toDietaryProfile(p DietaryProfile) dietaryProfile {
+	return dietaryProfile{
+		ExcludedAllergens:   p.ExcludedAllergens,
+		Diet:                p.Diet,
+		DislikedIngredients: p.DislikedIngredients,
+		MaxCalories:         p.MaxCalories,
+	}
+}
+
+type nutritionGoals struct {
+	Calories      float64 `json:"calories" binding:"gte=0" example:"2000"`
+	Protein       float64 `json:"protein" binding:"gte=0" example:"90"`
+	Fat           float64 `json:"fat" binding:"gte=0" example:"70"`
+	Carbohydrates float64 `json:"carbohydrates" binding:"gte=0" example:"250"`
+	Fiber         float64 `json:"fiber" binding:"gte=0" example:"30"`
+	Sugar         float64 `json:"sugar" binding:"gte=0" example:"50"`
+	Sodium        float64 `json:"sodium" binding:"gte=0" example:"2300"`
+}
+
+type updateUserRequest struct {
+	Phone      *string `json:"phone" binding:"omitempty,e164"`
+	UnitSystem *string `json:"unit_system" binding:"omitempty,oneof=metric imperial" example:"metric"`
+	// DietaryProfile replaces the stored profile, it filters searches and recommendations.
+	DietaryProfile *dietaryProfile `json:"dietary_profile" binding:"omitempty"`
+	// NutritionGoals replaces the daily goals the meal planner compares days with,
+	// sugar and sodium are upper limits.
+	NutritionGoals *nutritionGoals `json:"nutrition_goals" binding:"omitempty"`
+}
+
 // @Summary Update user information
 // @Description Update information of the currently logged in user
 // @Security BasicAuthcAuth
@@ -74,15 +120,15 @@ func (h *UserHandler) CreateUser(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 //
+//	@Param request body updateUserRequest true "User information"
+//
 // @Success 200 {object} response.Response
 // @Failure 400 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /users [patch]
 func (h *UserHandler) UpdateUser(ctx *gin.Context) {
-	var req struct {
-		Phone *string `json:"phone" binding:"omitempty,e164"`
-	}
+	var req updateUserRequest
 
 	err := ctx.ShouldBindJSON(&req)
 	if err != nil {
@@ -92,9 +138,25 @@ func (h *UserHandler) UpdateUser(ctx *gin.Context) {
 
 	userID := ctx.MustGet("userID").(string)
 
-	err = h.userUC.UpdateUser(ctx, userID, UpdateUserDTO{
+	dto := UpdateUserDTO{
 		Phone: req.Phone,
-	})
+	}
+
+	if req.UnitSystem != nil {
+		system := units.ParseSystem(*req.UnitSystem)
+		dto.UnitSystem = &system
+	}
+
+	if req.DietaryProfile != nil {
+		dto.Dietary = req.DietaryProfile.toModel()
+	}
+
+	if req.NutritionGoals != nil {
+		goals := NutritionGoals(*req.NutritionGoals)
+		dto.Goals = &goals
+	}
+
+	err = h.userUC.UpdateUser(ctx, userID, dto)
 	if err != nil {
 		switch err {
 		case database.ErrNotFound:
@@ -118,9 +180,9 @@ func (h *UserHandler) UpdateUser(ctx *gin.Context) {
 // @Failure 400 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Failure 500 {object} response.Response
-// @Router /users/{userID} [get]
+// @Router /users [get]
 func (h *UserHandler) GetUserInfo(ctx *gin.Context) {
-	userID := ctx.Param("userID")
+	userID := ctx.MustGet("userID").(string)
 	user, err := h.userUC.GetUserByID(ctx, userID)
 	if err != nil {
 		switch err {
@@ -133,13 +195,19 @@ func (h *UserHandler) GetUserInfo(ctx *gin.Context) {
 	}
 
 	response.WriteResponseWithBody(ctx, http.StatusOK, "success", struct {
-		Username string `json:"username"`
-		Email    string `json:"email"`
-		Phone    string `json:"phone"`
+		Username       string         `json:"username"`
+		Email          string         `json:"email"`
+		Phone          string         `json:"phone"`
+		UnitSystem     string         `json:"unit_system,omitempty"`
+		DietaryProfile dietaryProfile `json:"dietary_profile"`
+		NutritionGoals nutritionGoals `json:"nutrition_goals"`
 	}{
-		Username: user.Username,
-		Email:    user.Email,
-		Phone:    user.Phone,
+		Username:       user.Username,
+		Email:          user.Email,
+		Phone:          user.Phone,
+		UnitSystem:     string(user.UnitSystem),
+		DietaryProfile: toDietaryProfile(user.Dietary),
+		NutritionGoals: nutritionGoals(user.Goals),
 	})
 }
 
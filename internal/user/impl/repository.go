@@ -6,6 +6,7 @@ import (
 	"flove/job/config"
 	"flove/job/internal/base/database"
 	"flove/job/internal/user"
+	"flove/job/pkg/units"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -18,14 +19,34 @@ const (
 )
 
 type userEntity struct {
-	UUID         primitive.ObjectID `bson:"_id,omitempty"`
-	Username     string             `bson:"username"`
-	Email        string             `bson:"email"`
-	Phone        string             `bson:"phone"`
-	PasswordHash []byte             `bson:"password"`
-	Role         user.Role          `bson:"role"`
-	CreatedAt    time.Time          `bson:"created_at"`
-	UpdatedAt    time.Time          `bson:"updated_at"`
+	UUID         primitive.ObjectID   `bson:"_id,omitempty"`
+	Username     string               `bson:"username"`
+	Email        string               `bson:"email"`
+	Phone        string               `bson:"phone"`
+	PasswordHash []byte               `bson:"password"`
+	Role         user.Role            `bson:"role"`
+	UnitSystem   units.System         `bson:"unit_system"`
+	Dietary      dietaryProfileEntity `bson:"dietary_profile"`
+	Goals        nutritionGoalsEntity `bson:"nutrition_goals"`
+	CreatedAt    time.Time            `bson:"created_at"`
+	UpdatedAt    time.Time            `bson:"updated_at"`
+}
+
+type dietaryProfileEntity struct {
+	ExcludedAllergens   []string `bson:"excluded_allergens"`
+	Diet                string   `bson:"diet"`
+	DislikedIngredients []string `bson:"disliked_ingredients"`
+	MaxCalories         float64  `bson:"max_calories"`
+}
+
+type nutritionGoalsEntity struct {
+	Calories      float64 `bson:"calories"`
+	Protein       float64 `bson:"protein"`
+	Fat           float64 `bson:"fat"`
+	Carbohydrates float64 `bson:"carbohydrates"`
+	Fiber         float64 `bson:"fiber"`
+	Sugar         float64 `bson:"sugar"`
+	Sodium        float64 `bson:"sodium"`
 }
 
 func (e *userEntity) toUserModel() *user.UserModel {
@@ -36,6 +57,9 @@ func (e *userEntity) toUserModel() *user.UserModel {
 		Phone:        e.Phone,
 		PasswordHash: e.PasswordHash,
 		Role:         e.Role,
+		UnitSystem:   e.UnitSystem,
+		Dietary:      user.DietaryProfile(e.Dietary),
+		Goals:        user.NutritionGoals(e.Goals),
 		CreatedAt:    e.CreatedAt,
 		UpdatedAt:    e.UpdatedAt,
 	}
@@ -48,6 +72,9 @@ func toEntity(u *user.UserModel) *userEntity {
 		Phone:        u.Phone,
 		PasswordHash: u.PasswordHash,
 		Role:         u.Role,
+		UnitSystem:   u.UnitSystem,
+		Dietary:      dietaryProfileEntity(u.Dietary),
+		Goals:        nutritionGoalsEntity(u.Goals),
 		CreatedAt:    u.CreatedAt,
 		UpdatedAt:    u.UpdatedAt,
 	}
@@ -109,14 +136,23 @@ func (repo *repository) GetUserByID(ctx context.Context, id string) (*user.UserM
 }
 
 func (repo *repository) UpdateUser(ctx context.Context, userID string, updates any) error {
-	filter := bson.M{"_id": userID}
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return database.ErrNotFound
+	}
+
+	filter := bson.M{"_id": objectID}
 	update := map[string]any{"$set": updates}
 
-	_, err := repo.db.Collection(usersCollection).UpdateOne(ctx, filter, update)
+	result, err := repo.db.Collection(usersCollection).UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
 	}
 
+	if result.MatchedCount == 0 {
+		return database.ErrNotFound
+	}
+
 	return nil
 }
 
@@ -21,12 +21,12 @@ func NewUserUC(config *config.Config, eventBus *database.EventBus, userRepositor
 	}
 }
 
-func (uc *useCase) CreateUser(ctx context.Context, user *user.UserModel) error {
-	if err := uc.userRepo.CreateUser(ctx, user); err != nil {
+func (uc *useCase) CreateUser(ctx context.Context, u *user.UserModel) error {
+	if err := uc.userRepo.CreateUser(ctx, u); err != nil {
 		return err
 	}
 
-	if err := uc.eventBus.Publish("user:created", user.ID); err != nil {
+	if err := uc.eventBus.Publish(user.UserCreated{ID: u.ID}); err != nil {
 		return err
 	}
 
@@ -57,7 +57,7 @@ func (uc *useCase) DeleteUser(ctx context.Context, userID string) error {
 		return err
 	}
 
-	if err := uc.eventBus.Publish("user:deleted", userID); err != nil {
+	if err := uc.eventBus.Publish(user.UserDeleted{ID: userID}); err != nil {
 		return err
 	}
 
@@ -2,6 +2,8 @@ package user
 
 import (
 	"errors"
+	"flove/job/pkg/units"
+	"slices"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
@@ -14,10 +16,60 @@ type UserModel struct {
 	Phone        string
 	PasswordHash []byte
 	Role         Role
+	UnitSystem   units.System
+	Dietary      DietaryProfile
+	Goals        NutritionGoals
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
 
+// DietaryProfile describes what a user can or wants to eat. DislikedIngredients holds
+// catalogue ingredient IDs and MaxCalories is a per serving ceiling, zero means no limit.
+type DietaryProfile struct {
+	ExcludedAllergens   []string
+	Diet                string
+	DislikedIngredients []string
+	MaxCalories         float64
+}
+
+// Restrict narrows recipe filters down to recipes that do not violate the profile: its
+// allergens, disliked ingredients and diet join the lists and the lower calorie ceiling
+// wins. Recipe searches and recommendations both filter through it.
+func (p DietaryProfile) Restrict(excludeAllergens, dietLabels, excludeIngredients *[]string, maxCalories *float64) {
+	*excludeAllergens = union(*excludeAllergens, p.ExcludedAllergens)
+	*excludeIngredients = union(*excludeIngredients, p.DislikedIngredients)
+
+	if p.Diet != "" {
+		*dietLabels = union(*dietLabels, []string{p.Diet})
+	}
+
+	if p.MaxCalories > 0 && (*maxCalories == 0 || p.MaxCalories < *maxCalories) {
+		*maxCalories = p.MaxCalories
+	}
+}
+
+func union(a, b []string) []string {
+	for _, v := range b {
+		if !slices.Contains(a, v) {
+			a = append(a, v)
+		}
+	}
+
+	return a
+}
+
+// NutritionGoals are the daily amounts a user aims for, zero means no goal. Sugar and
+// Sodium are upper limits, the other values are targets to reach.
+type NutritionGoals struct {
+	Calories      float64
+	Protein       float64
+	Fat           float64
+	Carbohydrates float64
+	Fiber         float64
+	Sugar         float64
+	Sodium        float64
+}
+
 type Role int
 
 const (
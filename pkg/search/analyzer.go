@@ -0,0 +1,70 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/blevesearch/go-porterstemmer"
+)
+
+// Token is an analyzed word. Term is the stemmed form used for matching, Start and End
+// are the byte offsets of the word in the original text and are used for highlighting.
+type Token struct {
+	Term  string
+	Start int
+	End   int
+}
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"by": true, "for": true, "from": true, "in": true, "into": true, "is": true, "it": true,
+	"of": true, "on": true, "or": true, "the": true, "then": true, "to": true, "until": true,
+	"with": true,
+}
+
+// Analyze splits the text into lowercased words, drops stop words and reduces the rest
+// to their stem, so that "tomatoes" matches "tomato".
+func Analyze(text string) []Token {
+	var tokens []Token
+
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+
+		word := strings.ToLower(text[start:end])
+		if !stopWords[word] {
+			tokens = append(tokens, Token{Term: porterstemmer.StemString(word), Start: start, End: end})
+		}
+		start = -1
+	}
+
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		flush(i)
+	}
+	flush(len(text))
+
+	return tokens
+}
+
+// terms returns the distinct terms of the text in the order they first appear.
+func terms(text string) []string {
+	seen := map[string]bool{}
+
+	var result []string
+	for _, token := range Analyze(text) {
+		if !seen[token.Term] {
+			seen[token.Term] = true
+			result = append(result, token.Term)
+		}
+	}
+
+	return result
+}
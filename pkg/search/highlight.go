@@ -0,0 +1,92 @@
+package search
+
+import (
+	"html"
+	"strings"
+)
+
+const (
+	// fragmentSize is the longest fragment in bytes, shorter fields are returned whole.
+	fragmentSize = 160
+	// fragmentLead is the number of words kept in front of the first match of a fragment.
+	fragmentLead = 5
+	maxFragments = 3
+
+	markStart = "<mark>"
+	markEnd   = "</mark>"
+)
+
+// Highlight returns fragments of the text with the words matching the terms wrapped in
+// <mark> tags, or nil when nothing matches. The fragments are HTML-safe: the text around
+// and inside the tags is escaped, so they can be rendered as markup as they are.
+func Highlight(text string, terms map[string]bool) []string {
+	tokens := Analyze(text)
+
+	var matches []int
+	for i, token := range tokens {
+		if terms[token.Term] {
+			matches = append(matches, i)
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil
+	}
+
+	if len(text) <= fragmentSize {
+		return []string{mark(text, 0, len(text), tokens, terms)}
+	}
+
+	var fragments []string
+	end := -1
+	for _, match := range matches {
+		if tokens[match].Start < end {
+			continue
+		}
+
+		first := max(0, match-fragmentLead)
+		last := first
+		for last+1 < len(tokens) && tokens[last+1].End-tokens[first].Start <= fragmentSize {
+			last++
+		}
+		last = max(last, match)
+
+		start := tokens[first].Start
+		end = tokens[last].End
+
+		fragment := mark(text, start, end, tokens, terms)
+		if start > 0 {
+			fragment = "…" + fragment
+		}
+		if end < len(text) {
+			fragment += "…"
+		}
+
+		fragments = append(fragments, fragment)
+		if len(fragments) == maxFragments {
+			break
+		}
+	}
+
+	return fragments
+}
+
+func mark(text string, start, end int, tokens []Token, terms map[string]bool) string {
+	var b strings.Builder
+
+	position := start
+	for _, token := range tokens {
+		if token.Start < start || token.End > end || !terms[token.Term] {
+			continue
+		}
+
+		b.WriteString(html.EscapeString(text[position:token.Start]))
+		b.WriteString(markStart)
+		b.WriteString(html.EscapeString(text[token.Start:token.End]))
+		b.WriteString(markEnd)
+		position = token.End
+	}
+	b.WriteString(html.EscapeString(text[position:end]))
+
+	return b.String()
+}
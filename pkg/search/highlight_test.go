@@ -0,0 +1,82 @@
+package search
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		terms []string
+		want  []string
+	}{
+		{
+			name:  "no match",
+			text:  "Boil the water",
+			terms: []string{"tomato"},
+			want:  nil,
+		},
+		{
+			name:  "marks the matched words",
+			text:  "Add the tomatoes and basil",
+			terms: []string{"tomato", "basil"},
+			want:  []string{"Add the <mark>tomatoes</mark> and <mark>basil</mark>"},
+		},
+		{
+			name:  "escapes markup around the matches",
+			text:  "Salt & pepper <b>tomato</b>",
+			terms: []string{"tomato"},
+			want:  []string{"Salt &amp; pepper &lt;b&gt;<mark>tomato</mark>&lt;/b&gt;"},
+		},
+		{
+			name:  "escapes text without a match between marks",
+			text:  "tomato < 5 & basil",
+			terms: []string{"tomato", "basil"},
+			want:  []string{"<mark>tomato</mark> &lt; 5 &amp; <mark>basil</mark>"},
+		},
+		{
+			name:  "matches case-insensitively",
+			text:  "TOMATO soup",
+			terms: []string{"tomato"},
+			want:  []string{"<mark>TOMATO</mark> soup"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			terms := map[string]bool{}
+			for _, term := range tt.terms {
+				terms[term] = true
+			}
+
+			if got := Highlight(tt.text, terms); !slices.Equal(got, tt.want) {
+				t.Errorf("Highlight(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlightFragments(t *testing.T) {
+	text := "tomato " + strings.Repeat("water ", 40) + "basil & " + strings.Repeat("water ", 40)
+
+	got := Highlight(text, map[string]bool{"tomato": true, "basil": true})
+	if len(got) != 2 {
+		t.Fatalf("Highlight returned %d fragments, want 2: %q", len(got), got)
+	}
+
+	if !strings.HasPrefix(got[0], "<mark>tomato</mark>") || !strings.HasSuffix(got[0], "…") {
+		t.Errorf("first fragment = %q, want it to start at the match and be cut", got[0])
+	}
+	if !strings.HasPrefix(got[1], "…") || !strings.Contains(got[1], "<mark>basil</mark> &amp;") {
+		t.Errorf("second fragment = %q, want a cut fragment with the escaped match", got[1])
+	}
+
+	for _, fragment := range got {
+		if len(fragment) > fragmentSize+len(markStart)+len(markEnd)+2*len("…")+len("&amp;") {
+			t.Errorf("fragment is %d bytes long: %q", len(fragment), fragment)
+		}
+	}
+}
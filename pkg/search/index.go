@@ -0,0 +1,267 @@
+// Package search is a small embedded full-text engine. Documents are made of named text
+// fields, each field carries a boost, and queries are ranked with BM25F over the analyzed
+// (lowercased, stop word free, stemmed) terms. The documents are persisted in an append
+// only log next to the index, the inverted index itself is rebuilt in memory on open.
+package search
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// BM25 parameters, k1 saturates the term frequency and b normalizes the field length.
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+// Document is a unit of search, Fields maps a field name to its text.
+type Document struct {
+	ID     string            `json:"id"`
+	Fields map[string]string `json:"fields"`
+}
+
+// Hit is a document matching a query. Highlights holds the marked up fragments of every
+// field that matched.
+type Hit struct {
+	ID         string
+	Score      float64
+	Highlights map[string][]string
+}
+
+type Index struct {
+	mu     sync.RWMutex
+	boosts map[string]float64
+	store  *store
+
+	docs map[string]Document
+	// postings maps a term to the frequency of the term per field per document
+	postings map[string]map[string]map[string]int
+	// lengths holds the number of terms per document per field
+	lengths map[string]map[string]int
+	// totals holds the number of terms per field over all documents
+	totals map[string]int
+}
+
+// Open loads the index stored in dir, creating it when missing. Fields without a boost
+// are stored but not searched.
+func Open(dir string, boosts map[string]float64) (*Index, error) {
+	idx := &Index{
+		boosts:   boosts,
+		docs:     map[string]Document{},
+		postings: map[string]map[string]map[string]int{},
+		lengths:  map[string]map[string]int{},
+		totals:   map[string]int{},
+	}
+
+	s, err := openStore(dir, func(op operation) {
+		switch op.Op {
+		case opPut:
+			idx.put(*op.Doc)
+		case opDelete:
+			idx.delete(op.ID)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.compact(idx.docs); err != nil {
+		s.close()
+		return nil, err
+	}
+
+	idx.store = s
+	return idx, nil
+}
+
+func (idx *Index) Close() error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	return idx.store.close()
+}
+
+// Len returns the number of indexed documents.
+func (idx *Index) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return len(idx.docs)
+}
+
+// Put adds the document or replaces the stored one with the same ID.
+func (idx *Index) Put(doc Document) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if err := idx.store.append(operation{Op: opPut, Doc: &doc}); err != nil {
+		return err
+	}
+
+	idx.put(doc)
+	return nil
+}
+
+// Delete removes the document, deleting a missing document is not an error.
+func (idx *Index) Delete(id string) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if _, ok := idx.docs[id]; !ok {
+		return nil
+	}
+
+	if err := idx.store.append(operation{Op: opDelete, ID: id}); err != nil {
+		return err
+	}
+
+	idx.delete(id)
+	return nil
+}
+
+func (idx *Index) put(doc Document) {
+	idx.delete(doc.ID)
+
+	idx.docs[doc.ID] = doc
+	idx.lengths[doc.ID] = map[string]int{}
+
+	for field, text := range doc.Fields {
+		if _, ok := idx.boosts[field]; !ok {
+			continue
+		}
+
+		tokens := Analyze(text)
+		idx.lengths[doc.ID][field] = len(tokens)
+		idx.totals[field] += len(tokens)
+
+		for _, token := range tokens {
+			fields, ok := idx.postings[token.Term]
+			if !ok {
+				fields = map[string]map[string]int{}
+				idx.postings[token.Term] = fields
+			}
+
+			if fields[field] == nil {
+				fields[field] = map[string]int{}
+			}
+			fields[field][doc.ID]++
+		}
+	}
+}
+
+func (idx *Index) delete(id string) {
+	doc, ok := idx.docs[id]
+	if !ok {
+		return
+	}
+
+	for field, text := range doc.Fields {
+		if _, ok := idx.boosts[field]; !ok {
+			continue
+		}
+
+		for _, term := range terms(text) {
+			fields := idx.postings[term]
+			delete(fields[field], id)
+			if len(fields[field]) == 0 {
+				delete(fields, field)
+			}
+			if len(fields) == 0 {
+				delete(idx.postings, term)
+			}
+		}
+
+		idx.totals[field] -= idx.lengths[id][field]
+	}
+
+	delete(idx.docs, id)
+	delete(idx.lengths, id)
+}
+
+// Search returns up to limit documents containing any of the query terms, best first.
+func (idx *Index) Search(query string, limit int) []Hit {
+	queryTerms := terms(query)
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	n := float64(len(idx.docs))
+	averages := map[string]float64{}
+	for field := range idx.boosts {
+		if n > 0 {
+			averages[field] = float64(idx.totals[field]) / n
+		}
+	}
+
+	scores := map[string]float64{}
+	for _, term := range queryTerms {
+		fields := idx.postings[term]
+
+		matching := map[string]bool{}
+		for _, docs := range fields {
+			for id := range docs {
+				matching[id] = true
+			}
+		}
+
+		df := float64(len(matching))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+
+		for id := range matching {
+			// BM25F: the field frequencies are normalized and boosted before saturation
+			tf := 0.0
+			for field, docs := range fields {
+				frequency := float64(docs[id])
+				if frequency == 0 {
+					continue
+				}
+
+				norm := 1.0
+				if averages[field] > 0 {
+					norm = 1 - b + b*float64(idx.lengths[id][field])/averages[field]
+				}
+
+				tf += idx.boosts[field] * frequency / norm
+			}
+
+			scores[id] += idf * tf / (k1 + tf)
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit{ID: id, Score: score})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ID < hits[j].ID
+	})
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	highlighted := map[string]bool{}
+	for _, term := range queryTerms {
+		highlighted[term] = true
+	}
+
+	for i := range hits {
+		hits[i].Highlights = map[string][]string{}
+		for field, text := range idx.docs[hits[i].ID].Fields {
+			if _, ok := idx.boosts[field]; !ok {
+				continue
+			}
+			if fragments := Highlight(text, highlighted); fragments != nil {
+				hits[i].Highlights[field] = fragments
+			}
+		}
+	}
+
+	return hits
+}
@@ -0,0 +1,147 @@
+package search
+
+import (
+	"slices"
+	"testing"
+)
+
+var testBoosts = map[string]float64{"name": 4, "steps": 1}
+
+func TestSearchRanking(t *testing.T) {
+	tests := []struct {
+		name  string
+		docs  []Document
+		query string
+		want  []string
+	}{
+		{
+			name: "name outweighs steps",
+			docs: []Document{
+				{ID: "steps", Fields: map[string]string{"name": "Soup", "steps": "Add the tomato"}},
+				{ID: "name", Fields: map[string]string{"name": "Tomato", "steps": "Boil some water"}},
+			},
+			query: "tomato",
+			want:  []string{"name", "steps"},
+		},
+		{
+			name: "more frequent term first",
+			docs: []Document{
+				{ID: "once", Fields: map[string]string{"steps": "tomato bean soup"}},
+				{ID: "twice", Fields: map[string]string{"steps": "tomato tomato soup"}},
+			},
+			query: "tomato",
+			want:  []string{"twice", "once"},
+		},
+		{
+			name: "shorter field first",
+			docs: []Document{
+				{ID: "long", Fields: map[string]string{"steps": "tomato bean soup with carrot onion garlic"}},
+				{ID: "short", Fields: map[string]string{"steps": "tomato soup"}},
+			},
+			query: "tomato",
+			want:  []string{"short", "long"},
+		},
+		{
+			name: "more query terms first",
+			docs: []Document{
+				{ID: "one", Fields: map[string]string{"name": "Tomato salad"}},
+				{ID: "both", Fields: map[string]string{"name": "Tomato basil salad"}},
+				{ID: "none", Fields: map[string]string{"name": "Green salad"}},
+			},
+			query: "tomato basil",
+			want:  []string{"both", "one"},
+		},
+		{
+			name: "rare term outweighs common one",
+			docs: []Document{
+				{ID: "common", Fields: map[string]string{"name": "Salad bowl"}},
+				{ID: "rare", Fields: map[string]string{"name": "Basil bowl"}},
+				{ID: "other", Fields: map[string]string{"name": "Salad plate"}},
+			},
+			query: "salad basil",
+			want:  []string{"rare", "common", "other"},
+		},
+		{
+			name: "stems match",
+			docs: []Document{
+				{ID: "plural", Fields: map[string]string{"name": "Roasted tomatoes"}},
+			},
+			query: "tomato",
+			want:  []string{"plural"},
+		},
+		{
+			name: "unboosted fields are not searched",
+			docs: []Document{
+				{ID: "hidden", Fields: map[string]string{"author": "tomato"}},
+			},
+			query: "tomato",
+			want:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idx, err := Open(t.TempDir(), testBoosts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer idx.Close()
+
+			for _, doc := range tt.docs {
+				if err := idx.Put(doc); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got := []string{}
+			for _, hit := range idx.Search(tt.query, 0) {
+				got = append(got, hit.ID)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchLimitAndDelete(t *testing.T) {
+	dir := t.TempDir()
+
+	idx, err := Open(dir, testBoosts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"a", "b", "c"} {
+		if err := idx.Put(Document{ID: id, Fields: map[string]string{"name": "tomato"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := idx.Delete("b"); err != nil {
+		t.Fatal(err)
+	}
+
+	if hits := idx.Search("tomato", 1); len(hits) != 1 || hits[0].ID != "a" {
+		t.Errorf("Search with limit 1 = %v, want [a]", hits)
+	}
+
+	if err := idx.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the index is rebuilt from the log on open
+	idx, err = Open(dir, testBoosts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer idx.Close()
+
+	var got []string
+	for _, hit := range idx.Search("tomato", 0) {
+		got = append(got, hit.ID)
+	}
+	if !slices.Equal(got, []string{"a", "c"}) {
+		t.Errorf("Search after reopening = %v, want [a c]", got)
+	}
+}
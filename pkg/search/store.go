@@ -0,0 +1,134 @@
+package search
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	logFile = "documents.log"
+
+	opPut    = "put"
+	opDelete = "delete"
+)
+
+// operation is a line of the log, replaying the log in order restores the documents.
+type operation struct {
+	Op  string    `json:"op"`
+	ID  string    `json:"id,omitempty"`
+	Doc *Document `json:"doc,omitempty"`
+}
+
+type store struct {
+	dir  string
+	file *os.File
+}
+
+// openStore replays the log in dir and opens it for appending.
+func openStore(dir string, replay func(operation)) (*store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, logFile)
+
+	if file, err := os.Open(path); err == nil {
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+		line := 0
+		for scanner.Scan() {
+			line++
+
+			var op operation
+			if err := json.Unmarshal(scanner.Bytes(), &op); err != nil {
+				file.Close()
+				return nil, fmt.Errorf("search log %s line %d: %w", path, line, err)
+			}
+			replay(op)
+		}
+
+		err := scanner.Err()
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &store{dir: dir, file: file}, nil
+}
+
+func (s *store) append(op operation) error {
+	line, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.file.Write(append(line, '\n'))
+	return err
+}
+
+// compact rewrites the log with a single put per live document, so that replaced and
+// deleted documents do not slow down the next open.
+func (s *store) compact(docs map[string]Document) error {
+	path := filepath.Join(s.dir, logFile)
+	tmp := path + ".tmp"
+
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(docs))
+	for id := range docs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	w := bufio.NewWriter(file)
+	encoder := json.NewEncoder(w)
+	for _, id := range ids {
+		doc := docs[id]
+		if err := encoder.Encode(operation{Op: opPut, Doc: &doc}); err != nil {
+			file.Close()
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	s.file, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	return err
+}
+
+func (s *store) close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,234 @@
+// Package suggest implements an in-memory autocomplete index. Entries are matched on the
+// prefix of any of their words, small typos are tolerated through the edit distance
+// between the query and the closest prefix, and matches are ranked by popularity.
+package suggest
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Entry is a phrase a document can be found by, Kind tells what the phrase is,
+// for example a name or a tag.
+type Entry struct {
+	Text string
+	Kind string
+}
+
+// Suggestion is a phrase matching the query. Score is the summed weight of the
+// documents holding the phrase and Distance the number of edits the query needed.
+type Suggestion struct {
+	Text     string
+	Kind     string
+	DocIDs   []string
+	Score    float64
+	Distance int
+}
+
+type term struct {
+	text string
+	kind string
+	// words are the normalized suffixes of the text starting at every word boundary
+	words []string
+	docs  map[string]float64
+}
+
+type Index struct {
+	mu    sync.RWMutex
+	terms map[string]*term
+	docs  map[string][]string
+}
+
+func NewIndex() *Index {
+	return &Index{
+		terms: map[string]*term{},
+		docs:  map[string][]string{},
+	}
+}
+
+// Put replaces the entries of a document. Weight is the popularity of the document,
+// it adds up across the documents sharing an entry.
+func (idx *Index) Put(docID string, entries []Entry, weight float64) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(docID)
+
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		normalized := Normalize(entry.Text)
+		if normalized == "" {
+			continue
+		}
+
+		key := entry.Kind + "\x00" + normalized
+		t, ok := idx.terms[key]
+		if !ok {
+			t = &term{
+				text:  entry.Text,
+				kind:  entry.Kind,
+				words: wordSuffixes(normalized),
+				docs:  map[string]float64{},
+			}
+			idx.terms[key] = t
+		}
+
+		if _, ok := t.docs[docID]; !ok {
+			keys = append(keys, key)
+		}
+		t.docs[docID] = weight
+	}
+
+	idx.docs[docID] = keys
+}
+
+// Remove drops every entry of a document.
+func (idx *Index) Remove(docID string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(docID)
+}
+
+func (idx *Index) remove(docID string) {
+	for _, key := range idx.docs[docID] {
+		t := idx.terms[key]
+		delete(t.docs, docID)
+		if len(t.docs) == 0 {
+			delete(idx.terms, key)
+		}
+	}
+
+	delete(idx.docs, docID)
+}
+
+// Suggest returns up to limit entries matching the query. Exact prefix matches come first,
+// then matches needing more edits, ties are broken by score.
+func (idx *Index) Suggest(query string, limit int) []Suggestion {
+	q := Normalize(query)
+	if q == "" || limit <= 0 {
+		return []Suggestion{}
+	}
+
+	maxEdits := MaxEdits(q)
+
+	idx.mu.RLock()
+	var matches []Suggestion
+	for _, t := range idx.terms {
+		distance := maxEdits + 1
+		for _, word := range t.words {
+			distance = min(distance, PrefixDistance(q, word))
+			if distance == 0 {
+				break
+			}
+		}
+
+		if distance > maxEdits {
+			continue
+		}
+
+		suggestion := Suggestion{
+			Text:     t.text,
+			Kind:     t.kind,
+			DocIDs:   make([]string, 0, len(t.docs)),
+			Distance: distance,
+		}
+		for docID, weight := range t.docs {
+			suggestion.DocIDs = append(suggestion.DocIDs, docID)
+			suggestion.Score += weight
+		}
+		sort.Strings(suggestion.DocIDs)
+
+		matches = append(matches, suggestion)
+	}
+	idx.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Distance != b.Distance {
+			return a.Distance < b.Distance
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Text != b.Text {
+			return a.Text < b.Text
+		}
+		return a.Kind < b.Kind
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches
+}
+
+// Normalize lowercases the text and collapses everything but letters and digits into
+// single spaces.
+func Normalize(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+func wordSuffixes(normalized string) []string {
+	suffixes := []string{normalized}
+	for i, r := range normalized {
+		if r == ' ' {
+			suffixes = append(suffixes, normalized[i+1:])
+		}
+	}
+
+	return suffixes
+}
+
+// MaxEdits is the number of typos tolerated for a query, short queries have to be exact.
+func MaxEdits(query string) int {
+	switch n := len([]rune(query)); {
+	case n < 3:
+		return 0
+	case n < 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// PrefixDistance is the smallest edit distance between the query and any prefix of the
+// text, so that "chiken" is one edit away from "chicken soup". Swapping two adjacent
+// letters counts as a single edit.
+func PrefixDistance(query, text string) int {
+	q, t := []rune(query), []rune(text)
+
+	// rows[i%3][j] holds the distance between q[:i] and t[:j]
+	var rows [3][]int
+	for i := range rows {
+		rows[i] = make([]int, len(t)+1)
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(q); i++ {
+		current, previous, beforePrevious := rows[i%3], rows[(i-1)%3], rows[(i+1)%3]
+
+		current[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if q[i-1] == t[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if i > 1 && j > 1 && q[i-1] == t[j-2] && q[i-2] == t[j-1] {
+				current[j] = min(current[j], beforePrevious[j-2]+1)
+			}
+		}
+	}
+
+	return slices.Min(rows[len(q)%3])
+}
@@ -0,0 +1,38 @@
+package units
+
+import "strings"
+
+// densities holds g/ml for ingredients that are usually weighed rather than measured
+// by volume. Liquids are left out on purpose so that they stay in ml and cups.
+var densities = map[string]float64{
+	"flour":             0.53,
+	"all-purpose flour": 0.53,
+	"wheat flour":       0.53,
+	"bread flour":       0.55,
+	"whole wheat flour": 0.51,
+	"sugar":             0.85,
+	"white sugar":       0.85,
+	"brown sugar":       0.93,
+	"powdered sugar":    0.56,
+	"icing sugar":       0.56,
+	"butter":            0.91,
+	"rice":              0.85,
+	"oats":              0.41,
+	"rolled oats":       0.41,
+	"cocoa powder":      0.42,
+	"salt":              1.2,
+	"baking powder":     0.9,
+	"baking soda":       0.92,
+	"grated cheese":     0.42,
+	"breadcrumbs":       0.45,
+	"chopped nuts":      0.5,
+	"almonds":           0.6,
+	"honey":             1.42,
+	"peanut butter":     1.08,
+}
+
+// DensityOf returns the density in g/ml of a commonly weighed ingredient,
+// or 0 if it is not known.
+func DensityOf(ingredient string) float64 {
+	return densities[strings.ToLower(strings.TrimSpace(ingredient))]
+}
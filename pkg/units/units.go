@@ -0,0 +1,229 @@
+package units
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrUnknownUnit       = errors.New("unknown unit")
+	ErrIncompatibleUnits = errors.New("incompatible units")
+)
+
+type Dimension int
+
+const (
+	Count Dimension = iota
+	Mass
+	Volume
+	Temperature
+)
+
+type System string
+
+const (
+	Metric   System = "metric"
+	Imperial System = "imperial"
+)
+
+// Unit describes a unit of measure. Factor is the size of one unit in the base unit
+// of its dimension: grams for mass, millilitres for volume and pieces for count.
+// Units without a system (pieces, pinches) are never converted.
+type Unit struct {
+	Symbol     string
+	Dimension  Dimension
+	System     System
+	Factor     float64
+	Fractional bool
+	Preferred  bool
+	PreferFrom float64
+	Aliases    []string
+}
+
+var units = []Unit{
+	{Symbol: "pc", Dimension: Count, Factor: 1, Aliases: []string{"", "pcs", "piece", "pieces"}},
+	{Symbol: "clove", Dimension: Count, Factor: 1, Aliases: []string{"cloves"}},
+	{Symbol: "slice", Dimension: Count, Factor: 1, Aliases: []string{"slices"}},
+	{Symbol: "can", Dimension: Count, Factor: 1, Aliases: []string{"cans"}},
+	{Symbol: "bunch", Dimension: Count, Factor: 1, Aliases: []string{"bunches"}},
+	{Symbol: "pinch", Dimension: Count, Factor: 1, Fractional: true, Aliases: []string{"pinches"}},
+
+	{Symbol: "mg", Dimension: Mass, System: Metric, Factor: 0.001, Aliases: []string{"milligram", "milligrams"}},
+	{Symbol: "g", Dimension: Mass, System: Metric, Factor: 1, Preferred: true, PreferFrom: 1, Aliases: []string{"gram", "grams", "gr"}},
+	{Symbol: "kg", Dimension: Mass, System: Metric, Factor: 1000, Preferred: true, PreferFrom: 1, Aliases: []string{"kilogram", "kilograms"}},
+	{Symbol: "oz", Dimension: Mass, System: Imperial, Factor: 28.349523125, Preferred: true, PreferFrom: 1, Aliases: []string{"ounce", "ounces"}},
+	{Symbol: "lb", Dimension: Mass, System: Imperial, Factor: 453.59237, Preferred: true, PreferFrom: 1, Aliases: []string{"lbs", "pound", "pounds"}},
+
+	{Symbol: "ml", Dimension: Volume, System: Metric, Factor: 1, Preferred: true, PreferFrom: 1, Aliases: []string{"millilitre", "millilitres", "milliliter", "milliliters"}},
+	{Symbol: "l", Dimension: Volume, System: Metric, Factor: 1000, Preferred: true, PreferFrom: 1, Aliases: []string{"litre", "litres", "liter", "liters"}},
+	{Symbol: "tsp", Dimension: Volume, System: Imperial, Factor: 4.92892159375, Fractional: true, Preferred: true, PreferFrom: 0, Aliases: []string{"teaspoon", "teaspoons"}},
+	{Symbol: "tbsp", Dimension: Volume, System: Imperial, Factor: 14.78676478125, Fractional: true, Preferred: true, PreferFrom: 1, Aliases: []string{"tablespoon", "tablespoons"}},
+	{Symbol: "fl oz", Dimension: Volume, System: Imperial, Factor: 29.5735295625, Aliases: []string{"fluid ounce", "fluid ounces"}},
+	{Symbol: "cup", Dimension: Volume, System: Imperial, Factor: 236.5882365, Fractional: true, Preferred: true, PreferFrom: 0.25, Aliases: []string{"cups"}},
+	{Symbol: "pint", Dimension: Volume, System: Imperial, Factor: 473.176473, Aliases: []string{"pints", "pt"}},
+	{Symbol: "quart", Dimension: Volume, System: Imperial, Factor: 946.352946, Aliases: []string{"quarts", "qt"}},
+	{Symbol: "gallon", Dimension: Volume, System: Imperial, Factor: 3785.411784, Aliases: []string{"gallons", "gal"}},
+
+	{Symbol: "C", Dimension: Temperature, System: Metric, Factor: 1, Preferred: true, Aliases: []string{"°c", "celsius"}},
+	{Symbol: "F", Dimension: Temperature, System: Imperial, Factor: 1, Preferred: true, Aliases: []string{"°f", "fahrenheit"}},
+}
+
+var registry = map[string]*Unit{}
+
+func init() {
+	for i := range units {
+		u := &units[i]
+		registry[strings.ToLower(u.Symbol)] = u
+		for _, alias := range u.Aliases {
+			registry[alias] = u
+		}
+	}
+}
+
+// Lookup finds a unit by its symbol or one of its aliases, case-insensitively.
+func Lookup(name string) (*Unit, bool) {
+	u, ok := registry[strings.ToLower(strings.TrimSpace(name))]
+	return u, ok
+}
+
+// Convert converts a quantity between two units. Density in g/ml is needed to
+// convert between mass and volume and is ignored otherwise, pass 0 when unknown.
+func Convert(quantity float64, from, to string, density float64) (float64, error) {
+	fromUnit, ok := Lookup(from)
+	if !ok {
+		return 0, ErrUnknownUnit
+	}
+
+	toUnit, ok := Lookup(to)
+	if !ok {
+		return 0, ErrUnknownUnit
+	}
+
+	return convert(quantity, fromUnit, toUnit, density)
+}
+
+func convert(quantity float64, from, to *Unit, density float64) (float64, error) {
+	if from.Dimension == Temperature || to.Dimension == Temperature {
+		return convertTemperature(quantity, from, to)
+	}
+
+	base := quantity * from.Factor
+
+	switch {
+	case from.Dimension == to.Dimension:
+	case from.Dimension == Volume && to.Dimension == Mass && density > 0:
+		base *= density
+	case from.Dimension == Mass && to.Dimension == Volume && density > 0:
+		base /= density
+	default:
+		return 0, ErrIncompatibleUnits
+	}
+
+	return base / to.Factor, nil
+}
+
+func convertTemperature(value float64, from, to *Unit) (float64, error) {
+	if from.Dimension != Temperature || to.Dimension != Temperature {
+		return 0, ErrIncompatibleUnits
+	}
+
+	switch {
+	case from.System == to.System:
+		return value, nil
+	case to.System == Imperial:
+		return value*9/5 + 32, nil
+	default:
+		return (value - 32) * 5 / 9, nil
+	}
+}
+
+// ToSystem expresses a quantity in the most readable unit of the given system,
+// e.g. 1500 g becomes 1.5 kg in metric and 3.3 lb in imperial. With a known density,
+// volumes are weighed in metric and masses are measured by volume in imperial,
+// following how recipes are usually written. Unknown units and units that do not
+// belong to any system are returned unchanged.
+func ToSystem(quantity float64, unit string, system System, density float64) (float64, string) {
+	from, ok := Lookup(unit)
+	if !ok || from.System == "" || system == "" {
+		return quantity, unit
+	}
+
+	dimension := from.Dimension
+	if density > 0 {
+		switch {
+		case system == Metric && dimension == Volume:
+			dimension = Mass
+		case system == Imperial && dimension == Mass:
+			dimension = Volume
+		}
+	}
+
+	if from.System == system && dimension == from.Dimension {
+		return quantity, unit
+	}
+
+	converted, to := readable(quantity, from, system, dimension, density)
+	if to == nil {
+		return quantity, unit
+	}
+
+	return converted, to.Symbol
+}
+
+// Simplify expresses a quantity in the most readable unit of its dimension, e.g. 1500 g
+// becomes 1.5 kg and 24 tsp become 0.5 cup. Without a system the unit keeps its own,
+// masses stay masses and volumes stay volumes.
+func Simplify(quantity float64, unit string, system System) (float64, string) {
+	from, ok := Lookup(unit)
+	if !ok || from.System == "" || from.Dimension == Temperature {
+		return quantity, unit
+	}
+
+	if system == "" {
+		system = from.System
+	}
+
+	converted, to := readable(quantity, from, system, from.Dimension, 0)
+	if to == nil {
+		return quantity, unit
+	}
+
+	return converted, to.Symbol
+}
+
+// readable picks the largest preferred unit of the system and dimension that the
+// quantity reaches, falling back to the smallest one. It returns nil when no unit fits.
+func readable(quantity float64, from *Unit, system System, dimension Dimension, density float64) (float64, *Unit) {
+	var best *Unit
+	var bestQuantity float64
+	for i := range units {
+		candidate := &units[i]
+		if !candidate.Preferred || candidate.System != system || candidate.Dimension != dimension {
+			continue
+		}
+
+		converted, err := convert(quantity, from, candidate, density)
+		if err != nil {
+			continue
+		}
+
+		if best == nil || converted >= candidate.PreferFrom && candidate.Factor > best.Factor {
+			best = candidate
+			bestQuantity = converted
+		}
+	}
+
+	return bestQuantity, best
+}
+
+// ParseSystem returns the unit system with the given name, or an empty system.
+func ParseSystem(name string) System {
+	switch System(strings.ToLower(name)) {
+	case Metric:
+		return Metric
+	case Imperial:
+		return Imperial
+	default:
+		return ""
+	}
+}
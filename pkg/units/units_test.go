@@ -0,0 +1,101 @@
+package units
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity float64
+		from, to string
+		density  float64
+		want     float64
+		err      error
+	}{
+		{name: "kilograms to grams", quantity: 1.5, from: "kg", to: "g", want: 1500},
+		{name: "aliases and case", quantity: 2, from: "Tablespoons", to: "TSP", want: 6},
+		{name: "pounds to ounces", quantity: 1, from: "lb", to: "oz", want: 16},
+		{name: "cups to millilitres", quantity: 1, from: "cup", to: "ml", want: 236.5882365},
+		{name: "volume to mass with density", quantity: 100, from: "ml", to: "g", density: 0.5, want: 50},
+		{name: "mass to volume with density", quantity: 50, from: "g", to: "ml", density: 0.5, want: 100},
+		{name: "volume to mass without density", quantity: 100, from: "ml", to: "g", err: ErrIncompatibleUnits},
+		{name: "pieces to grams", quantity: 2, from: "pc", to: "g", err: ErrIncompatibleUnits},
+		{name: "celsius to fahrenheit", quantity: 180, from: "C", to: "F", want: 356},
+		{name: "fahrenheit to celsius", quantity: 212, from: "°f", to: "celsius", want: 100},
+		{name: "temperature to mass", quantity: 180, from: "C", to: "g", err: ErrIncompatibleUnits},
+		{name: "unknown unit", quantity: 1, from: "handful", to: "g", err: ErrUnknownUnit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Convert(tt.quantity, tt.from, tt.to, tt.density)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Convert() error = %v, want %v", err, tt.err)
+			}
+
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Convert(%v %s, %s) = %v, want %v", tt.quantity, tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	tests := []struct {
+		name         string
+		quantity     float64
+		unit         string
+		system       System
+		wantQuantity float64
+		wantUnit     string
+	}{
+		{name: "grams to kilograms", quantity: 1500, unit: "g", wantQuantity: 1.5, wantUnit: "kg"},
+		{name: "small masses stay in grams", quantity: 700, unit: "g", wantQuantity: 700, wantUnit: "g"},
+		{name: "millilitres to litres", quantity: 2500, unit: "ml", wantQuantity: 2.5, wantUnit: "l"},
+		{name: "teaspoons to cups", quantity: 24, unit: "tsp", wantQuantity: 0.5, wantUnit: "cup"},
+		{name: "teaspoons to tablespoons", quantity: 6, unit: "tsp", wantQuantity: 2, wantUnit: "tbsp"},
+		{name: "into another system", quantity: 1000, unit: "g", system: Imperial, wantQuantity: 2.2046226218487757, wantUnit: "lb"},
+		{name: "pieces are kept", quantity: 12, unit: "pc", wantQuantity: 12, wantUnit: "pc"},
+		{name: "temperatures are kept", quantity: 180, unit: "C", system: Imperial, wantQuantity: 180, wantUnit: "C"},
+		{name: "unknown units are kept", quantity: 3, unit: "sprig", wantQuantity: 3, wantUnit: "sprig"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quantity, unit := Simplify(tt.quantity, tt.unit, tt.system)
+			if math.Abs(quantity-tt.wantQuantity) > 1e-9 || unit != tt.wantUnit {
+				t.Errorf("Simplify(%v %s) = %v %s, want %v %s", tt.quantity, tt.unit, quantity, unit, tt.wantQuantity, tt.wantUnit)
+			}
+		})
+	}
+}
+
+func TestToSystem(t *testing.T) {
+	tests := []struct {
+		name         string
+		quantity     float64
+		unit         string
+		system       System
+		density      float64
+		wantQuantity float64
+		wantUnit     string
+	}{
+		{name: "same system is kept", quantity: 1500, unit: "g", system: Metric, wantQuantity: 1500, wantUnit: "g"},
+		{name: "ounces to grams", quantity: 16, unit: "oz", system: Metric, wantQuantity: 453.59237, wantUnit: "g"},
+		{name: "cups are weighed in metric", quantity: 1, unit: "cup", system: Metric, density: 0.5, wantQuantity: 118.29411825, wantUnit: "g"},
+		{name: "grams are measured in imperial", quantity: 236.5882365, unit: "g", system: Imperial, density: 1, wantQuantity: 1, wantUnit: "cup"},
+		{name: "no system", quantity: 2, unit: "cup", wantQuantity: 2, wantUnit: "cup"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quantity, unit := ToSystem(tt.quantity, tt.unit, tt.system, tt.density)
+			if math.Abs(quantity-tt.wantQuantity) > 1e-9 || unit != tt.wantUnit {
+				t.Errorf("ToSystem(%v %s) = %v %s, want %v %s", tt.quantity, tt.unit, quantity, unit, tt.wantQuantity, tt.wantUnit)
+			}
+		})
+	}
+}